@@ -5,6 +5,7 @@ go 1.20
 require (
 	github.com/consensys/gnark v0.7.2-0.20230411151857-a69acbb3a572
 	github.com/consensys/gnark-crypto v0.10.1-0.20230414110055-e500f2f0ff3a
+	golang.org/x/crypto v0.6.0
 )
 
 require (
@@ -23,7 +24,6 @@ require (
 	github.com/rs/zerolog v1.29.0 // indirect
 	github.com/stretchr/testify v1.8.2 // indirect
 	github.com/x448/float16 v0.8.4 // indirect
-	golang.org/x/crypto v0.6.0 // indirect
 	golang.org/x/exp v0.0.0-20230213192124-5e25df0256eb // indirect
 	golang.org/x/sys v0.5.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
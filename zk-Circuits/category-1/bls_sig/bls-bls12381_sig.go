@@ -1,25 +1,38 @@
 package bls_sig
 
 import (
+	"errors"
 	"fmt"
 
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/math/emulated"
 	bls12 "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bls12381"
+	"github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/internal/verifier"
 )
 
 type BLS_bls12 struct {
-	pr *bls12.Pairing
+	api frontend.API
+	pr  *bls12.Pairing
+	fp  *emulated.Field[emulated.BLS12381Fp]
+	cfg verifier.Config
 }
 
-func NewBLS_bls12(api frontend.API) (*BLS_bls12, error) {
+func NewBLS_bls12(api frontend.API, opts ...Option) (*BLS_bls12, error) {
 	pairing_bls12, err := bls12.NewPairing(api)
 	if err != nil {
 		return nil, fmt.Errorf("new pairing: %w", err)
 	}
+	fp, err := emulated.NewField[emulated.BLS12381Fp](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base field: %w", err)
+	}
+	cfg := verifier.NewConfig(opts...)
 	return &BLS_bls12{
-		pr: pairing_bls12,
+		api: api,
+		pr:  pairing_bls12,
+		fp:  fp,
+		cfg: cfg,
 	}, nil
 }
 
@@ -29,25 +42,191 @@ func NewBLS_bls12(api frontend.API) (*BLS_bls12, error) {
 // the size of (PK_1, ..., PK_n, signature) is dominated by the public keys
 // even for small n.
 // This variant is compatible with Ethereum PoS.
-func (bls BLS_bls12) VerifyBLS_bls12_v1(pubKey *bls12.G1Affine, sig, hash *bls12.G2Affine) {
-	// canonical generator of the trace-zero r-torsion on BLS12-381
+func (bls BLS_bls12) VerifyBLS_bls12_v1(pubKey *bls12.G1Affine, sig, hash *bls12.G2Affine) error {
+	if bls.cfg.SubgroupChecks {
+		bls.pr.AssertIsOnG1(pubKey)
+		bls.pr.AssertIsOnG2(sig)
+	}
+
+	G1neg := g1GenNeg_bls12()
+
+	// e(-G1, σ) * e(pubKey, H(m)) == 1, without final exponentiation
+	return bls.pr.PairingCheckResidue([]*bls12.G1Affine{&G1neg, pubKey}, []*bls12.G2Affine{sig, hash})
+}
+
+// VerifyBLS_bls12_v1Msg is the same as [BLS_bls12.VerifyBLS_bls12_v1] but
+// takes the message msg instead of its hash. The message is hashed to G2
+// in-circuit with the domain separation tag dst following the
+// BLS12381G2_XMD:SHA-256_SSWU_RO_ suite, so that the prover cannot choose the
+// hash point.
+//
+// The bytes of msg are given as frontend.Variable, as the std/math/uints
+// package is not available in the gnark version used here. Each of them is
+// asserted to be less than 2⁸ by the in-circuit SHA-256 (see
+// [bls12.Pairing.HashToG2]), so that msg can't be replaced by values equal to
+// its bytes modulo 2⁸.
+func (bls BLS_bls12) VerifyBLS_bls12_v1Msg(pubKey *bls12.G1Affine, sig *bls12.G2Affine, msg []frontend.Variable, dst []byte) error {
+	hash, err := bls.pr.HashToG2(msg, dst)
+	if err != nil {
+		return fmt.Errorf("hash to G2: %w", err)
+	}
+	return bls.VerifyBLS_bls12_v1(pubKey, sig, hash)
+}
+
+// VerifyAggregate verifies the aggregate signature sig of the messages whose
+// hashes to G2 are hashes, the i-th message being signed by pks[i]. It checks
+//
+//	e(-G1, σ) * ∏ᵢ e(pks[i], H(mᵢ)) == 1
+//
+// with n+1 Miller loops and a residue witness instead of the final
+// exponentiation (see [bls12.Pairing.PairingCheckResidue]).
+//
+// N.B: Against rogue-key attacks, the messages MUST be distinct (see
+// draft-irtf-cfrg-bls-signature, Section 3.1.1). This is not checked
+// in-circuit.
+func (bls BLS_bls12) VerifyAggregate(pks []*bls12.G1Affine, sig *bls12.G2Affine, hashes []*bls12.G2Affine) error {
+	if len(pks) == 0 || len(pks) != len(hashes) {
+		return errors.New("invalid inputs sizes")
+	}
+	if bls.cfg.SubgroupChecks {
+		for _, pk := range pks {
+			bls.pr.AssertIsOnG1(pk)
+		}
+		bls.pr.AssertIsOnG2(sig)
+	}
+
+	G1neg := g1GenNeg_bls12()
+
+	P := append([]*bls12.G1Affine{&G1neg}, pks...)
+	Q := append([]*bls12.G2Affine{sig}, hashes...)
+	return bls.pr.PairingCheckResidue(P, Q)
+}
+
+// VerifyFastAggregate verifies the aggregate signature sig of a common message
+// whose hash to G2 is hash, signed by all of pks. The public keys are summed
+// in-circuit so that it costs a single 2-pairing check:
+//
+//	e(-G1, σ) * e(∑ᵢ pks[i], H(m)) == 1
+//
+// The sum uses complete additions, so that duplicate public keys and partial
+// sums cancelling out are handled. The aggregate public key is asserted not to
+// be the point at infinity.
+//
+// N.B: Against rogue-key attacks, the public keys MUST come with a proof of
+// possession (see draft-irtf-cfrg-bls-signature, Section 3.3).
+func (bls BLS_bls12) VerifyFastAggregate(pks []*bls12.G1Affine, sig, hash *bls12.G2Affine) error {
+	if len(pks) == 0 {
+		return errors.New("no public key")
+	}
+	if bls.cfg.SubgroupChecks {
+		for _, pk := range pks {
+			bls.pr.AssertIsOnG1(pk)
+		}
+		bls.pr.AssertIsOnG2(sig)
+	}
+
+	aggPK := pks[0]
+	for _, pk := range pks[1:] {
+		aggPK = bls.pr.AddG1Complete(aggPK, pk)
+	}
+	bls.api.AssertIsEqual(bls.pr.IsInfinityG1(aggPK), 0)
+
+	G1neg := g1GenNeg_bls12()
+
+	// e(-G1, σ) * e(aggPK, H(m)) == 1, without final exponentiation
+	return bls.pr.PairingCheckResidue([]*bls12.G1Affine{&G1neg, aggPK}, []*bls12.G2Affine{sig, hash})
+}
+
+// BatchVerify verifies the signatures sigs[i] of the messages whose hashes to
+// G2 are hashes[i] under the public keys pks[i]. Instead of checking each
+// signature with its own pairing, it checks the random linear combination
+//
+//	e(-G1, ∑ᵢ [rᵢ]sigs[i]) * ∏ᵢ e([rᵢ]pks[i], hashes[i]) == 1
+//
+// with n+1 Miller loops and a residue witness instead of the final
+// exponentiation (see [bls12.Pairing.PairingCheckResidue]). The
+// coefficients rᵢ are derived in-circuit from 128-bit challenges on all the
+// inputs (Fiat-Shamir), hashed in their canonical (reduced) form.
+//
+// Compared to n separate verifications, it saves n-1 residue checks and n-1
+// Miller loops. Each signature still costs a Miller loop and two ~130-bit
+// scalar multiplications, in G1 and in G2, the latter being of the same order
+// as the Miller loop itself.
+//
+// The scalar multiplications and the sum of the signatures use incomplete
+// affine formulas, so that the public keys and signatures must be non-zero
+// and in the correct subgroups (see [WithSubgroupChecks]).
+func (bls BLS_bls12) BatchVerify(pks []*bls12.G1Affine, sigs, hashes []*bls12.G2Affine) error {
+	n := len(pks)
+	if n == 0 || n != len(sigs) || n != len(hashes) {
+		return errors.New("invalid inputs sizes")
+	}
+	if bls.cfg.SubgroupChecks {
+		for i := range pks {
+			bls.pr.AssertIsOnG1(pks[i])
+			bls.pr.AssertIsOnG2(sigs[i])
+		}
+	}
+
+	var transcript []frontend.Variable
+	for i := range pks {
+		transcript = verifier.AppendCanonical(bls.fp, transcript, &pks[i].X, &pks[i].Y)
+		for _, q := range []*bls12.G2Affine{sigs[i], hashes[i]} {
+			transcript = verifier.AppendCanonical(bls.fp, transcript, &q.X.A0, &q.X.A1, &q.Y.A0, &q.Y.A1)
+		}
+	}
+	rs, err := verifier.BatchChallenges(bls.api, n, transcript)
+	if err != nil {
+		return fmt.Errorf("challenges: %w", err)
+	}
+
+	P := make([]*bls12.G1Affine, n+1)
+	Q := make([]*bls12.G2Affine, n+1)
+	var aggSig *bls12.G2Affine
+	for i := range pks {
+		rSig := bls.pr.ScalarMulSignedBitsG2(sigs[i], rs[i])
+		if aggSig == nil {
+			aggSig = rSig
+		} else {
+			aggSig = bls.pr.AddG2(aggSig, rSig)
+		}
+		P[i+1] = bls.pr.ScalarMulSignedBitsG1(pks[i], rs[i])
+		Q[i+1] = hashes[i]
+	}
+	G1neg := g1GenNeg_bls12()
+	P[0], Q[0] = &G1neg, aggSig
+
+	return bls.pr.PairingCheckResidue(P, Q)
+}
+
+// Minimal-signature-size variant: signatures are points in G1, public keys are points in G2.
+func (bls BLS_bls12) VerifyBLS_bls12_v2(sig, hash *bls12.G1Affine, pubKey *bls12.G2Affine) error {
+	if bls.cfg.SubgroupChecks {
+		bls.pr.AssertIsOnG1(sig)
+		bls.pr.AssertIsOnG2(pubKey)
+	}
+
+	G2neg := g2GenNeg_bls12()
+
+	// e(σ, -G2) * e(H(m), pubKey) == 1, without final exponentiation
+	return bls.pr.PairingCheckResidue([]*bls12.G1Affine{sig, hash}, []*bls12.G2Affine{&G2neg, pubKey})
+}
+
+// g1GenNeg_bls12 returns the opposite of the canonical generator of the
+// trace-zero r-torsion on BLS12-381.
+func g1GenNeg_bls12() bls12.G1Affine {
 	_, _, g1, _ := bls12381.Generators()
 	g1.Neg(&g1)
-	G1neg := bls12.G1Affine{
+	return bls12.G1Affine{
 		X: emulated.ValueOf[emulated.BLS12381Fp](g1.X),
 		Y: emulated.ValueOf[emulated.BLS12381Fp](g1.Y),
 	}
-
-	// e(-G1, σ) * e(pubKey, H(m)) == 1
-	bls.pr.PairingCheck([]*bls12.G1Affine{&G1neg, pubKey}, []*bls12.G2Affine{sig, hash})
 }
 
-// Minimal-signature-size variant: signatures are points in G1, public keys are points in G2.
-func (bls BLS_bls12) VerifyBLS_bls12_v2(sig, hash *bls12.G1Affine, pubKey *bls12.G2Affine) {
-	pubKey.Y = *bls.pr.Ext2.Neg(&pubKey.Y)
-
-	// e(σ, G2) * e(H(m), -pubKey) == 1
-	f, _ := bls.pr.DoublePairFixedQ(hash, sig, pubKey)
-	one := bls.pr.Ext12.One()
-	bls.pr.Ext12.AssertIsEqual(f, one)
+// g2GenNeg_bls12 returns the opposite of the canonical generator of the
+// trace-zero r-torsion of the twist on BLS12-381.
+func g2GenNeg_bls12() bls12.G2Affine {
+	_, _, _, g2 := bls12381.Generators()
+	g2.Neg(&g2)
+	return bls12.NewG2Affine(g2)
 }
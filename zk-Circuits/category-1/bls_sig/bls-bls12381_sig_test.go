@@ -21,17 +21,18 @@ type blsVerifyCircuit_bls12_v1 struct {
 	PK  bls12.G1Affine
 	Sig bls12.G2Affine
 	HM  bls12.G2Affine
+
+	opts []Option
 }
 
 func (c *blsVerifyCircuit_bls12_v1) Define(api frontend.API) error {
-	bls, err := NewBLS_bls12(api)
+	bls, err := NewBLS_bls12(api, c.opts...)
 	if err != nil {
 		return fmt.Errorf("new pairing: %w", err)
 
 	}
 
-	bls.VerifyBLS_bls12_v1(&c.PK, &c.Sig, &c.HM)
-	return nil
+	return bls.VerifyBLS_bls12_v1(&c.PK, &c.Sig, &c.HM)
 
 }
 
@@ -71,6 +72,309 @@ func TestBLS_bls12_Verify_v1(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestBLS_bls12_Verify_v1_SubgroupChecks(t *testing.T) {
+	assert := test.NewAssert(t)
+	secret, err := rand.Int(rand.Reader, bls12381.ID.ScalarField())
+	assert.NoError(err)
+
+	var PK bls12381.G1Affine
+	PK.ScalarMultiplicationBase(secret)
+
+	HM, err := bls12381.HashToG2([]byte("Hello, World!"), []byte("test"))
+	assert.NoError(err)
+
+	var Sig bls12381.G2Affine
+	Sig.ScalarMultiplication(&HM, secret)
+
+	circuit := &blsVerifyCircuit_bls12_v1{opts: []Option{WithSubgroupChecks()}}
+	witness := &blsVerifyCircuit_bls12_v1{
+		PK:  bls12.NewG1Affine(PK),
+		Sig: bls12.NewG2Affine(Sig),
+		HM:  bls12.NewG2Affine(HM),
+	}
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// a point R on the twist but not in G2 passes the pairing check as the
+	// signature of the hash R under the generator of G1, since
+	// e(-G1, R) * e(G1, R) == 1 holds for any R. Only the subgroup check
+	// rejects it.
+	var b, x, y bls12381.E2
+	b.A0.SetUint64(4)
+	b.A1.SetUint64(4)
+	for {
+		_, err = x.SetRandom()
+		assert.NoError(err)
+		y.Square(&x).Mul(&y, &x).Add(&y, &b)
+		if y.Legendre() == 1 {
+			y.Sqrt(&y)
+			break
+		}
+	}
+	R := bls12381.G2Affine{X: x, Y: y}
+	assert.True(R.IsOnCurve())
+	assert.False(R.IsInSubGroup())
+	_, _, G1, _ := bls12381.Generators()
+	witness = &blsVerifyCircuit_bls12_v1{
+		PK:  bls12.NewG1Affine(G1),
+		Sig: bls12.NewG2Affine(R),
+		HM:  bls12.NewG2Affine(R),
+	}
+	err = test.IsSolved(&blsVerifyCircuit_bls12_v1{}, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+// v1 with the message hashed in-circuit
+type blsVerifyCircuit_bls12_v1Msg struct {
+	PK  bls12.G1Affine
+	Sig bls12.G2Affine
+	Msg []frontend.Variable
+}
+
+const dst_bls12 = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_"
+
+func (c *blsVerifyCircuit_bls12_v1Msg) Define(api frontend.API) error {
+	bls, err := NewBLS_bls12(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+
+	return bls.VerifyBLS_bls12_v1Msg(&c.PK, &c.Sig, c.Msg, []byte(dst_bls12))
+}
+
+func TestBLS_bls12_Verify_v1Msg(t *testing.T) {
+	assert := test.NewAssert(t)
+	secret, err := rand.Int(rand.Reader, bls12381.ID.ScalarField())
+	assert.NoError(err)
+
+	var PK bls12381.G1Affine
+	PK.ScalarMultiplicationBase(secret)
+
+	msg := []byte("Hello, World!")
+	HM, err := bls12381.HashToG2(msg, []byte(dst_bls12))
+	assert.NoError(err)
+
+	var Sig bls12381.G2Affine
+	Sig.ScalarMultiplication(&HM, secret)
+
+	circuit := &blsVerifyCircuit_bls12_v1Msg{Msg: make([]frontend.Variable, len(msg))}
+	witness := &blsVerifyCircuit_bls12_v1Msg{
+		PK:  bls12.NewG1Affine(PK),
+		Sig: bls12.NewG2Affine(Sig),
+		Msg: make([]frontend.Variable, len(msg)),
+	}
+	for i := range msg {
+		witness.Msg[i] = msg[i]
+	}
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the signature does not verify for another message
+	witness.Msg[0] = 'h'
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// nor for a message equal to msg modulo 2⁸
+	witness.Msg[0] = int(msg[0]) + 256
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+// v1 aggregate signatures
+type blsAggregateCircuit_bls12 struct {
+	PKs    []bls12.G1Affine
+	Sig    bls12.G2Affine
+	Hashes []bls12.G2Affine
+}
+
+func (c *blsAggregateCircuit_bls12) Define(api frontend.API) error {
+	bls, err := NewBLS_bls12(api, WithSubgroupChecks())
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	pks := make([]*bls12.G1Affine, len(c.PKs))
+	hashes := make([]*bls12.G2Affine, len(c.Hashes))
+	for i := range pks {
+		pks[i] = &c.PKs[i]
+		hashes[i] = &c.Hashes[i]
+	}
+
+	return bls.VerifyAggregate(pks, &c.Sig, hashes)
+}
+
+func TestBLS_bls12_VerifyAggregate(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n = 3
+
+	circuit := &blsAggregateCircuit_bls12{
+		PKs:    make([]bls12.G1Affine, n),
+		Hashes: make([]bls12.G2Affine, n),
+	}
+	witness := &blsAggregateCircuit_bls12{
+		PKs:    make([]bls12.G1Affine, n),
+		Hashes: make([]bls12.G2Affine, n),
+	}
+	var Sig bls12381.G2Jac
+	for i := 0; i < n; i++ {
+		secret, err := rand.Int(rand.Reader, ecc.BLS12_381.ScalarField())
+		assert.NoError(err)
+		var PK bls12381.G1Affine
+		PK.ScalarMultiplicationBase(secret)
+		HM, err := bls12381.HashToG2([]byte(fmt.Sprintf("message %d", i)), []byte(dst_bls12))
+		assert.NoError(err)
+		var sig bls12381.G2Jac
+		sig.FromAffine(&HM)
+		sig.ScalarMultiplication(&sig, secret)
+		Sig.AddAssign(&sig)
+
+		witness.PKs[i] = bls12.NewG1Affine(PK)
+		witness.Hashes[i] = bls12.NewG2Affine(HM)
+	}
+	var sig bls12381.G2Affine
+	sig.FromJacobian(&Sig)
+	witness.Sig = bls12.NewG2Affine(sig)
+
+	err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the aggregate signature does not verify if a message is swapped
+	witness.Hashes[0], witness.Hashes[1] = witness.Hashes[1], witness.Hashes[0]
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+// v1 aggregate signatures of a common message
+type blsFastAggregateCircuit_bls12 struct {
+	PKs []bls12.G1Affine
+	Sig bls12.G2Affine
+	HM  bls12.G2Affine
+}
+
+func (c *blsFastAggregateCircuit_bls12) Define(api frontend.API) error {
+	bls, err := NewBLS_bls12(api, WithSubgroupChecks())
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	pks := make([]*bls12.G1Affine, len(c.PKs))
+	for i := range pks {
+		pks[i] = &c.PKs[i]
+	}
+
+	return bls.VerifyFastAggregate(pks, &c.Sig, &c.HM)
+}
+
+func TestBLS_bls12_VerifyFastAggregate(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n = 4
+
+	HM, err := bls12381.HashToG2([]byte("Hello, World!"), []byte(dst_bls12))
+	assert.NoError(err)
+
+	circuit := &blsFastAggregateCircuit_bls12{PKs: make([]bls12.G1Affine, n)}
+	witness := &blsFastAggregateCircuit_bls12{
+		PKs: make([]bls12.G1Affine, n),
+		HM:  bls12.NewG2Affine(HM),
+	}
+	// σ = [∑ᵢ skᵢ]H(m)
+	aggSecret := new(big.Int)
+	secrets := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		secret, err := rand.Int(rand.Reader, ecc.BLS12_381.ScalarField())
+		assert.NoError(err)
+		secrets[i] = secret
+		aggSecret.Add(aggSecret, secret)
+		var PK bls12381.G1Affine
+		PK.ScalarMultiplicationBase(secret)
+		witness.PKs[i] = bls12.NewG1Affine(PK)
+	}
+	var Sig bls12381.G2Affine
+	Sig.ScalarMultiplication(&HM, aggSecret)
+	witness.Sig = bls12.NewG2Affine(Sig)
+
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the aggregate signature does not verify if a signer is missing
+	witness.PKs[n-1] = witness.PKs[0]
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// the same public key may appear twice, e.g. a signer signing twice
+	aggSecret.Sub(aggSecret, secrets[n-1])
+	aggSecret.Add(aggSecret, secrets[0])
+	Sig.ScalarMultiplication(&HM, aggSecret)
+	witness.Sig = bls12.NewG2Affine(Sig)
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+// v1 batch verification
+type blsBatchCircuit_bls12 struct {
+	PKs    []bls12.G1Affine
+	Sigs   []bls12.G2Affine
+	Hashes []bls12.G2Affine
+}
+
+func (c *blsBatchCircuit_bls12) Define(api frontend.API) error {
+	bls, err := NewBLS_bls12(api, WithSubgroupChecks())
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	pks := make([]*bls12.G1Affine, len(c.PKs))
+	sigs := make([]*bls12.G2Affine, len(c.Sigs))
+	hashes := make([]*bls12.G2Affine, len(c.Hashes))
+	for i := range pks {
+		pks[i] = &c.PKs[i]
+		sigs[i] = &c.Sigs[i]
+		hashes[i] = &c.Hashes[i]
+	}
+
+	return bls.BatchVerify(pks, sigs, hashes)
+}
+
+func TestBLS_bls12_BatchVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n = 3
+
+	newCircuit := func() *blsBatchCircuit_bls12 {
+		return &blsBatchCircuit_bls12{
+			PKs:    make([]bls12.G1Affine, n),
+			Sigs:   make([]bls12.G2Affine, n),
+			Hashes: make([]bls12.G2Affine, n),
+		}
+	}
+	witness := newCircuit()
+	sigs := make([]bls12381.G2Affine, n)
+	for i := 0; i < n; i++ {
+		secret, err := rand.Int(rand.Reader, ecc.BLS12_381.ScalarField())
+		assert.NoError(err)
+		var PK bls12381.G1Affine
+		PK.ScalarMultiplicationBase(secret)
+		HM, err := bls12381.HashToG2([]byte(fmt.Sprintf("message %d", i)), []byte(dst_bls12))
+		assert.NoError(err)
+		sigs[i].ScalarMultiplication(&HM, secret)
+
+		witness.PKs[i] = bls12.NewG1Affine(PK)
+		witness.Sigs[i] = bls12.NewG2Affine(sigs[i])
+		witness.Hashes[i] = bls12.NewG2Affine(HM)
+	}
+	err := test.IsSolved(newCircuit(), witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// two invalid signatures σ₀+Δ and σ₁-Δ whose sum is the sum of the valid
+	// ones are caught by the random coefficients.
+	_, _, _, g2 := bls12381.Generators()
+	var sig0, sig1 bls12381.G2Affine
+	sig0.Add(&sigs[0], &g2)
+	sig1.Sub(&sigs[1], &g2)
+	witness.Sigs[0] = bls12.NewG2Affine(sig0)
+	witness.Sigs[1] = bls12.NewG2Affine(sig1)
+	err = test.IsSolved(newCircuit(), witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
 // -----
 // v2 (Minimal-signature-size variant)
 type blsVerifyCircuit_bls12_v2 struct {
@@ -86,8 +390,7 @@ func (c *blsVerifyCircuit_bls12_v2) Define(api frontend.API) error {
 
 	}
 
-	bls.VerifyBLS_bls12_v2(&c.Sig, &c.HM, &c.PK)
-	return nil
+	return bls.VerifyBLS_bls12_v2(&c.Sig, &c.HM, &c.PK)
 
 }
 
@@ -137,6 +440,14 @@ func BenchmarkBLS2Verify_v1(b *testing.B) {
 	fmt.Println("⏱️  BLS signature verifier on BLS12-381 in a BN254 R1CS circuit (v1): ", p.NbConstraints())
 }
 
+func BenchmarkBLS2Verify_v1Msg(b *testing.B) {
+	c := blsVerifyCircuit_bls12_v1Msg{Msg: make([]frontend.Variable, 32)}
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  BLS signature verifier on BLS12-381 with hash-to-G2 (32-byte message) in a BN254 R1CS circuit (v1): ", p.NbConstraints())
+}
+
 func BenchmarkBLS2Verify_v2(b *testing.B) {
 	var c blsVerifyCircuit_bls12_v2
 	p := profile.Start()
@@ -144,3 +455,16 @@ func BenchmarkBLS2Verify_v2(b *testing.B) {
 	p.Stop()
 	fmt.Println("⏱️  BLS signature verifier on BLS12-381 in a BN254 R1CS circuit (v2): ", p.NbConstraints())
 }
+
+func BenchmarkBLS2BatchVerify(b *testing.B) {
+	const n = 4
+	c := blsBatchCircuit_bls12{
+		PKs:    make([]bls12.G1Affine, n),
+		Sigs:   make([]bls12.G2Affine, n),
+		Hashes: make([]bls12.G2Affine, n),
+	}
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  Batch verifier of 4 BLS signatures on BLS12-381 in a BN254 R1CS circuit (v1): ", p.NbConstraints())
+}
@@ -1,25 +1,38 @@
 package bls_sig
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/math/emulated"
 	bn "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bn254"
+	"github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/internal/verifier"
 )
 
 type BLS_bn struct {
-	pr *bn.Pairing
+	api frontend.API
+	pr  *bn.Pairing
+	fp  *emulated.Field[emulated.BN254Fp]
+	cfg verifier.Config
 }
 
-func NewBLS_bn(api frontend.API) (*BLS_bn, error) {
+func NewBLS_bn(api frontend.API, opts ...Option) (*BLS_bn, error) {
 	pairing_bn, err := bn.NewPairing(api)
 	if err != nil {
 		return nil, fmt.Errorf("new pairing: %w", err)
 	}
+	fp, err := emulated.NewField[emulated.BN254Fp](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base field: %w", err)
+	}
+	cfg := verifier.NewConfig(opts...)
 	return &BLS_bn{
-		pr: pairing_bn,
+		api: api,
+		pr:  pairing_bn,
+		fp:  fp,
+		cfg: cfg,
 	}, nil
 }
 
@@ -29,21 +42,163 @@ func NewBLS_bn(api frontend.API) (*BLS_bn, error) {
 // the size of (PK_1, ..., PK_n, signature) is dominated by the public keys
 // even for small n.
 // This variant is compatible with Ethereum PoS.
-func (bls BLS_bn) VerifyBLS_bn_v1(pubKey *bn.G1Affine, sig, hash *bn.G2Affine) {
-	// canonical generator of the trace-zero r-torsion on BN254
-	_, _, g1, _ := bn254.Generators()
-	g1.Neg(&g1)
-	G1neg := bn.G1Affine{
-		X: emulated.ValueOf[emulated.BN254Fp](g1.X),
-		Y: emulated.ValueOf[emulated.BN254Fp](g1.Y),
+func (bls BLS_bn) VerifyBLS_bn_v1(pubKey *bn.G1Affine, sig, hash *bn.G2Affine) error {
+	if bls.cfg.SubgroupChecks {
+		bls.pr.AssertIsOnG1(pubKey)
+		bls.pr.AssertIsOnG2(sig)
+	}
+
+	G1neg := g1GenNeg_bn()
+
+	// e(-G1, σ) * e(pubKey, H(m)) == 1, without final exponentiation
+	return bls.pr.PairingCheckResidue([]*bn.G1Affine{&G1neg, pubKey}, []*bn.G2Affine{sig, hash})
+}
+
+// VerifyBLS_bn_v1Msg is the same as [BLS_bn.VerifyBLS_bn_v1] but takes the
+// message msg instead of its hash. The message is hashed to G2 in-circuit with
+// the domain separation tag dst following the BN254G2_XMD:SHA-256_SVDW_RO_
+// suite, so that the prover cannot choose the hash point.
+func (bls BLS_bn) VerifyBLS_bn_v1Msg(pubKey *bn.G1Affine, sig *bn.G2Affine, msg []frontend.Variable, dst []byte) error {
+	hash, err := bls.pr.HashToG2(msg, dst)
+	if err != nil {
+		return fmt.Errorf("hash to G2: %w", err)
+	}
+	return bls.VerifyBLS_bn_v1(pubKey, sig, hash)
+}
+
+// VerifyAggregate verifies the aggregate signature sig of the messages whose
+// hashes to G2 are hashes, the i-th message being signed by pks[i]. It checks
+//
+//	e(-G1, σ) * ∏ᵢ e(pks[i], H(mᵢ)) == 1
+//
+// with n+1 Miller loops and a residue witness instead of the final
+// exponentiation (see [bn.Pairing.PairingCheckResidue]).
+//
+// N.B: Against rogue-key attacks, the messages MUST be distinct (see
+// draft-irtf-cfrg-bls-signature, Section 3.1.1). This is not checked
+// in-circuit.
+func (bls BLS_bn) VerifyAggregate(pks []*bn.G1Affine, sig *bn.G2Affine, hashes []*bn.G2Affine) error {
+	if len(pks) == 0 || len(pks) != len(hashes) {
+		return errors.New("invalid inputs sizes")
+	}
+	if bls.cfg.SubgroupChecks {
+		for _, pk := range pks {
+			bls.pr.AssertIsOnG1(pk)
+		}
+		bls.pr.AssertIsOnG2(sig)
+	}
+
+	G1neg := g1GenNeg_bn()
+
+	P := append([]*bn.G1Affine{&G1neg}, pks...)
+	Q := append([]*bn.G2Affine{sig}, hashes...)
+	return bls.pr.PairingCheckResidue(P, Q)
+}
+
+// VerifyFastAggregate verifies the aggregate signature sig of a common message
+// whose hash to G2 is hash, signed by all of pks. The public keys are summed
+// in-circuit so that it costs a single 2-pairing check:
+//
+//	e(-G1, σ) * e(∑ᵢ pks[i], H(m)) == 1
+//
+// The sum uses complete additions, so that duplicate public keys and partial
+// sums cancelling out are handled. The aggregate public key is asserted not to
+// be the point at infinity.
+//
+// N.B: Against rogue-key attacks, the public keys MUST come with a proof of
+// possession (see draft-irtf-cfrg-bls-signature, Section 3.3).
+func (bls BLS_bn) VerifyFastAggregate(pks []*bn.G1Affine, sig, hash *bn.G2Affine) error {
+	if len(pks) == 0 {
+		return errors.New("no public key")
+	}
+	if bls.cfg.SubgroupChecks {
+		for _, pk := range pks {
+			bls.pr.AssertIsOnG1(pk)
+		}
+		bls.pr.AssertIsOnG2(sig)
+	}
+
+	aggPK := pks[0]
+	for _, pk := range pks[1:] {
+		aggPK = bls.pr.AddG1Complete(aggPK, pk)
+	}
+	bls.api.AssertIsEqual(bls.pr.IsInfinityG1(aggPK), 0)
+
+	G1neg := g1GenNeg_bn()
+
+	// e(-G1, σ) * e(aggPK, H(m)) == 1, without final exponentiation
+	return bls.pr.PairingCheckResidue([]*bn.G1Affine{&G1neg, aggPK}, []*bn.G2Affine{sig, hash})
+}
+
+// BatchVerify verifies the signatures sigs[i] of the messages whose hashes to
+// G2 are hashes[i] under the public keys pks[i]. Instead of checking each
+// signature with its own pairing, it checks the random linear combination
+//
+//	e(-G1, ∑ᵢ [rᵢ]sigs[i]) * ∏ᵢ e([rᵢ]pks[i], hashes[i]) == 1
+//
+// with n+1 Miller loops and a residue witness instead of the final
+// exponentiation (see [bn.Pairing.PairingCheckResidue]). The
+// coefficients rᵢ are derived in-circuit from 128-bit challenges on all the
+// inputs (Fiat-Shamir), hashed in their canonical (reduced) form.
+//
+// Compared to n separate verifications, it saves n-1 residue checks and n-1
+// Miller loops. Each signature still costs a Miller loop and two ~130-bit
+// scalar multiplications, in G1 and in G2, the latter being of the same order
+// as the Miller loop itself.
+//
+// The scalar multiplications and the sum of the signatures use incomplete
+// affine formulas, so that the public keys and signatures must be non-zero
+// and in the correct subgroups (see [WithSubgroupChecks]).
+func (bls BLS_bn) BatchVerify(pks []*bn.G1Affine, sigs, hashes []*bn.G2Affine) error {
+	n := len(pks)
+	if n == 0 || n != len(sigs) || n != len(hashes) {
+		return errors.New("invalid inputs sizes")
+	}
+	if bls.cfg.SubgroupChecks {
+		for i := range pks {
+			bls.pr.AssertIsOnG1(pks[i])
+			bls.pr.AssertIsOnG2(sigs[i])
+		}
+	}
+
+	var transcript []frontend.Variable
+	for i := range pks {
+		transcript = verifier.AppendCanonical(bls.fp, transcript, &pks[i].X, &pks[i].Y)
+		for _, q := range []*bn.G2Affine{sigs[i], hashes[i]} {
+			transcript = verifier.AppendCanonical(bls.fp, transcript, &q.X.A0, &q.X.A1, &q.Y.A0, &q.Y.A1)
+		}
+	}
+	rs, err := verifier.BatchChallenges(bls.api, n, transcript)
+	if err != nil {
+		return fmt.Errorf("challenges: %w", err)
 	}
 
-	// e(-G1, σ) * e(pubKey, H(m)) == 1
-	bls.pr.PairingCheck([]*bn.G1Affine{&G1neg, pubKey}, []*bn.G2Affine{sig, hash})
+	P := make([]*bn.G1Affine, n+1)
+	Q := make([]*bn.G2Affine, n+1)
+	var aggSig *bn.G2Affine
+	for i := range pks {
+		rSig := bls.pr.ScalarMulSignedBitsG2(sigs[i], rs[i])
+		if aggSig == nil {
+			aggSig = rSig
+		} else {
+			aggSig = bls.pr.AddG2(aggSig, rSig)
+		}
+		P[i+1] = bls.pr.ScalarMulSignedBitsG1(pks[i], rs[i])
+		Q[i+1] = hashes[i]
+	}
+	G1neg := g1GenNeg_bn()
+	P[0], Q[0] = &G1neg, aggSig
+
+	return bls.pr.PairingCheckResidue(P, Q)
 }
 
 // Minimal-signature-size variant: signatures are points in G1, public keys are points in G2.
-func (bls BLS_bn) VerifyBLS_bn_v2(sig, hash *bn.G1Affine, pubKey *bn.G2Affine) {
+func (bls BLS_bn) VerifyBLS_bn_v2(sig, hash *bn.G1Affine, pubKey *bn.G2Affine) error {
+	if bls.cfg.SubgroupChecks {
+		bls.pr.AssertIsOnG1(sig)
+		bls.pr.AssertIsOnG2(pubKey)
+	}
+
 	// canonical generator of the trace-zero r-torsion on BN254
 	_, _, _, g2 := bn254.Generators()
 	g2.Neg(&g2)
@@ -58,6 +213,29 @@ func (bls BLS_bn) VerifyBLS_bn_v2(sig, hash *bn.G1Affine, pubKey *bn.G2Affine) {
 		},
 	}
 
-	// e(σ, -G2) * e(H(m), pubKey) == 1
-	bls.pr.PairingCheck([]*bn.G1Affine{sig, hash}, []*bn.G2Affine{&G2neg, pubKey})
+	// e(σ, -G2) * e(H(m), pubKey) == 1, without final exponentiation
+	return bls.pr.PairingCheckResidue([]*bn.G1Affine{sig, hash}, []*bn.G2Affine{&G2neg, pubKey})
+}
+
+// VerifyBLS_bn_v2Msg is the same as [BLS_bn.VerifyBLS_bn_v2] but takes the
+// message msg instead of its hash. The message is hashed to G1 in-circuit with
+// the domain separation tag dst following the BN254G1_XMD:SHA-256_SVDW_RO_
+// suite, so that the prover cannot choose the hash point.
+func (bls BLS_bn) VerifyBLS_bn_v2Msg(sig *bn.G1Affine, pubKey *bn.G2Affine, msg []frontend.Variable, dst []byte) error {
+	hash, err := bls.pr.HashToG1(msg, dst)
+	if err != nil {
+		return fmt.Errorf("hash to G1: %w", err)
+	}
+	return bls.VerifyBLS_bn_v2(sig, hash, pubKey)
+}
+
+// g1GenNeg_bn returns the opposite of the canonical generator of the
+// trace-zero r-torsion on BN254.
+func g1GenNeg_bn() bn.G1Affine {
+	_, _, g1, _ := bn254.Generators()
+	g1.Neg(&g1)
+	return bn.G1Affine{
+		X: emulated.ValueOf[emulated.BN254Fp](g1.X),
+		Y: emulated.ValueOf[emulated.BN254Fp](g1.Y),
+	}
 }
@@ -30,8 +30,7 @@ func (c *blsVerifyCircuit_bn_v1) Define(api frontend.API) error {
 
 	}
 
-	bls.VerifyBLS_bn_v1(&c.PK, &c.Sig, &c.HM)
-	return nil
+	return bls.VerifyBLS_bn_v1(&c.PK, &c.Sig, &c.HM)
 
 }
 
@@ -71,6 +70,250 @@ func TestBLS_bn_Verify_v1(t *testing.T) {
 	assert.NoError(err)
 }
 
+// v1 with the message hashed in-circuit
+type blsVerifyCircuit_bn_v1Msg struct {
+	PK  bn.G1Affine
+	Sig bn.G2Affine
+	Msg []frontend.Variable
+}
+
+const dst_bn_v1 = "BLS_SIG_BN254G2_XMD:SHA-256_SVDW_RO_NUL_"
+
+func (c *blsVerifyCircuit_bn_v1Msg) Define(api frontend.API) error {
+	bls, err := NewBLS_bn(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+
+	return bls.VerifyBLS_bn_v1Msg(&c.PK, &c.Sig, c.Msg, []byte(dst_bn_v1))
+}
+
+func TestBLS_bn_Verify_v1Msg(t *testing.T) {
+	assert := test.NewAssert(t)
+	secret, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	var PK bn254.G1Affine
+	PK.ScalarMultiplicationBase(secret)
+
+	msg := []byte("Hello, World!")
+	HM, err := bn254.HashToG2(msg, []byte(dst_bn_v1))
+	assert.NoError(err)
+
+	var Sig bn254.G2Affine
+	Sig.ScalarMultiplication(&HM, secret)
+
+	circuit := &blsVerifyCircuit_bn_v1Msg{Msg: make([]frontend.Variable, len(msg))}
+	witness := &blsVerifyCircuit_bn_v1Msg{
+		PK:  bn.NewG1Affine(PK),
+		Sig: bn.NewG2Affine(Sig),
+		Msg: make([]frontend.Variable, len(msg)),
+	}
+	for i := range msg {
+		witness.Msg[i] = msg[i]
+	}
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the signature does not verify for another message
+	witness.Msg[0] = 'h'
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+// v1 aggregate signatures
+type blsAggregateCircuit_bn struct {
+	PKs    []bn.G1Affine
+	Sig    bn.G2Affine
+	Hashes []bn.G2Affine
+}
+
+func (c *blsAggregateCircuit_bn) Define(api frontend.API) error {
+	bls, err := NewBLS_bn(api, WithSubgroupChecks())
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	pks := make([]*bn.G1Affine, len(c.PKs))
+	hashes := make([]*bn.G2Affine, len(c.Hashes))
+	for i := range pks {
+		pks[i] = &c.PKs[i]
+		hashes[i] = &c.Hashes[i]
+	}
+
+	return bls.VerifyAggregate(pks, &c.Sig, hashes)
+}
+
+func TestBLS_bn_VerifyAggregate(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n = 3
+
+	circuit := &blsAggregateCircuit_bn{
+		PKs:    make([]bn.G1Affine, n),
+		Hashes: make([]bn.G2Affine, n),
+	}
+	witness := &blsAggregateCircuit_bn{
+		PKs:    make([]bn.G1Affine, n),
+		Hashes: make([]bn.G2Affine, n),
+	}
+	var Sig bn254.G2Jac
+	for i := 0; i < n; i++ {
+		secret, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
+		assert.NoError(err)
+		var PK bn254.G1Affine
+		PK.ScalarMultiplicationBase(secret)
+		HM, err := bn254.HashToG2([]byte(fmt.Sprintf("message %d", i)), []byte(dst_bn_v1))
+		assert.NoError(err)
+		var sig bn254.G2Jac
+		sig.FromAffine(&HM)
+		sig.ScalarMultiplication(&sig, secret)
+		Sig.AddAssign(&sig)
+
+		witness.PKs[i] = bn.NewG1Affine(PK)
+		witness.Hashes[i] = bn.NewG2Affine(HM)
+	}
+	var sig bn254.G2Affine
+	sig.FromJacobian(&Sig)
+	witness.Sig = bn.NewG2Affine(sig)
+
+	err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the aggregate signature does not verify if a message is swapped
+	witness.Hashes[0], witness.Hashes[1] = witness.Hashes[1], witness.Hashes[0]
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+// v1 aggregate signatures of a common message
+type blsFastAggregateCircuit_bn struct {
+	PKs []bn.G1Affine
+	Sig bn.G2Affine
+	HM  bn.G2Affine
+}
+
+func (c *blsFastAggregateCircuit_bn) Define(api frontend.API) error {
+	bls, err := NewBLS_bn(api, WithSubgroupChecks())
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	pks := make([]*bn.G1Affine, len(c.PKs))
+	for i := range pks {
+		pks[i] = &c.PKs[i]
+	}
+
+	return bls.VerifyFastAggregate(pks, &c.Sig, &c.HM)
+}
+
+func TestBLS_bn_VerifyFastAggregate(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n = 4
+
+	HM, err := bn254.HashToG2([]byte("Hello, World!"), []byte(dst_bn_v1))
+	assert.NoError(err)
+
+	circuit := &blsFastAggregateCircuit_bn{PKs: make([]bn.G1Affine, n)}
+	witness := &blsFastAggregateCircuit_bn{
+		PKs: make([]bn.G1Affine, n),
+		HM:  bn.NewG2Affine(HM),
+	}
+	// σ = [∑ᵢ skᵢ]H(m)
+	aggSecret := new(big.Int)
+	secrets := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		secret, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
+		assert.NoError(err)
+		secrets[i] = secret
+		aggSecret.Add(aggSecret, secret)
+		var PK bn254.G1Affine
+		PK.ScalarMultiplicationBase(secret)
+		witness.PKs[i] = bn.NewG1Affine(PK)
+	}
+	var Sig bn254.G2Affine
+	Sig.ScalarMultiplication(&HM, aggSecret)
+	witness.Sig = bn.NewG2Affine(Sig)
+
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the aggregate signature does not verify if a signer is missing
+	witness.PKs[n-1] = witness.PKs[0]
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// the same public key may appear twice, e.g. a signer signing twice
+	aggSecret.Sub(aggSecret, secrets[n-1])
+	aggSecret.Add(aggSecret, secrets[0])
+	Sig.ScalarMultiplication(&HM, aggSecret)
+	witness.Sig = bn.NewG2Affine(Sig)
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+// v1 batch verification
+type blsBatchCircuit_bn struct {
+	PKs    []bn.G1Affine
+	Sigs   []bn.G2Affine
+	Hashes []bn.G2Affine
+}
+
+func (c *blsBatchCircuit_bn) Define(api frontend.API) error {
+	bls, err := NewBLS_bn(api, WithSubgroupChecks())
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	pks := make([]*bn.G1Affine, len(c.PKs))
+	sigs := make([]*bn.G2Affine, len(c.Sigs))
+	hashes := make([]*bn.G2Affine, len(c.Hashes))
+	for i := range pks {
+		pks[i] = &c.PKs[i]
+		sigs[i] = &c.Sigs[i]
+		hashes[i] = &c.Hashes[i]
+	}
+
+	return bls.BatchVerify(pks, sigs, hashes)
+}
+
+func TestBLS_bn_BatchVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n = 3
+
+	newCircuit := func() *blsBatchCircuit_bn {
+		return &blsBatchCircuit_bn{
+			PKs:    make([]bn.G1Affine, n),
+			Sigs:   make([]bn.G2Affine, n),
+			Hashes: make([]bn.G2Affine, n),
+		}
+	}
+	witness := newCircuit()
+	sigs := make([]bn254.G2Affine, n)
+	for i := 0; i < n; i++ {
+		secret, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
+		assert.NoError(err)
+		var PK bn254.G1Affine
+		PK.ScalarMultiplicationBase(secret)
+		HM, err := bn254.HashToG2([]byte(fmt.Sprintf("message %d", i)), []byte(dst_bn_v1))
+		assert.NoError(err)
+		sigs[i].ScalarMultiplication(&HM, secret)
+
+		witness.PKs[i] = bn.NewG1Affine(PK)
+		witness.Sigs[i] = bn.NewG2Affine(sigs[i])
+		witness.Hashes[i] = bn.NewG2Affine(HM)
+	}
+	err := test.IsSolved(newCircuit(), witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// two invalid signatures σ₀+Δ and σ₁-Δ whose sum is the sum of the valid
+	// ones are caught by the random coefficients.
+	_, _, _, g2 := bn254.Generators()
+	var sig0, sig1 bn254.G2Affine
+	sig0.Add(&sigs[0], &g2)
+	sig1.Sub(&sigs[1], &g2)
+	witness.Sigs[0] = bn.NewG2Affine(sig0)
+	witness.Sigs[1] = bn.NewG2Affine(sig1)
+	err = test.IsSolved(newCircuit(), witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
 // -----
 // v2 (Minimal-signature-size variant)
 type blsVerifyCircuit_bn_v2 struct {
@@ -86,8 +329,7 @@ func (c *blsVerifyCircuit_bn_v2) Define(api frontend.API) error {
 
 	}
 
-	bls.VerifyBLS_bn_v2(&c.Sig, &c.HM, &c.PK)
-	return nil
+	return bls.VerifyBLS_bn_v2(&c.Sig, &c.HM, &c.PK)
 
 }
 
@@ -128,6 +370,58 @@ func TestBLS_bn_Verify_v2(t *testing.T) {
 	assert.NoError(err)
 }
 
+// v2 with the message hashed in-circuit
+type blsVerifyCircuit_bn_v2Msg struct {
+	Sig bn.G1Affine
+	PK  bn.G2Affine
+	Msg []frontend.Variable
+}
+
+const dst_bn_v2 = "BLS_SIG_BN254G1_XMD:SHA-256_SVDW_RO_NUL_"
+
+func (c *blsVerifyCircuit_bn_v2Msg) Define(api frontend.API) error {
+	bls, err := NewBLS_bn(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+
+	return bls.VerifyBLS_bn_v2Msg(&c.Sig, &c.PK, c.Msg, []byte(dst_bn_v2))
+}
+
+func TestBLS_bn_Verify_v2Msg(t *testing.T) {
+	assert := test.NewAssert(t)
+	secret, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	var PK bn254.G2Affine
+	_, _, _, g2 := bn254.Generators()
+	PK.ScalarMultiplication(&g2, secret)
+
+	msg := []byte("Hello, World!")
+	HM, err := bn254.HashToG1(msg, []byte(dst_bn_v2))
+	assert.NoError(err)
+
+	var Sig bn254.G1Affine
+	Sig.ScalarMultiplication(&HM, secret)
+
+	circuit := &blsVerifyCircuit_bn_v2Msg{Msg: make([]frontend.Variable, len(msg))}
+	witness := &blsVerifyCircuit_bn_v2Msg{
+		Sig: bn.NewG1Affine(Sig),
+		PK:  bn.NewG2Affine(PK),
+		Msg: make([]frontend.Variable, len(msg)),
+	}
+	for i := range msg {
+		witness.Msg[i] = msg[i]
+	}
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the signature does not verify for another message
+	witness.Msg[0] = 'h'
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
 // bench
 func BenchmarkBLSVerify_v1(b *testing.B) {
 	var c blsVerifyCircuit_bn_v1
@@ -144,3 +438,16 @@ func BenchmarkBLSVerify_v2(b *testing.B) {
 	p.Stop()
 	fmt.Println("⏱️  BLS signature verifier on BN254 in a BN254 R1CS circuit (v2): ", p.NbConstraints())
 }
+
+func BenchmarkBLSBatchVerify(b *testing.B) {
+	const n = 4
+	c := blsBatchCircuit_bn{
+		PKs:    make([]bn.G1Affine, n),
+		Sigs:   make([]bn.G2Affine, n),
+		Hashes: make([]bn.G2Affine, n),
+	}
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  Batch verifier of 4 BLS signatures on BN254 in a BN254 R1CS circuit (v1): ", p.NbConstraints())
+}
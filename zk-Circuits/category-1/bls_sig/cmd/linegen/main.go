@@ -0,0 +1,168 @@
+// Command linegen generates the precomputed lines of the Miller loop for a
+// fixed G2 argument, as used by MillerLoopFixedQ in the pairing_bn254 and
+// pairing_bls12381 packages. By default the lines are computed for the
+// canonical generator of G2 and written to the PrecomputedLines variable.
+//
+// Usage:
+//
+//	linegen -curve bn254 [-q hex] [-var name] [-o file]
+//
+// where -q is the hex-encoded compressed G2 point.
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	pairing_bls12381 "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bls12381"
+	pairing_bn254 "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bn254"
+)
+
+func main() {
+	curve := flag.String("curve", "bn254", "curve of the pairing: bn254 or bls12-381")
+	q := flag.String("q", "", "hex-encoded compressed G2 point (default: the generator of G2)")
+	name := flag.String("var", "PrecomputedLines", "name of the generated variable")
+	out := flag.String("o", "", "output file (default: stdout)")
+	flag.Parse()
+
+	src, err := generate(*curve, *q, *name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(*out, src, 0o644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// lineTable holds the decimal values of the coefficients of the precomputed
+// lines. Empty entries are not used by the Miller loop.
+type lineTable struct {
+	pkg   string
+	field string
+	// loopLen is the number of iterations of the Miller loop. The entries
+	// after it are the lines of the final additions.
+	loopLen int
+	lines   [4][][2]string
+}
+
+// generate returns the formatted Go source of the precomputed lines of the
+// curve for the hex-encoded G2 point q, or the generator of G2 if q is empty.
+func generate(curve, q, name string) ([]byte, error) {
+	var t lineTable
+	var err error
+	switch curve {
+	case "bn254":
+		t, err = bn254Lines(q)
+	case "bls12-381":
+		t, err = bls12381Lines(q)
+	default:
+		return nil, fmt.Errorf("unknown curve %q", curve)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by linegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", t.pkg)
+	fmt.Fprintf(&buf, "import \"github.com/consensys/gnark/std/math/emulated\"\n\n")
+	if q == "" {
+		fmt.Fprintf(&buf, "// %s are the lines of the Miller loop for the canonical\n// generator of G2.\n", name)
+	} else {
+		fmt.Fprintf(&buf, "// %s are the lines of the Miller loop for the fixed G2\n// argument %s.\n", name, q)
+	}
+	fmt.Fprintf(&buf, "var %s FixedLines\n\n", name)
+	fmt.Fprintf(&buf, "func init() {\n")
+	for i := t.loopLen - 1; i >= 0; i-- {
+		fmt.Fprintf(&buf, "\t// i = %d\n", i)
+		t.writeLines(&buf, name, i)
+	}
+	if len(t.lines[0]) > t.loopLen {
+		fmt.Fprintf(&buf, "\n\t// lines ℓ_{[6x₀+2]Q,π(Q)} and ℓ_{[6x₀+2]Q+π(Q),-π²(Q)}\n")
+		for i := t.loopLen; i < len(t.lines[0]); i++ {
+			t.writeLines(&buf, name, i)
+		}
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// writeLines writes the assignments of the non-empty entries of the table at
+// index i.
+func (t *lineTable) writeLines(buf *bytes.Buffer, name string, i int) {
+	for k := range t.lines {
+		e := t.lines[k][i]
+		if e[0] == "" {
+			continue
+		}
+		fmt.Fprintf(buf, "\t%s[%d][%d].A0 = emulated.ValueOf[emulated.%s](\"%s\")\n", name, k, i, t.field, e[0])
+		fmt.Fprintf(buf, "\t%s[%d][%d].A1 = emulated.ValueOf[emulated.%s](\"%s\")\n", name, k, i, t.field, e[1])
+	}
+}
+
+func bn254Lines(q string) (lineTable, error) {
+	_, _, _, Q := bn254.Generators()
+	if q != "" {
+		b, err := hex.DecodeString(q)
+		if err != nil {
+			return lineTable{}, fmt.Errorf("decode G2 point: %w", err)
+		}
+		if _, err := Q.SetBytes(b); err != nil {
+			return lineTable{}, fmt.Errorf("set G2 point: %w", err)
+		}
+		if Q.IsInfinity() {
+			return lineTable{}, fmt.Errorf("G2 point is the point at infinity")
+		}
+	}
+	lines := pairing_bn254.PrecomputeNativeLines(Q)
+	t := lineTable{pkg: "pairing_bn254", field: "BN254Fp", loopLen: 65}
+	for k := range lines {
+		t.lines[k] = make([][2]string, len(lines[k]))
+		for i, e := range lines[k] {
+			if !e.IsZero() {
+				t.lines[k][i] = [2]string{e.A0.String(), e.A1.String()}
+			}
+		}
+	}
+	return t, nil
+}
+
+func bls12381Lines(q string) (lineTable, error) {
+	_, _, _, Q := bls12381.Generators()
+	if q != "" {
+		b, err := hex.DecodeString(q)
+		if err != nil {
+			return lineTable{}, fmt.Errorf("decode G2 point: %w", err)
+		}
+		if _, err := Q.SetBytes(b); err != nil {
+			return lineTable{}, fmt.Errorf("set G2 point: %w", err)
+		}
+		if Q.IsInfinity() {
+			return lineTable{}, fmt.Errorf("G2 point is the point at infinity")
+		}
+	}
+	lines := pairing_bls12381.PrecomputeNativeLines(Q)
+	t := lineTable{pkg: "pairing_bls12381", field: "BLS12381Fp", loopLen: 63}
+	for k := range lines {
+		t.lines[k] = make([][2]string, len(lines[k]))
+		for i, e := range lines[k] {
+			if !e.IsZero() {
+				t.lines[k][i] = [2]string{e.A0.String(), e.A1.String()}
+			}
+		}
+	}
+	return t, nil
+}
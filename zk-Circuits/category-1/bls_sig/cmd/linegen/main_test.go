@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestPrecomputationsUpToDate(t *testing.T) {
+	for _, tc := range []struct {
+		curve, file string
+	}{
+		{"bn254", "../../pairing_bn254/precomputations.go"},
+		{"bls12-381", "../../pairing_bls12381/precomputations.go"},
+	} {
+		t.Run(tc.curve, func(t *testing.T) {
+			want, err := generate(tc.curve, "", "PrecomputedLines")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(tc.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("%s is out of date, run go generate", tc.file)
+			}
+		})
+	}
+}
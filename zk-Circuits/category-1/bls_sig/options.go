@@ -0,0 +1,15 @@
+package bls_sig
+
+import "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/internal/verifier"
+
+// Option allows to configure the BLS signature verifiers.
+type Option = verifier.Option
+
+// WithSubgroupChecks enables the in-circuit on-curve and subgroup membership
+// checks of the public key and of the signature. It must be set when the
+// public key or the signature are not trusted (e.g. Ethereum consensus
+// signatures) as the pairing does not check that its inputs are in the correct
+// subgroups.
+func WithSubgroupChecks() Option {
+	return verifier.WithSubgroupChecks()
+}
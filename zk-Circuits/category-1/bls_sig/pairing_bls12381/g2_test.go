@@ -0,0 +1,107 @@
+package pairing_bls12381
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+// randomTwistPoint returns a point on the twist which is (with overwhelming
+// probability) not in G2.
+func randomTwistPoint(assert *test.Assert) bls12381.G2Affine {
+	var b, x, y bls12381.E2
+	b.A0.SetUint64(4)
+	b.A1.SetUint64(4)
+	for {
+		_, err := x.SetRandom()
+		assert.NoError(err)
+		y.Square(&x).Mul(&y, &x).Add(&y, &b)
+		if y.Legendre() == 1 {
+			y.Sqrt(&y)
+			break
+		}
+	}
+	q := bls12381.G2Affine{X: x, Y: y}
+	assert.True(q.IsOnCurve())
+	assert.False(q.IsInSubGroup())
+	return q
+}
+
+type IsOnG2Circuit struct {
+	Q G2Affine
+}
+
+func (c *IsOnG2Circuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return err
+	}
+	pairing.AssertIsOnG2(&c.Q)
+	return nil
+}
+
+func TestIsOnG2Solve(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, q := randomG1G2Affines(assert)
+	witness := IsOnG2Circuit{
+		Q: NewG2Affine(q),
+	}
+	err := test.IsSolved(&IsOnG2Circuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestIsOnG2FailsOutsideSubgroup(t *testing.T) {
+	assert := test.NewAssert(t)
+	q := randomTwistPoint(assert)
+	witness := IsOnG2Circuit{
+		Q: NewG2Affine(q),
+	}
+	err := test.IsSolved(&IsOnG2Circuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestIsOnG2FailsOutsideTwist(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, q := randomG1G2Affines(assert)
+	q.Y.Double(&q.Y)
+	witness := IsOnG2Circuit{
+		Q: NewG2Affine(q),
+	}
+	err := test.IsSolved(&IsOnG2Circuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type ScalarMulSignedBitsG2Circuit struct {
+	Q, R G2Affine
+	Bits []frontend.Variable
+}
+
+func (c *ScalarMulSignedBitsG2Circuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return err
+	}
+	res := pairing.ScalarMulSignedBitsG2(&c.Q, c.Bits)
+	pairing.Ext2.AssertIsEqual(&res.X, &c.R.X)
+	pairing.Ext2.AssertIsEqual(&res.Y, &c.R.Y)
+	return nil
+}
+
+func TestScalarMulSignedBitsG2Solve(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n = 128
+	_, q := randomG1G2Affines(assert)
+	s, bits := signedBitsScalar(assert, n)
+	var r bls12381.G2Affine
+	r.ScalarMultiplication(&q, s)
+	witness := ScalarMulSignedBitsG2Circuit{
+		Q:    NewG2Affine(q),
+		R:    NewG2Affine(r),
+		Bits: bits,
+	}
+	err := test.IsSolved(&ScalarMulSignedBitsG2Circuit{Bits: make([]frontend.Variable, n)}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
@@ -0,0 +1,263 @@
+package pairing_bls12381
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/sha256"
+)
+
+// HashToG2 hashes the message msg to a point in G2 following the
+// BLS12381G2_XMD:SHA-256_SSWU_RO_ suite of [RFC9380] with the domain
+// separation tag dst. The bytes of msg are given as frontend.Variable and its
+// length is fixed at compile time. Each of them is asserted to be less than
+// 2⁸ (see [sha256.Sum]).
+//
+// The map to curve uses incomplete affine formulas. Since its inputs are
+// outputs of SHA-256, the exceptional cases happen only with negligible
+// probability and lead to an unsatisfiable circuit.
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (pr Pairing) HashToG2(msg []frontend.Variable, dst []byte) (*G2Affine, error) {
+	// 1. u = hash_to_field(msg, 2)
+	u, err := pr.hashToFp(msg, dst, 4)
+	if err != nil {
+		return nil, err
+	}
+	// 2. Q0 = map_to_curve(u[0])
+	Q0 := pr.mapToCurve2(&E2{A0: *u[0], A1: *u[1]})
+	// 3. Q1 = map_to_curve(u[1])
+	Q1 := pr.mapToCurve2(&E2{A0: *u[2], A1: *u[3]})
+	// 4. R = Q0 + Q1
+	// The 3-isogeny is a group homomorphism so we add the points on the
+	// isogenous curve E2' and evaluate the isogeny only once.
+	R := pr.AddG2(Q0, Q1)
+	R = pr.isogenyG2(R)
+	// 5. P = clear_cofactor(R)
+	return pr.clearCofactorG2(R), nil
+}
+
+// hashToFp implements hash_to_field from [RFC9380] (Section 5.2) for count
+// elements of 𝔽p. Each element is obtained by reducing L = 64 pseudo-random
+// bytes modulo p.
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (pr Pairing) hashToFp(msg []frontend.Variable, dst []byte, count int) ([]*baseEl, error) {
+	const L = 64
+	uniformBytes, err := sha256.ExpandMsgXmd(pr.api, msg, dst, count*L)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2²⁵⁶ mod p
+	shift := emulated.ValueOf[emulated.BLS12381Fp](new(big.Int).Lsh(big.NewInt(1), 256))
+	res := make([]*baseEl, count)
+	for i := range res {
+		// OS2IP(tv) = hi ⋅ 2²⁵⁶ + lo
+		hi := pr.fromBytesBE(uniformBytes[i*L : i*L+L/2])
+		lo := pr.fromBytesBE(uniformBytes[i*L+L/2 : (i+1)*L])
+		res[i] = pr.curveF.Add(pr.curveF.MulMod(hi, &shift), lo)
+	}
+	return res, nil
+}
+
+// fromBytesBE returns the element of 𝔽p whose big-endian encoding is b. The
+// integer encoded by b must be less than 2²⁵⁶.
+func (pr Pairing) fromBytesBE(b []frontend.Variable) *baseEl {
+	// 384 little-endian bits, zero-padded to fill all the limbs
+	res := make([]frontend.Variable, 0, 384)
+	for i := len(b) - 1; i >= 0; i-- {
+		res = append(res, bits.ToBinary(pr.api, b[i], bits.WithNbDigits(8))...)
+	}
+	for len(res) < 384 {
+		res = append(res, 0)
+	}
+	return pr.curveF.FromBits(res...)
+}
+
+// sgn0 returns the "sign" of z as defined in [RFC9380] (Section 4.1) for
+// 𝔽p², i.e. sgn0(z.A0) ∨ (z.A0 == 0 ∧ sgn0(z.A1)) where sgn0(x) is the parity
+// of x in [0, p).
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (pr Pairing) sgn0(z *E2) frontend.Variable {
+	a0 := pr.curveF.Reduce(&z.A0)
+	pr.curveF.AssertIsInRange(a0)
+	sign0 := pr.curveF.ToBits(a0)[0]
+	zero0 := pr.isZeroFp(a0)
+
+	a1 := pr.curveF.Reduce(&z.A1)
+	pr.curveF.AssertIsInRange(a1)
+	sign1 := pr.curveF.ToBits(a1)[0]
+
+	return pr.api.Or(sign0, pr.api.And(zero0, sign1))
+}
+
+// mapToCurve2 implements the simplified SWU map [RFC9380] (Section 6.6.2) to
+// the curve E2': Y² = X³ + A'⋅X + B' which is 3-isogenous to the twist, where
+// A' = 240u, B' = 1012(1+u) and Z = -(2+u).
+//
+// Instead of computing the square root of g(x₁) or g(x₂) in-circuit, we get
+// from a hint y such that y² = g(x₁) if g(x₁) is a square and y² = g(x₂)
+// otherwise. Since g(x₂) = Z³u⁶⋅g(x₁) and Z is a non-square, exactly one of
+// them is a square (unless g(x₁) = 0, which happens with negligible
+// probability) so that the prover has no choice on x.
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (pr Pairing) mapToCurve2(u *E2) *G2Affine {
+	A := E2{
+		A0: emulated.ValueOf[emulated.BLS12381Fp](0),
+		A1: emulated.ValueOf[emulated.BLS12381Fp](240),
+	}
+	B := E2{
+		A0: emulated.ValueOf[emulated.BLS12381Fp](1012),
+		A1: emulated.ValueOf[emulated.BLS12381Fp](1012),
+	}
+	Z := E2{
+		A0: emulated.ValueOf[emulated.BLS12381Fp]("4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559785"),
+		A1: emulated.ValueOf[emulated.BLS12381Fp]("4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559786"),
+	}
+
+	// tv1 = Z⋅u²
+	tv1 := pr.Ext2.Square(u)
+	tv1 = pr.Ext2.Mul(&Z, tv1)
+	// tv2 = Z²⋅u⁴ + Z⋅u²
+	tv2 := pr.Ext2.Square(tv1)
+	tv2 = pr.Ext2.Add(tv2, tv1)
+	// x₁ = (-B'/A')⋅(1 + 1/tv2) = -B'⋅(tv2 + 1) / (A'⋅tv2)
+	// The exceptional case tv2 = 0 makes the circuit unsatisfiable.
+	n := pr.Ext2.Add(tv2, pr.Ext2.One())
+	n = pr.Ext2.Mul(pr.Ext2.Neg(&B), n)
+	d := pr.Ext2.Mul(&A, tv2)
+	x1 := pr.Ext2.DivUnchecked(n, d)
+	// g(x₁) = x₁³ + A'⋅x₁ + B'
+	gx1 := pr.Ext2.Square(x1)
+	gx1 = pr.Ext2.Add(gx1, &A)
+	gx1 = pr.Ext2.Mul(gx1, x1)
+	gx1 = pr.Ext2.Add(gx1, &B)
+	// x₂ = Z⋅u²⋅x₁
+	x2 := pr.Ext2.Mul(tv1, x1)
+	// g(x₂) = x₂³ + A'⋅x₂ + B'
+	gx2 := pr.Ext2.Square(x2)
+	gx2 = pr.Ext2.Add(gx2, &A)
+	gx2 = pr.Ext2.Mul(gx2, x2)
+	gx2 = pr.Ext2.Add(gx2, &B)
+
+	// y² = g(x₁) if g(x₁) is a square, g(x₂) otherwise
+	res, err := pr.curveF.NewHint(sqrtE2Hint, 2, &gx1.A0, &gx1.A1, &gx2.A0, &gx2.A1)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	y := &E2{A0: *res[0], A1: *res[1]}
+	yy := pr.Ext2.Square(y)
+	isSquare := pr.Ext2.IsZero(pr.Ext2.Sub(yy, gx1))
+	pr.Ext2.AssertIsEqual(yy, pr.Ext2.Select(isSquare, gx1, gx2))
+	x := pr.Ext2.Select(isSquare, x1, x2)
+
+	// y = -y if sgn0(u) ≠ sgn0(y)
+	e := pr.api.Xor(pr.sgn0(u), pr.sgn0(y))
+	y = pr.Ext2.Select(e, pr.Ext2.Neg(y), y)
+
+	return &G2Affine{X: *x, Y: *y}
+}
+
+// coefficients of the 3-isogeny map from E2' to the twist ([RFC9380],
+// Appendix E.3), by increasing degree. The denominators are monic and their
+// leading coefficient is omitted.
+var (
+	isoXNum = [4][2]string{
+		{"889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542", "889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542"},
+		{"0", "2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706522"},
+		{"2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706526", "1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853261"},
+		{"3557697382419259905260257622876359250272784728834673675850718343221361467102966990615722337003569479144794908942033", "0"},
+	}
+	isoXDen = [2][2]string{
+		{"0", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559715"},
+		{"12", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559775"},
+	}
+	isoYNum = [4][2]string{
+		{"3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558", "3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558"},
+		{"0", "889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235518"},
+		{"2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706524", "1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853263"},
+		{"2816510427748580758331037284777117739799287910327449993381818688383577828123182200904113516794492504322962636245776", "0"},
+	}
+	isoYDen = [3][2]string{
+		{"4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355"},
+		{"0", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559571"},
+		{"18", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559769"},
+	}
+)
+
+// isogenyG2 evaluates the 3-isogeny map from E2' to the twist:
+//
+//	(x, y) ↦ (x_num(x)/x_den(x), y⋅y_num(x)/y_den(x))
+func (pr Pairing) isogenyG2(Q *G2Affine) *G2Affine {
+	xNum := pr.evalPolynomialE2(&Q.X, false, isoXNum[:])
+	xDen := pr.evalPolynomialE2(&Q.X, true, isoXDen[:])
+	yNum := pr.evalPolynomialE2(&Q.X, false, isoYNum[:])
+	yDen := pr.evalPolynomialE2(&Q.X, true, isoYDen[:])
+
+	x := pr.Ext2.DivUnchecked(xNum, xDen)
+	y := pr.Ext2.Mul(&Q.Y, yNum)
+	y = pr.Ext2.DivUnchecked(y, yDen)
+	return &G2Affine{X: *x, Y: *y}
+}
+
+// evalPolynomialE2 evaluates at x the polynomial with constant coefficients
+// given by increasing degree using Horner's rule. If monic is true, the
+// polynomial has an additional leading coefficient 1.
+func (pr Pairing) evalPolynomialE2(x *E2, monic bool, coefficients [][2]string) *E2 {
+	coeff := func(i int) *E2 {
+		return &E2{
+			A0: emulated.ValueOf[emulated.BLS12381Fp](coefficients[i][0]),
+			A1: emulated.ValueOf[emulated.BLS12381Fp](coefficients[i][1]),
+		}
+	}
+	last := len(coefficients) - 1
+	res := coeff(last)
+	if monic {
+		res = pr.Ext2.Add(res, x)
+	}
+	for i := last - 1; i >= 0; i-- {
+		res = pr.Ext2.Mul(res, x)
+		res = pr.Ext2.Add(res, coeff(i))
+	}
+	return res
+}
+
+// clearCofactorG2 maps a point Q on the twist to G2 by multiplying it by the
+// effective cofactor h_eff of [RFC9380] (Section 8.8.2). Following [BP17]
+// (Section 4.1), we compute
+//
+//	[h_eff]Q = [x₀²-x₀-1]Q + [x₀-1]ψ(Q) + ψ²([2]Q)
+//
+// so that the dominant cost is two scalar multiplications by the seed x₀.
+//
+// The scalar multiplications and additions use incomplete affine formulas.
+// For Q output of the map to curve, the edge cases happen only with
+// negligible probability.
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+// [BP17]: https://eprint.iacr.org/2017/419.pdf
+func (pr Pairing) clearCofactorG2(Q *G2Affine) *G2Affine {
+	// [x₀]Q = -[|x₀|]Q
+	xQ := pr.negG2(pr.scalarMulBySeedG2(Q))
+	// [x₀²]Q
+	xxQ := pr.negG2(pr.scalarMulBySeedG2(xQ))
+	negQ := pr.negG2(Q)
+
+	// [x₀²-x₀-1]Q
+	res := pr.AddG2(xxQ, pr.negG2(xQ))
+	res = pr.AddG2(res, negQ)
+	// + ψ([x₀-1]Q)
+	t := pr.AddG2(xQ, negQ)
+	res = pr.AddG2(res, pr.psi(t))
+	// + ψ²([2]Q)
+	t = pr.psi2(pr.doubleG2(Q))
+	res = pr.AddG2(res, t)
+
+	return res
+}
@@ -1,13 +1,18 @@
 package pairing_bls12381
 
 import (
+	"errors"
 	"math/big"
 
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
 	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/std/math/emulated"
 )
 
+// bCurveCoeff is the coefficient b = 4 of the curve Y² = X³ + b.
+var bCurveCoeff = fp.NewElement(4)
+
 func init() {
 	solver.RegisterHint(GetHints()...)
 }
@@ -15,9 +20,12 @@ func init() {
 // GetHints returns all hint functions used in the package.
 func GetHints() []solver.Hint {
 	return []solver.Hint{
+		// G1
+		decompressG1Hint,
 		// E2
 		divE2Hint,
 		inverseE2Hint,
+		sqrtE2Hint,
 		// E6
 		divE6Hint,
 		inverseE6Hint,
@@ -25,9 +33,33 @@ func GetHints() []solver.Hint {
 		// E12
 		divE12Hint,
 		inverseE12Hint,
+		// Pairing
+		residueWitnessHint,
 	}
 }
 
+// decompressG1Hint returns the square root of x³+4 in [0, (p-1)/2]. It fails if
+// x³+4 is not a square, i.e. if x is not the abscissa of a point of the curve.
+func decompressG1Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var x, y fp.Element
+
+			x.SetBigInt(inputs[0])
+			y.Square(&x).Mul(&y, &x).Add(&y, &bCurveCoeff)
+			if y.Sqrt(&y) == nil {
+				return errors.New("x is not on the curve")
+			}
+			if y.LexicographicallyLargest() {
+				y.Neg(&y)
+			}
+
+			y.BigInt(outputs[0])
+
+			return nil
+		})
+}
+
 func inverseE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
 	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
 		func(mod *big.Int, inputs, outputs []*big.Int) error {
@@ -64,6 +96,31 @@ func divE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error
 		})
 }
 
+// sqrtE2Hint returns a square root of x if x is a square in 𝔽p², and a square
+// root of y otherwise.
+func sqrtE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var x, y, c bls12381.E2
+
+			x.A0.SetBigInt(inputs[0])
+			x.A1.SetBigInt(inputs[1])
+			y.A0.SetBigInt(inputs[2])
+			y.A1.SetBigInt(inputs[3])
+
+			if x.Legendre() != -1 {
+				c.Sqrt(&x)
+			} else {
+				c.Sqrt(&y)
+			}
+
+			c.A0.BigInt(outputs[0])
+			c.A1.BigInt(outputs[1])
+
+			return nil
+		})
+}
+
 // E6 hints
 func inverseE6Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
 	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
@@ -236,3 +293,49 @@ func divE12Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) erro
 			return nil
 		})
 }
+
+// residueWitnessHint returns the residue witness c such that c^λ = m ⋅ u for
+// some u ∈ 𝔽p⁶, where m is the conjugate of the Miller loop of the input
+// points and λ = p - x₀ (see [Pairing.PairingCheckResidue]).
+func residueWitnessHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			n := len(inputs) / 6
+			P := make([]bls12381.G1Affine, n)
+			Q := make([]bls12381.G2Affine, n)
+			for k := 0; k < n; k++ {
+				P[k].X.SetBigInt(inputs[6*k])
+				P[k].Y.SetBigInt(inputs[6*k+1])
+				Q[k].X.A0.SetBigInt(inputs[6*k+2])
+				Q[k].X.A1.SetBigInt(inputs[6*k+3])
+				Q[k].Y.A0.SetBigInt(inputs[6*k+4])
+				Q[k].Y.A1.SetBigInt(inputs[6*k+5])
+			}
+			m, err := bls12381.MillerLoop(P, Q)
+			if err != nil {
+				return err
+			}
+			// negative x₀
+			m.Conjugate(&m)
+
+			var u, c bls12381.E12
+			u.Exp(m, scalingFactorExponent)
+			c.Mul(&m, &u)
+			c.Exp(c, residueWitnessExponent)
+
+			c.C0.B0.A0.BigInt(outputs[0])
+			c.C0.B0.A1.BigInt(outputs[1])
+			c.C0.B1.A0.BigInt(outputs[2])
+			c.C0.B1.A1.BigInt(outputs[3])
+			c.C0.B2.A0.BigInt(outputs[4])
+			c.C0.B2.A1.BigInt(outputs[5])
+			c.C1.B0.A0.BigInt(outputs[6])
+			c.C1.B0.A1.BigInt(outputs[7])
+			c.C1.B1.A0.BigInt(outputs[8])
+			c.C1.B1.A1.BigInt(outputs[9])
+			c.C1.B2.A0.BigInt(outputs[10])
+			c.C1.B2.A1.BigInt(outputs[11])
+
+			return nil
+		})
+}
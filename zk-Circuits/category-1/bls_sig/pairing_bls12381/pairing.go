@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/internal/residue"
 )
 
 type Pairing struct {
@@ -222,6 +224,48 @@ func (pr Pairing) PairingCheck(P []*G1Affine, Q []*G2Affine) error {
 	return nil
 }
 
+// PairingCheckWithTarget calculates the reduced pairing for a set of points
+// and asserts if the result is the constant T
+// ∏ᵢ e(Pᵢ, Qᵢ) =? T
+//
+// Instead of comparing to T after the final exponentiation, the Miller loop
+// output is multiplied by the inverse of a precomputed representative f of T,
+// i.e. such that the final exponentiation of f is T, so that a single final
+// exponentiation is needed.
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairingCheckWithTarget(P []*G1Affine, Q []*G2Affine, T bls12381.GT) error {
+	res, err := pr.MillerLoop(P, Q)
+	if err != nil {
+		return fmt.Errorf("miller loop: %w", err)
+	}
+
+	// f⁻¹ = T^(-1/d' mod r)
+	var fInv bls12381.GT
+	fInv.Exp(T, targetExponent)
+	t := NewGTEl(fInv)
+
+	res = pr.Mul(res, &t)
+	res = pr.finalExponentiation(res, false)
+	pr.AssertIsEqual(res, pr.One())
+
+	return nil
+}
+
+// targetExponent = -1/d' mod r, where d' = s ⋅ (p¹²-1)/r is the exponent of
+// the final exponentiation and s = 3 the cofactor. For T ∈ GT,
+// (T^targetExponent)^d' = T⁻¹.
+var targetExponent = func() *big.Int {
+	s := big.NewInt(3)
+	p, r := ecc.BLS12_381.BaseField(), ecc.BLS12_381.ScalarField()
+	d := new(big.Int).Exp(p, big.NewInt(12), nil)
+	d.Sub(d, big.NewInt(1)).
+		Div(d, r).
+		Mul(d, s).
+		ModInverse(d, r)
+	return d.Sub(r, d)
+}()
+
 func (pr Pairing) AssertIsEqual(x, y *GTEl) {
 	pr.Ext12.AssertIsEqual(x, y)
 }
@@ -554,7 +598,9 @@ func (pr Pairing) tangentCompute(p1 *G2Affine) *lineEvaluation {
 // ----
 // Fixed argument pairing
 
-func (pr Pairing) MillerLoopFixedQ(P *G1Affine) (*GTEl, error) {
+// MillerLoopFixedQ computes the Miller loop f_{x₀,Q}(P) for a fixed G2
+// argument Q given by its precomputed lines (see [PrecomputeLines]).
+func (pr Pairing) MillerLoopFixedQ(P *G1Affine, lines *FixedLines) (*GTEl, error) {
 
 	res := pr.Ext12.One()
 
@@ -573,12 +619,12 @@ func (pr Pairing) MillerLoopFixedQ(P *G1Affine) (*GTEl, error) {
 	// i = 62, separately to avoid an E12 Square
 	// (Square(res) = 1² = 1)
 	res = pr.MulBy014(res,
-		pr.MulByElement(&PrecomputedLines[1][62], yInv),
-		pr.MulByElement(&PrecomputedLines[0][62], xOverY),
+		pr.MulByElement(&lines[1][62], yInv),
+		pr.MulByElement(&lines[0][62], xOverY),
 	)
 	res = pr.MulBy014(res,
-		pr.MulByElement(&PrecomputedLines[3][62], yInv),
-		pr.MulByElement(&PrecomputedLines[2][62], xOverY),
+		pr.MulByElement(&lines[3][62], yInv),
+		pr.MulByElement(&lines[2][62], xOverY),
 	)
 
 	// Compute ∏ᵢ { fᵢ_{u,Q}(P) }
@@ -589,17 +635,17 @@ func (pr Pairing) MillerLoopFixedQ(P *G1Affine) (*GTEl, error) {
 
 		if loopCounter[i] == 0 {
 			res = pr.MulBy014(res,
-				pr.MulByElement(&PrecomputedLines[1][i], yInv),
-				pr.MulByElement(&PrecomputedLines[0][i], xOverY),
+				pr.MulByElement(&lines[1][i], yInv),
+				pr.MulByElement(&lines[0][i], xOverY),
 			)
 		} else {
 			res = pr.MulBy014(res,
-				pr.MulByElement(&PrecomputedLines[1][i], yInv),
-				pr.MulByElement(&PrecomputedLines[0][i], xOverY),
+				pr.MulByElement(&lines[1][i], yInv),
+				pr.MulByElement(&lines[0][i], xOverY),
 			)
 			res = pr.MulBy014(res,
-				pr.MulByElement(&PrecomputedLines[3][i], yInv),
-				pr.MulByElement(&PrecomputedLines[2][i], xOverY),
+				pr.MulByElement(&lines[3][i], yInv),
+				pr.MulByElement(&lines[2][i], xOverY),
 			)
 		}
 	}
@@ -610,7 +656,10 @@ func (pr Pairing) MillerLoopFixedQ(P *G1Affine) (*GTEl, error) {
 	return res, nil
 }
 
-func (pr Pairing) DoubleMillerLoopFixedQ(P, T *G1Affine, Q *G2Affine) (*GTEl, error) {
+// DoubleMillerLoopFixedQ computes the double Miller loop
+// f_{x₀,Q}(P)⋅f_{x₀,R}(T) for a variable Q and a fixed R given by its
+// precomputed lines (see [PrecomputeLines]).
+func (pr Pairing) DoubleMillerLoopFixedQ(P, T *G1Affine, Q *G2Affine, lines *FixedLines) (*GTEl, error) {
 	res := pr.Ext12.One()
 
 	var l1, l2 *lineEvaluation
@@ -647,12 +696,12 @@ func (pr Pairing) DoubleMillerLoopFixedQ(P, T *G1Affine, Q *G2Affine) (*GTEl, er
 	res.C1.B2 = prodLines[4]
 
 	res = pr.MulBy014(res,
-		pr.MulByElement(&PrecomputedLines[1][62], y2Inv),
-		pr.MulByElement(&PrecomputedLines[0][62], x2OverY2),
+		pr.MulByElement(&lines[1][62], y2Inv),
+		pr.MulByElement(&lines[0][62], x2OverY2),
 	)
 	res = pr.MulBy014(res,
-		pr.MulByElement(&PrecomputedLines[3][62], y2Inv),
-		pr.MulByElement(&PrecomputedLines[2][62], x2OverY2),
+		pr.MulByElement(&lines[3][62], y2Inv),
+		pr.MulByElement(&lines[2][62], x2OverY2),
 	)
 
 	// Compute ∏ᵢ { fᵢ_{u,G2}(T) }
@@ -663,8 +712,8 @@ func (pr Pairing) DoubleMillerLoopFixedQ(P, T *G1Affine, Q *G2Affine) (*GTEl, er
 
 		if loopCounter[i] == 0 {
 			res = pr.MulBy014(res,
-				pr.MulByElement(&PrecomputedLines[1][i], y2Inv),
-				pr.MulByElement(&PrecomputedLines[0][i], x2OverY2),
+				pr.MulByElement(&lines[1][i], y2Inv),
+				pr.MulByElement(&lines[0][i], x2OverY2),
 			)
 			// Qacc ← 2Qacc and l1 the tangent ℓ passing 2Qacc
 			Qacc, l1 = pr.doubleStep(Qacc)
@@ -675,12 +724,12 @@ func (pr Pairing) DoubleMillerLoopFixedQ(P, T *G1Affine, Q *G2Affine) (*GTEl, er
 			res = pr.MulBy014(res, &l1.R1, &l1.R0)
 		} else {
 			res = pr.MulBy014(res,
-				pr.MulByElement(&PrecomputedLines[1][i], y2Inv),
-				pr.MulByElement(&PrecomputedLines[0][i], x2OverY2),
+				pr.MulByElement(&lines[1][i], y2Inv),
+				pr.MulByElement(&lines[0][i], x2OverY2),
 			)
 			res = pr.MulBy014(res,
-				pr.MulByElement(&PrecomputedLines[3][i], y2Inv),
-				pr.MulByElement(&PrecomputedLines[2][i], x2OverY2),
+				pr.MulByElement(&lines[3][i], y2Inv),
+				pr.MulByElement(&lines[2][i], x2OverY2),
 			)
 			// Qacc ← 2Qacc+Q,
 			// l1 the line ℓ passing Qacc and Q
@@ -705,8 +754,117 @@ func (pr Pairing) DoubleMillerLoopFixedQ(P, T *G1Affine, Q *G2Affine) (*GTEl, er
 	return res, nil
 }
 
-func (pr Pairing) PairFixedQ(P *G1Affine) (*GTEl, error) {
-	res, err := pr.MillerLoopFixedQ(P)
+// MultiMillerLoopMixed computes the multi-Miller loop
+// ∏ᵢ f_{x₀,Rᵢ}(Tᵢ) ⋅ ∏ⱼ f_{x₀,Qⱼ}(Pⱼ) for fixed G2 arguments Rᵢ given by their
+// precomputed lines (see [PrecomputeLines]) and variable G2 arguments Qⱼ. The
+// squarings of the accumulator are shared among all the Miller loops.
+func (pr Pairing) MultiMillerLoopMixed(fixedP []*G1Affine, fixedLines []FixedLines, varP []*G1Affine, varQ []*G2Affine) (*GTEl, error) {
+	// check input size match
+	nFixed, nVar := len(fixedP), len(varP)
+	if nFixed != len(fixedLines) || nVar != len(varQ) || nFixed+nVar == 0 {
+		return nil, errors.New("invalid inputs sizes")
+	}
+
+	res := pr.Ext12.One()
+	var prodLines [5]E2
+	var l1, l2 *lineEvaluation
+
+	fixedYInv := make([]*emulated.Element[emulated.BLS12381Fp], nFixed)
+	fixedXOverY := make([]*emulated.Element[emulated.BLS12381Fp], nFixed)
+	for k := 0; k < nFixed; k++ {
+		fixedYInv[k] = pr.curveF.Inverse(&fixedP[k].Y)
+		fixedXOverY[k] = pr.curveF.MulMod(&fixedP[k].X, fixedYInv[k])
+	}
+	Qacc := make([]*G2Affine, nVar)
+	yInv := make([]*emulated.Element[emulated.BLS12381Fp], nVar)
+	xOverY := make([]*emulated.Element[emulated.BLS12381Fp], nVar)
+	for k := 0; k < nVar; k++ {
+		Qacc[k] = varQ[k]
+		yInv[k] = pr.curveF.Inverse(&varP[k].Y)
+		xOverY[k] = pr.curveF.MulMod(&varP[k].X, yInv[k])
+	}
+
+	for i := 62; i >= 0; i-- {
+		// mutualize the square among n Miller loops
+		// (∏ᵢfᵢ)²
+		// (for i = 62, Square(res) = 1² = 1)
+		if i < 62 {
+			res = pr.Square(res)
+		}
+
+		// fixed lines evaluation at Tᵢ
+		for k := 0; k < nFixed; k++ {
+			lines := &fixedLines[k]
+			if loopCounter[i] == 0 {
+				// ℓ × res
+				res = pr.MulBy014(res,
+					pr.MulByElement(&lines[1][i], fixedYInv[k]),
+					pr.MulByElement(&lines[0][i], fixedXOverY[k]),
+				)
+				continue
+			}
+			// (ℓ × ℓ) × res
+			prodLines = *pr.Mul014By014(
+				pr.MulByElement(&lines[1][i], fixedYInv[k]),
+				pr.MulByElement(&lines[0][i], fixedXOverY[k]),
+				pr.MulByElement(&lines[3][i], fixedYInv[k]),
+				pr.MulByElement(&lines[2][i], fixedXOverY[k]),
+			)
+			res = pr.MulBy01245(res, &prodLines)
+		}
+
+		// variable lines evaluation at Pⱼ
+		for k := 0; k < nVar; k++ {
+			switch {
+			case i == 62:
+				// Qacc[k] ← 3Qacc[k],
+				// l1 the tangent ℓ to 2Q[k]
+				// l2 the line ℓ passing 2Q[k] and Q[k]
+				Qacc[k], l1, l2 = pr.tripleStep(Qacc[k])
+			case i == 0:
+				// l1 the tangent ℓ passing 2Qacc[k]
+				// (separately to avoid a point doubling)
+				l1 = pr.tangentCompute(Qacc[k])
+				l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+				l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+				res = pr.MulBy014(res, &l1.R1, &l1.R0)
+				continue
+			case loopCounter[i] == 0:
+				// Qacc[k] ← 2Qacc[k] and l1 the tangent ℓ passing 2Qacc[k]
+				Qacc[k], l1 = pr.doubleStep(Qacc[k])
+				// line evaluation at P[k]
+				l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+				l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+				// ℓ × res
+				res = pr.MulBy014(res, &l1.R1, &l1.R0)
+				continue
+			default:
+				// Qacc[k] ← 2Qacc[k]+Q[k],
+				// l1 the line ℓ passing Qacc[k] and Q[k]
+				// l2 the line ℓ passing (Qacc[k]+Q[k]) and Qacc[k]
+				Qacc[k], l1, l2 = pr.doubleAndAddStep(Qacc[k], varQ[k])
+			}
+			// line evaluation at P[k]
+			l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+			l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+			l2.R0 = *pr.MulByElement(&l2.R0, xOverY[k])
+			l2.R1 = *pr.MulByElement(&l2.R1, yInv[k])
+			// (ℓ × ℓ) × res
+			prodLines = *pr.Mul014By014(&l1.R1, &l1.R0, &l2.R1, &l2.R0)
+			res = pr.MulBy01245(res, &prodLines)
+		}
+	}
+
+	// negative x₀
+	res = pr.Ext12.Conjugate(res)
+
+	return res, nil
+}
+
+// PairFixedQ computes the reduced pairing e(P, Q) for a fixed Q given by its
+// precomputed lines (see [PrecomputeLines]).
+func (pr Pairing) PairFixedQ(P *G1Affine, lines *FixedLines) (*GTEl, error) {
+	res, err := pr.MillerLoopFixedQ(P, lines)
 	if err != nil {
 		return nil, fmt.Errorf("miller loop: %w", err)
 	}
@@ -714,11 +872,198 @@ func (pr Pairing) PairFixedQ(P *G1Affine) (*GTEl, error) {
 	return res, nil
 }
 
-func (pr Pairing) DoublePairFixedQ(P, T *G1Affine, Q *G2Affine) (*GTEl, error) {
-	res, err := pr.DoubleMillerLoopFixedQ(P, T, Q)
+// DoublePairFixedQ computes the reduced pairing product e(P, Q)⋅e(T, R) for a
+// variable Q and a fixed R given by its precomputed lines (see
+// [PrecomputeLines]).
+func (pr Pairing) DoublePairFixedQ(P, T *G1Affine, Q *G2Affine, lines *FixedLines) (*GTEl, error) {
+	res, err := pr.DoubleMillerLoopFixedQ(P, T, Q, lines)
 	if err != nil {
 		return nil, fmt.Errorf("double miller loop: %w", err)
 	}
 	res = pr.finalExponentiation(res, false)
 	return res, nil
 }
+
+// MultiPairMixed computes the reduced pairing product
+// ∏ᵢ e(Tᵢ, Rᵢ) ⋅ ∏ⱼ e(Pⱼ, Qⱼ) for fixed Rᵢ given by their precomputed lines
+// (see [PrecomputeLines]) and variable Qⱼ (see [Pairing.MultiMillerLoopMixed]).
+func (pr Pairing) MultiPairMixed(fixedP []*G1Affine, fixedLines []FixedLines, varP []*G1Affine, varQ []*G2Affine) (*GTEl, error) {
+	res, err := pr.MultiMillerLoopMixed(fixedP, fixedLines, varP, varQ)
+	if err != nil {
+		return nil, fmt.Errorf("multi miller loop: %w", err)
+	}
+	res = pr.finalExponentiation(res, len(fixedP)+len(varP) == 1)
+	return res, nil
+}
+
+// ----
+// Final-exponentiation-free pairing check
+
+// PairingCheckResidue asserts that the reduced pairing of a set of points is
+// One
+// ∏ᵢ e(Pᵢ, Qᵢ) =? 1
+//
+// without computing the final exponentiation in-circuit. It follows Section 4
+// of "On Proving Pairings" by A. Novakovic and L. Eagen
+// (https://eprint.iacr.org/2024/640.pdf).
+//
+// The Miller loop output f = conj(m), with m = ∏ᵢ fᵢ_{|x₀|,Q}(P), satisfies
+// fᵈ = 1, with d = (p¹²-1)/r, iff mᵈ = 1 iff there exist c ∈ 𝔽p¹² and u ∈ 𝔽p⁶
+// such that
+//
+//	m ⋅ u = c^λ, with λ = p - x₀ = p + |x₀|
+//
+// a multiple of r. The residue witness c is given by a hint and c^|x₀| is
+// computed in the Miller loop, sharing its squarings. Instead of providing the
+// scaling factor u as a witness, we check that c^λ / m is fixed by the
+// conjugation (i.e. lies in 𝔽p⁶). Any u ∈ 𝔽p⁶ is sound as (p⁶-1) divides d.
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairingCheckResidue(P []*G1Affine, Q []*G2Affine) error {
+	// check input size match
+	n := len(P)
+	if n == 0 || n != len(Q) {
+		return errors.New("invalid inputs sizes")
+	}
+
+	inputs := make([]*emulated.Element[emulated.BLS12381Fp], 0, 6*n)
+	for k := 0; k < n; k++ {
+		inputs = append(inputs, &P[k].X, &P[k].Y, &Q[k].X.A0, &Q[k].X.A1, &Q[k].Y.A0, &Q[k].Y.A1)
+	}
+	hint, err := pr.curveF.NewHint(residueWitnessHint, 12, inputs...)
+	if err != nil {
+		return fmt.Errorf("new hint: %w", err)
+	}
+	c := GTEl{
+		C0: E6{
+			B0: E2{A0: *hint[0], A1: *hint[1]},
+			B1: E2{A0: *hint[2], A1: *hint[3]},
+			B2: E2{A0: *hint[4], A1: *hint[5]},
+		},
+		C1: E6{
+			B0: E2{A0: *hint[6], A1: *hint[7]},
+			B1: E2{A0: *hint[8], A1: *hint[9]},
+			B2: E2{A0: *hint[10], A1: *hint[11]},
+		},
+	}
+	// c ≠ 0 as c ⋅ c⁻¹ = 1 is asserted
+	cInv := pr.Ext12.Inverse(&c)
+
+	// m ⋅ c^-|x₀|
+	res, err := pr.millerLoopResidue(P, Q, cInv)
+	if err != nil {
+		return fmt.Errorf("miller loop: %w", err)
+	}
+
+	// c^p
+	t := pr.Frobenius(&c)
+
+	// t / res ∈ 𝔽p⁶ ⇔ conj(t) ⋅ res = t ⋅ conj(res)
+	// (res ≠ 0 as the lines have a constant coefficient 1)
+	pr.AssertIsEqual(
+		pr.Mul(pr.Conjugate(t), res),
+		pr.Mul(t, pr.Conjugate(res)),
+	)
+
+	return nil
+}
+
+// millerLoopResidue computes the multi-Miller loop as in [MillerLoop] but
+// without the final conjugation and with the accumulator initialized to c⁻¹
+// and multiplied by c⁻¹ at each non-zero bit of the loop counter. It returns
+// ∏ᵢ { fᵢ_{|x₀|,Q}(P) } ⋅ c^-|x₀|
+func (pr Pairing) millerLoopResidue(P []*G1Affine, Q []*G2Affine, cInv *GTEl) (*GTEl, error) {
+	n := len(P)
+	var l1, l2 *lineEvaluation
+	Qacc := make([]*G2Affine, n)
+	yInv := make([]*emulated.Element[emulated.BLS12381Fp], n)
+	xOverY := make([]*emulated.Element[emulated.BLS12381Fp], n)
+
+	for k := 0; k < n; k++ {
+		Qacc[k] = Q[k]
+		yInv[k] = pr.curveF.Inverse(&P[k].Y)
+		xOverY[k] = pr.curveF.MulMod(&P[k].X, yInv[k])
+	}
+
+	// i = 63, the leading bit 1
+	res := cInv
+
+	// i = 62, separately to avoid a doubleAndAddStep(Q, Q)
+	res = pr.Square(res)
+	res = pr.Mul(res, cInv)
+	for k := 0; k < n; k++ {
+		// Qacc[k] ← 3Qacc[k],
+		// l1 the tangent ℓ to 2Q[k]
+		// l2 the line ℓ passing 2Q[k] and Q[k]
+		Qacc[k], l1, l2 = pr.tripleStep(Qacc[k])
+		// line evaluation at P[k]
+		l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+		l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+		l2.R0 = *pr.MulByElement(&l2.R0, xOverY[k])
+		l2.R1 = *pr.MulByElement(&l2.R1, yInv[k])
+		// (ℓ × ℓ) × res
+		res = pr.MulBy014(res, &l1.R1, &l1.R0)
+		res = pr.MulBy014(res, &l2.R1, &l2.R0)
+	}
+
+	for i := 61; i >= 1; i-- {
+		// mutualize the square among n Miller loops and c^-|x₀|
+		res = pr.Square(res)
+
+		if loopCounter[i] == 0 {
+			for k := 0; k < n; k++ {
+				// Qacc[k] ← 2Qacc[k] and l1 the tangent ℓ passing 2Qacc[k]
+				Qacc[k], l1 = pr.doubleStep(Qacc[k])
+				// line evaluation at P[k]
+				l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+				l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+				// ℓ × res
+				res = pr.MulBy014(res, &l1.R1, &l1.R0)
+			}
+		} else {
+			res = pr.Mul(res, cInv)
+			for k := 0; k < n; k++ {
+				// Qacc[k] ← 2Qacc[k]+Q[k],
+				// l1 the line ℓ passing Qacc[k] and Q[k]
+				// l2 the line ℓ passing (Qacc[k]+Q[k]) and Qacc[k]
+				Qacc[k], l1, l2 = pr.doubleAndAddStep(Qacc[k], Q[k])
+				// line evaluation at P[k]
+				l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+				l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+				l2.R0 = *pr.MulByElement(&l2.R0, xOverY[k])
+				l2.R1 = *pr.MulByElement(&l2.R1, yInv[k])
+				// (ℓ × ℓ) × res
+				res = pr.MulBy014(res, &l1.R1, &l1.R0)
+				res = pr.MulBy014(res, &l2.R1, &l2.R0)
+			}
+		}
+	}
+
+	// i = 0, separately to avoid a point doubling
+	res = pr.Square(res)
+	for k := 0; k < n; k++ {
+		// l1 the tangent ℓ passing 2Qacc[k]
+		l1 = pr.tangentCompute(Qacc[k])
+		// line evaluation at P[k]
+		l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+		l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+		// ℓ × res
+		res = pr.MulBy014(res, &l1.R1, &l1.R0)
+	}
+
+	return res, nil
+}
+
+// residueWitnessExponent and scalingFactorExponent are such that for any
+// m ∈ 𝔽p¹² with mᵈ = 1, d = (p¹²-1)/r, the element u = m^scalingFactorExponent
+// is in 𝔽p⁶ and c = (m ⋅ u)^residueWitnessExponent satisfies
+// c^λ = m ⋅ u, where λ = p - x₀ (see [residue.Exponents]). Here u is in the
+// subgroup of order B made of the primes dividing 1-x₀, which is in 𝔽p⁶ as
+// B | p⁶-1.
+var residueWitnessExponent, scalingFactorExponent = func() (*big.Int, *big.Int) {
+	x0, _ := new(big.Int).SetString("-15132376222941642752", 10)
+	p, r := ecc.BLS12_381.BaseField(), ecc.BLS12_381.ScalarField()
+	// λ = p - x₀
+	lambda := new(big.Int).Sub(p, x0)
+	return residue.Exponents(lambda, p, r)
+}()
@@ -0,0 +1,143 @@
+package pairing_bls12381
+
+//go:generate go run ../cmd/linegen -curve bls12-381 -o precomputations.go
+
+import (
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// FixedLines are the lines of the Miller loop for a fixed G2 argument Q, as
+// used by [Pairing.MillerLoopFixedQ]. For each iteration i, lines[0][i],
+// lines[1][i] are the coefficients R0 and R1 of a first line and, when
+// loopCounter[i] = 1, lines[2][i] and lines[3][i] those of a second line:
+//
+//   - for i = 62, the tangent at Q and the line through [2]Q and Q,
+//   - for loopCounter[i] = 0, the tangent at the accumulated point T,
+//   - for loopCounter[i] = 1, the line through T and Q and the line through
+//     T+Q and T.
+type FixedLines [4][63]E2
+
+// NativeFixedLines are the out-of-circuit values of [FixedLines]. The entries
+// not used by the Miller loop are zero.
+type NativeFixedLines [4][63]bls12381.E2
+
+// PrecomputeLines computes out of circuit the lines of the Miller loop for the
+// fixed G2 argument Q. Q must be in G2 and not the point at infinity.
+func PrecomputeLines(Q bls12381.G2Affine) FixedLines {
+	nativeLines := PrecomputeNativeLines(Q)
+	var lines FixedLines
+	for k := range lines {
+		for i := range lines[k] {
+			lines[k][i] = FromE2(&nativeLines[k][i])
+		}
+	}
+	return lines
+}
+
+// PrecomputeNativeLines is the same as [PrecomputeLines] but returns the
+// lines as native 𝔽p² elements.
+func PrecomputeNativeLines(Q bls12381.G2Affine) NativeFixedLines {
+	var lines NativeFixedLines
+	var l1, l2 nativeLine
+
+	// i = 62
+	Qacc := Q
+	l1 = nativeDoubleStep(&Qacc)
+	l2 = nativeAddStep(&Qacc, &Q)
+	lines[0][62], lines[1][62] = l1.R0, l1.R1
+	lines[2][62], lines[3][62] = l2.R0, l2.R1
+
+	for i := 61; i >= 0; i-- {
+		if loopCounter[i] == 0 {
+			l1 = nativeDoubleStep(&Qacc)
+		} else {
+			l1, l2 = nativeDoubleAndAddStep(&Qacc, &Q)
+			lines[2][i], lines[3][i] = l2.R0, l2.R1
+		}
+		lines[0][i], lines[1][i] = l1.R0, l1.R1
+	}
+
+	return lines
+}
+
+// nativeLine is the out-of-circuit counterpart of lineEvaluation.
+type nativeLine struct {
+	R0, R1 bls12381.E2
+}
+
+// newNativeLine returns the line of slope λ passing through p:
+// R0 = -λ and R1 = λ⋅p.x - p.y.
+func newNativeLine(λ *bls12381.E2, p *bls12381.G2Affine) nativeLine {
+	var l nativeLine
+	l.R0.Neg(λ)
+	l.R1.Mul(λ, &p.X).Sub(&l.R1, &p.Y)
+	return l
+}
+
+// nativeDoubleStep is the out-of-circuit counterpart of
+// [Pairing.doubleStep]. It sets p to 2p and returns the tangent line.
+func nativeDoubleStep(p *bls12381.G2Affine) nativeLine {
+	// λ = 3x²/2y
+	var n, d, λ bls12381.E2
+	n.Square(&p.X)
+	d.Double(&n)
+	n.Add(&n, &d)
+	d.Double(&p.Y)
+	λ.Div(&n, &d)
+	l := newNativeLine(&λ, p)
+
+	// xr = λ²-2x, yr = λ(x-xr)-y
+	var xr, yr bls12381.E2
+	xr.Square(&λ).Sub(&xr, &p.X).Sub(&xr, &p.X)
+	yr.Sub(&p.X, &xr).Mul(&yr, &λ).Sub(&yr, &p.Y)
+	p.X, p.Y = xr, yr
+
+	return l
+}
+
+// nativeAddStep is the out-of-circuit counterpart of [Pairing.addStep]. It
+// sets p1 to p1+p2 and returns the line through p1 and p2.
+func nativeAddStep(p1, p2 *bls12381.G2Affine) nativeLine {
+	// λ = (y2-y1)/(x2-x1)
+	var n, d, λ bls12381.E2
+	n.Sub(&p2.Y, &p1.Y)
+	d.Sub(&p2.X, &p1.X)
+	λ.Div(&n, &d)
+	l := newNativeLine(&λ, p1)
+
+	// xr = λ²-x1-x2, yr = λ(x1-xr)-y1
+	var xr, yr bls12381.E2
+	xr.Square(&λ).Sub(&xr, &p1.X).Sub(&xr, &p2.X)
+	yr.Sub(&p1.X, &xr).Mul(&yr, &λ).Sub(&yr, &p1.Y)
+	p1.X, p1.Y = xr, yr
+
+	return l
+}
+
+// nativeDoubleAndAddStep is the out-of-circuit counterpart of
+// [Pairing.doubleAndAddStep]. It sets p1 to 2p1+p2 and returns the line
+// through p1 and p2 and the line through p1+p2 and p1.
+func nativeDoubleAndAddStep(p1, p2 *bls12381.G2Affine) (nativeLine, nativeLine) {
+	// λ1 = (y1-y2)/(x1-x2)
+	var n, d, λ1, λ2, x3, x4, y4 bls12381.E2
+	n.Sub(&p1.Y, &p2.Y)
+	d.Sub(&p1.X, &p2.X)
+	λ1.Div(&n, &d)
+	l1 := newNativeLine(&λ1, p1)
+
+	// x3 = λ1²-x1-x2
+	x3.Square(&λ1).Sub(&x3, &p1.X).Sub(&x3, &p2.X)
+
+	// λ2 = -λ1-2y1/(x3-x1)
+	n.Double(&p1.Y)
+	d.Sub(&x3, &p1.X)
+	λ2.Div(&n, &d).Add(&λ2, &λ1).Neg(&λ2)
+	l2 := newNativeLine(&λ2, p1)
+
+	// x4 = λ2²-x1-x3, y4 = λ2(x1-x4)-y1
+	x4.Square(&λ2).Sub(&x4, &p1.X).Sub(&x4, &x3)
+	y4.Sub(&p1.X, &x4).Mul(&y4, &λ2).Sub(&y4, &p1.Y)
+	p1.X, p1.Y = x4, y4
+
+	return l1, l2
+}
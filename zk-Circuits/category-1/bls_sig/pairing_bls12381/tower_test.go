@@ -958,6 +958,35 @@ func TestFp12MulBy014(t *testing.T) {
 }
 
 // Torus-based arithmetic
+type e12Frobenius struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12Frobenius) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.Frobenius(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestFp12Frobenius(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bls12381.E12
+	_, _ = a.SetRandom()
+	c.Frobenius(&a)
+
+	witness := e12Frobenius{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12Frobenius{}, &witness, ecc.BLS12_381.ScalarField())
+	assert.NoError(err)
+}
+
 type torusCompress struct {
 	A E12
 	C E6 `gnark:",public"`
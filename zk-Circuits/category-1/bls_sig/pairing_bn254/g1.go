@@ -1,7 +1,11 @@
 package pairing_bn254
 
 import (
+	"errors"
+	"math/big"
+
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
 	"github.com/consensys/gnark/std/math/emulated"
 )
@@ -14,3 +18,252 @@ func NewG1Affine(v bn254.G1Affine) G1Affine {
 		Y: emulated.ValueOf[emulated.BN254Fp](v.Y),
 	}
 }
+
+// AssertIsOnG1 asserts that P is on G1. As the curve E(𝔽p): Y² = X³ + 3 has
+// prime order r, it only checks that P is on the curve.
+func (pr Pairing) AssertIsOnG1(P *G1Affine) {
+	three := emulated.ValueOf[emulated.BN254Fp](3)
+	left := pr.curveF.MulMod(&P.Y, &P.Y)
+	right := pr.curveF.MulMod(&P.X, &P.X)
+	right = pr.curveF.MulMod(right, &P.X)
+	right = pr.curveF.Add(right, &three)
+	pr.curveF.AssertIsEqual(left, right)
+}
+
+// AddG1 adds p and q in affine coordinates.
+//
+// ⚠️  p must be different than q and -q.
+func (pr Pairing) AddG1(p, q *G1Affine) *G1Affine {
+	// compute λ = (q.y-p.y)/(q.x-p.x)
+	qypy := pr.curveF.Sub(&q.Y, &p.Y)
+	qxpx := pr.curveF.Sub(&q.X, &p.X)
+	λ := pr.curveF.Div(qypy, qxpx)
+
+	// xr = λ²-p.x-q.x
+	λλ := pr.curveF.MulMod(λ, λ)
+	qxpx = pr.curveF.Add(&p.X, &q.X)
+	xr := pr.curveF.Sub(λλ, qxpx)
+
+	// yr = λ(p.x-xr) - p.y
+	pxrx := pr.curveF.Sub(&p.X, xr)
+	λpxrx := pr.curveF.MulMod(λ, pxrx)
+	yr := pr.curveF.Sub(λpxrx, &p.Y)
+
+	return &G1Affine{X: *xr, Y: *yr}
+}
+
+// AddG1Complete adds p and q in affine coordinates, where the point at
+// infinity is represented as (0,0). Contrary to [Pairing.AddG1], it handles
+// all the edge cases: p or q at infinity, p = q and p = -q.
+func (pr Pairing) AddG1Complete(p, q *G1Affine) *G1Affine {
+	pInf := pr.IsInfinityG1(p)
+	qInf := pr.IsInfinityG1(q)
+
+	// p.x = q.x happens for q = ±p, in which case we either double p or
+	// return the point at infinity.
+	xEq := pr.isZeroFp(pr.curveF.Sub(&q.X, &p.X))
+	isInf := pr.api.And(xEq, pr.isZeroFp(pr.curveF.Add(&p.Y, &q.Y)))
+
+	// λ = 3p.x²/2p.y if p.x = q.x, (q.y-p.y)/(q.x-p.x) otherwise
+	xx3 := pr.curveF.MulMod(&p.X, &p.X)
+	xx3 = pr.curveF.MulConst(xx3, big.NewInt(3))
+	num := pr.curveF.Select(xEq, xx3, pr.curveF.Sub(&q.Y, &p.Y))
+	den := pr.curveF.Select(xEq, pr.curveF.MulConst(&p.Y, big.NewInt(2)), pr.curveF.Sub(&q.X, &p.X))
+	// the denominator is zero only when the result is not used (p or q at
+	// infinity, or p = -q), in which case we divide by 1 instead.
+	den = pr.curveF.Select(pr.isZeroFp(den), pr.curveF.One(), den)
+	λ := pr.curveF.Div(num, den)
+
+	// xr = λ²-p.x-q.x
+	λλ := pr.curveF.MulMod(λ, λ)
+	xr := pr.curveF.Sub(λλ, pr.curveF.Add(&p.X, &q.X))
+
+	// yr = λ(p.x-xr) - p.y
+	yr := pr.curveF.MulMod(λ, pr.curveF.Sub(&p.X, xr))
+	yr = pr.curveF.Sub(yr, &p.Y)
+
+	res := &G1Affine{X: *xr, Y: *yr}
+	res = pr.SelectG1(isInf, pr.InfinityG1(), res)
+	res = pr.SelectG1(pInf, q, res)
+	res = pr.SelectG1(qInf, p, res)
+	return res
+}
+
+// InfinityG1 returns the point at infinity of G1, represented as (0,0) which
+// is not on the curve.
+func (pr Pairing) InfinityG1() *G1Affine {
+	return &G1Affine{
+		X: *pr.curveF.Zero(),
+		Y: *pr.curveF.Zero(),
+	}
+}
+
+// IsInfinityG1 returns 1 if p is the point at infinity (0,0) and 0 otherwise.
+func (pr Pairing) IsInfinityG1(p *G1Affine) frontend.Variable {
+	return pr.api.And(pr.isZeroFp(&p.X), pr.isZeroFp(&p.Y))
+}
+
+// SelectG1 returns p if b = 1 and q if b = 0.
+func (pr Pairing) SelectG1(b frontend.Variable, p, q *G1Affine) *G1Affine {
+	return &G1Affine{
+		X: *pr.curveF.Select(b, &p.X, &q.X),
+		Y: *pr.curveF.Select(b, &p.Y, &q.Y),
+	}
+}
+
+// ScalarMulSignedBitsG1 computes [s]P where
+//
+//	s = 3⋅2ⁿ + ∑ⱼ (2bⱼ-1)⋅2ʲ
+//
+// and b are the n bits given in little-endian order. The bits are not
+// asserted to be boolean.
+//
+// The scalar multiplication uses a left-to-right double-and-add algorithm
+// with signed digits ±1 so that every step is a double-and-add step following
+// [ELM03] (Section 3.1). For s < r, the incomplete formulas never hit an edge
+// case when P is in G1 \ {0}.
+//
+// [ELM03]: https://arxiv.org/pdf/math/0208038.pdf
+func (pr Pairing) ScalarMulSignedBitsG1(P *G1Affine, bits []frontend.Variable) *G1Affine {
+	negY := pr.curveF.Neg(&P.Y)
+	res := pr.tripleG1(P)
+	for j := len(bits) - 1; j >= 0; j-- {
+		Q := &G1Affine{
+			X: P.X,
+			Y: *pr.curveF.Select(bits[j], &P.Y, negY),
+		}
+		res = pr.doubleAndAddG1(res, Q)
+	}
+	return res
+}
+
+// MultiScalarMulG1 computes ∑ᵢ [sᵢ]Pᵢ where the scalars sᵢ are given by their
+// bits in little-endian order, possibly of different lengths. The bits are
+// not asserted to be boolean.
+//
+// It uses a left-to-right double-and-add algorithm sharing the doublings
+// between the points (Straus) with the complete formulas of
+// [Pairing.AddG1Complete], so that the points, the scalars and the result can
+// be zero.
+func (pr Pairing) MultiScalarMulG1(P []*G1Affine, scalars [][]frontend.Variable) (*G1Affine, error) {
+	if len(P) == 0 || len(P) != len(scalars) {
+		return nil, errors.New("invalid inputs sizes")
+	}
+	n := 0
+	for _, s := range scalars {
+		if len(s) > n {
+			n = len(s)
+		}
+	}
+	res := pr.InfinityG1()
+	for j := n - 1; j >= 0; j-- {
+		if j < n-1 {
+			res = pr.AddG1Complete(res, res)
+		}
+		for i := range P {
+			if j < len(scalars[i]) {
+				res = pr.AddG1Complete(res, pr.SelectG1(scalars[i][j], P[i], pr.InfinityG1()))
+			}
+		}
+	}
+	return res, nil
+}
+
+// doubleG1 doubles p in affine coordinates.
+func (pr Pairing) doubleG1(p *G1Affine) *G1Affine {
+	// λ = 3x²/2y
+	xx3 := pr.curveF.MulMod(&p.X, &p.X)
+	xx3 = pr.curveF.MulConst(xx3, big.NewInt(3))
+	y2 := pr.curveF.MulConst(&p.Y, big.NewInt(2))
+	λ := pr.curveF.Div(xx3, y2)
+
+	// xr = λ²-2x
+	x2 := pr.curveF.MulConst(&p.X, big.NewInt(2))
+	λλ := pr.curveF.MulMod(λ, λ)
+	xr := pr.curveF.Sub(λλ, x2)
+
+	// yr = λ(x-xr)-y
+	pxrx := pr.curveF.Sub(&p.X, xr)
+	λpxrx := pr.curveF.MulMod(λ, pxrx)
+	yr := pr.curveF.Sub(λpxrx, &p.Y)
+
+	return &G1Affine{
+		X: *pr.curveF.Reduce(xr),
+		Y: *pr.curveF.Reduce(yr),
+	}
+}
+
+// tripleG1 triples p in affine coordinates. It omits the computation of the y
+// coordinate of 2p.
+func (pr Pairing) tripleG1(p *G1Affine) *G1Affine {
+	// compute λ1 = 3p.x²/2p.y
+	xx := pr.curveF.MulMod(&p.X, &p.X)
+	xx = pr.curveF.MulConst(xx, big.NewInt(3))
+	y2 := pr.curveF.MulConst(&p.Y, big.NewInt(2))
+	λ1 := pr.curveF.Div(xx, y2)
+
+	// x2 = λ1²-2p.x
+	x2 := pr.curveF.MulConst(&p.X, big.NewInt(2))
+	λ1λ1 := pr.curveF.MulMod(λ1, λ1)
+	x2 = pr.curveF.Sub(λ1λ1, x2)
+
+	// omit y2 computation, and
+	// compute λ2 = 2p.y/(x2 − p.x) − λ1.
+	x1x2 := pr.curveF.Sub(&p.X, x2)
+	λ2 := pr.curveF.Div(y2, x1x2)
+	λ2 = pr.curveF.Sub(λ2, λ1)
+
+	// xr = λ2²-p.x-x2
+	λ2λ2 := pr.curveF.MulMod(λ2, λ2)
+	qxrx := pr.curveF.Add(x2, &p.X)
+	xr := pr.curveF.Sub(λ2λ2, qxrx)
+
+	// yr = λ2(p.x-xr) - p.y
+	pxrx := pr.curveF.Sub(&p.X, xr)
+	λ2pxrx := pr.curveF.MulMod(λ2, pxrx)
+	yr := pr.curveF.Sub(λ2pxrx, &p.Y)
+
+	return &G1Affine{
+		X: *pr.curveF.Reduce(xr),
+		Y: *pr.curveF.Reduce(yr),
+	}
+}
+
+// doubleAndAddG1 computes 2p+q as (p+q)+p in affine coordinates. It omits the
+// computation of the y coordinate of p+q.
+//
+// ⚠️  p must be different than q and -q.
+func (pr Pairing) doubleAndAddG1(p, q *G1Affine) *G1Affine {
+	// compute λ1 = (q.y-p.y)/(q.x-p.x)
+	yqyp := pr.curveF.Sub(&q.Y, &p.Y)
+	xqxp := pr.curveF.Sub(&q.X, &p.X)
+	λ1 := pr.curveF.Div(yqyp, xqxp)
+
+	// compute x2 = λ1²-p.x-q.x
+	λ1λ1 := pr.curveF.MulMod(λ1, λ1)
+	xqxp = pr.curveF.Add(&p.X, &q.X)
+	x2 := pr.curveF.Sub(λ1λ1, xqxp)
+
+	// omit y2 computation
+	// compute λ2 = -λ1-2*p.y/(x2-p.x)
+	ypyp := pr.curveF.Add(&p.Y, &p.Y)
+	x2xp := pr.curveF.Sub(x2, &p.X)
+	λ2 := pr.curveF.Div(ypyp, x2xp)
+	λ2 = pr.curveF.Add(λ1, λ2)
+	λ2 = pr.curveF.Neg(λ2)
+
+	// compute x3 = λ2²-p.x-x2
+	λ2λ2 := pr.curveF.MulMod(λ2, λ2)
+	x3 := pr.curveF.Sub(λ2λ2, &p.X)
+	x3 = pr.curveF.Sub(x3, x2)
+
+	// compute y3 = λ2*(p.x - x3)-p.y
+	y3 := pr.curveF.Sub(&p.X, x3)
+	y3 = pr.curveF.MulMod(λ2, y3)
+	y3 = pr.curveF.Sub(y3, &p.Y)
+
+	return &G1Affine{
+		X: *pr.curveF.Reduce(x3),
+		Y: *pr.curveF.Reduce(y3),
+	}
+}
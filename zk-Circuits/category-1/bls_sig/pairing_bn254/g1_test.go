@@ -0,0 +1,213 @@
+package pairing_bn254
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+// signedBitsScalar returns s = 3⋅2ⁿ + ∑ⱼ (2bⱼ-1)⋅2ʲ and the bits b of a random
+// n-bit integer.
+func signedBitsScalar(assert *test.Assert, n int) (*big.Int, []frontend.Variable) {
+	b, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), uint(n)))
+	assert.NoError(err)
+	// s = 3⋅2ⁿ + 2b - (2ⁿ-1)
+	s := new(big.Int).Lsh(big.NewInt(1), uint(n+1))
+	s.Add(s, big.NewInt(1))
+	s.Add(s, new(big.Int).Lsh(b, 1))
+	bits := make([]frontend.Variable, n)
+	for j := range bits {
+		bits[j] = b.Bit(j)
+	}
+	return s, bits
+}
+
+type ScalarMulSignedBitsG1Circuit struct {
+	P, R G1Affine
+	Bits []frontend.Variable
+}
+
+func (c *ScalarMulSignedBitsG1Circuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return err
+	}
+	res := pairing.ScalarMulSignedBitsG1(&c.P, c.Bits)
+	pairing.curveF.AssertIsEqual(&res.X, &c.R.X)
+	pairing.curveF.AssertIsEqual(&res.Y, &c.R.Y)
+	return nil
+}
+
+func TestScalarMulSignedBitsG1Solve(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n = 128
+	p, _ := randomG1G2Affines(assert)
+	s, bits := signedBitsScalar(assert, n)
+	var r bn254.G1Affine
+	r.ScalarMultiplication(&p, s)
+	witness := ScalarMulSignedBitsG1Circuit{
+		P:    NewG1Affine(p),
+		R:    NewG1Affine(r),
+		Bits: bits,
+	}
+	err := test.IsSolved(&ScalarMulSignedBitsG1Circuit{Bits: make([]frontend.Variable, n)}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type AddG1CompleteCircuit struct {
+	P, Q, R G1Affine
+}
+
+func (c *AddG1CompleteCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return err
+	}
+	res := pairing.AddG1Complete(&c.P, &c.Q)
+	pairing.curveF.AssertIsEqual(&res.X, &c.R.X)
+	pairing.curveF.AssertIsEqual(&res.Y, &c.R.Y)
+	return nil
+}
+
+func TestAddG1CompleteSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p, _ := randomG1G2Affines(assert)
+	q, _ := randomG1G2Affines(assert)
+	var negP, inf bn254.G1Affine
+	negP.Neg(&p)
+
+	for _, tc := range []struct {
+		name string
+		p, q bn254.G1Affine
+	}{
+		{"p+q", p, q},
+		{"p+p", p, p},
+		{"p-p", p, negP},
+		{"0+q", inf, q},
+		{"p+0", p, inf},
+		{"0+0", inf, inf},
+	} {
+		var r bn254.G1Affine
+		r.Add(&tc.p, &tc.q)
+		witness := AddG1CompleteCircuit{
+			P: NewG1Affine(tc.p),
+			Q: NewG1Affine(tc.q),
+			R: NewG1Affine(r),
+		}
+		err := test.IsSolved(&AddG1CompleteCircuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err, tc.name)
+	}
+}
+
+func TestAddG1CompleteHighLimbs(t *testing.T) {
+	assert := test.NewAssert(t)
+	p, _ := randomG1G2Affines(assert)
+
+	// q.X - p.X and p.Y + q.Y are nonzero multiples of 2⁶⁴, so that their
+	// first limbs are zero. The points are not on the curve but the chord
+	// formulas still apply.
+	var shift, λ, den fp.Element
+	shift.SetBigInt(new(big.Int).Lsh(big.NewInt(1), 64))
+	var q, r bn254.G1Affine
+	q.X.Add(&p.X, &shift)
+	q.Y.Neg(&p.Y).Add(&q.Y, &shift)
+	den.Sub(&q.X, &p.X).Inverse(&den)
+	λ.Sub(&q.Y, &p.Y).Mul(&λ, &den)
+	r.X.Square(&λ).Sub(&r.X, &p.X).Sub(&r.X, &q.X)
+	r.Y.Sub(&p.X, &r.X).Mul(&r.Y, &λ).Sub(&r.Y, &p.Y)
+
+	witness := AddG1CompleteCircuit{
+		P: NewG1Affine(p),
+		Q: NewG1Affine(q),
+		R: NewG1Affine(r),
+	}
+	err := test.IsSolved(&AddG1CompleteCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// (2⁶⁴, 2⁶⁴) is not the point at infinity
+	var s bn254.G1Affine
+	s.X, s.Y = shift, shift
+	den.Sub(&s.X, &p.X).Inverse(&den)
+	λ.Sub(&s.Y, &p.Y).Mul(&λ, &den)
+	r.X.Square(&λ).Sub(&r.X, &p.X).Sub(&r.X, &s.X)
+	r.Y.Sub(&p.X, &r.X).Mul(&r.Y, &λ).Sub(&r.Y, &p.Y)
+	witness = AddG1CompleteCircuit{
+		P: NewG1Affine(p),
+		Q: NewG1Affine(s),
+		R: NewG1Affine(r),
+	}
+	err = test.IsSolved(&AddG1CompleteCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type MultiScalarMulG1Circuit struct {
+	P1, P2, R G1Affine
+	S1, S2    []frontend.Variable
+}
+
+func (c *MultiScalarMulG1Circuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return err
+	}
+	res, err := pairing.MultiScalarMulG1([]*G1Affine{&c.P1, &c.P2}, [][]frontend.Variable{c.S1, c.S2})
+	if err != nil {
+		return err
+	}
+	pairing.curveF.AssertIsEqual(&res.X, &c.R.X)
+	pairing.curveF.AssertIsEqual(&res.Y, &c.R.Y)
+	return nil
+}
+
+func TestMultiScalarMulG1Solve(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n1, n2 = 64, 32
+	p1, _ := randomG1G2Affines(assert)
+	p2, _ := randomG1G2Affines(assert)
+	var negP1 bn254.G1Affine
+	negP1.Neg(&p1)
+	s1, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), n1))
+	assert.NoError(err)
+	s2, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), n2))
+	assert.NoError(err)
+
+	for _, tc := range []struct {
+		name   string
+		p1, p2 bn254.G1Affine
+		s1, s2 *big.Int
+	}{
+		{"random", p1, p2, s1, s2},
+		{"zero scalar", p1, p2, big.NewInt(0), s2},
+		{"zero result", p1, negP1, s2, s2},
+		{"zero point", p1, bn254.G1Affine{}, s1, s2},
+	} {
+		var r, tmp bn254.G1Affine
+		r.ScalarMultiplication(&tc.p1, tc.s1)
+		tmp.ScalarMultiplication(&tc.p2, tc.s2)
+		r.Add(&r, &tmp)
+		bits1 := make([]frontend.Variable, n1)
+		for j := range bits1 {
+			bits1[j] = tc.s1.Bit(j)
+		}
+		bits2 := make([]frontend.Variable, n2)
+		for j := range bits2 {
+			bits2[j] = tc.s2.Bit(j)
+		}
+		witness := MultiScalarMulG1Circuit{
+			P1: NewG1Affine(tc.p1),
+			P2: NewG1Affine(tc.p2),
+			R:  NewG1Affine(r),
+			S1: bits1,
+			S2: bits2,
+		}
+		circuit := MultiScalarMulG1Circuit{S1: make([]frontend.Variable, n1), S2: make([]frontend.Variable, n2)}
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err, tc.name)
+	}
+}
@@ -1,7 +1,10 @@
 package pairing_bn254
 
 import (
+	"math/big"
+
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/math/emulated"
 )
 
@@ -21,3 +24,228 @@ func NewG2Affine(v bn254.G2Affine) G2Affine {
 		},
 	}
 }
+
+// seed x₀=4965661367192848881 in binary (big-endian)
+var seedBits = "100010011101001100100101011010001001010011010010000100111110001"
+
+// AssertIsOnTwist asserts that Q is on the twist E'(𝔽p²):
+//
+//	Y² = X³ + 3/(9+u)
+func (pr Pairing) AssertIsOnTwist(Q *G2Affine) {
+	bTwist := E2{
+		A0: emulated.ValueOf[emulated.BN254Fp]("19485874751759354771024239261021720505790618469301721065564631296452457478373"),
+		A1: emulated.ValueOf[emulated.BN254Fp]("266929791119991161246907387137283842545076965332900288569378510910307636690"),
+	}
+	left := pr.Ext2.Square(&Q.Y)
+	right := pr.Ext2.Square(&Q.X)
+	right = pr.Ext2.Mul(right, &Q.X)
+	right = pr.Ext2.Add(right, &bTwist)
+	pr.Ext2.AssertIsEqual(left, right)
+}
+
+// AssertIsOnG2 asserts that Q is on the twist and in the r-torsion subgroup
+// G2.
+//
+// Instead of checking [r]Q = 0 we use the endomorphism ψ = 𝜓⁻¹∘π∘𝜓 (the
+// Frobenius on the twist) which acts on G2 as the multiplication by p, and
+// check following [Scott21] and [EHG22] (Prop. 5) that
+//
+//	[x₀+1]Q + ψ([x₀]Q) + ψ²([x₀]Q) = ψ³([2x₀]Q)
+//
+// so that the dominant cost is a scalar multiplication by the 63-bit seed x₀.
+//
+// The scalar multiplication uses incomplete affine formulas. For Q in G2 no
+// edge case can happen.
+//
+// [Scott21]: https://eprint.iacr.org/2021/1130.pdf
+// [EHG22]: https://eprint.iacr.org/2022/352.pdf
+func (pr Pairing) AssertIsOnG2(Q *G2Affine) {
+	// 1- Check Q is on the twist
+	pr.AssertIsOnTwist(Q)
+
+	// 2- Check Q has the right subgroup order
+	// [x₀]Q
+	xQ := pr.scalarMulBySeed(Q)
+	// ψ([x₀]Q)
+	psixQ := pr.psi(xQ)
+	// ψ²([x₀]Q)
+	psi2xQ := pr.psi2(xQ)
+	// ψ³([2x₀]Q)
+	psi3xxQ := pr.psi(pr.doubleG2(psi2xQ))
+
+	// _Q = [x₀+1]Q + ψ([x₀]Q) + ψ²([x₀]Q)
+	_Q := pr.AddG2(xQ, Q)
+	_Q = pr.AddG2(_Q, psixQ)
+	_Q = pr.AddG2(_Q, psi2xQ)
+
+	// Q is in G2 if and only if _Q == ψ³([2x₀]Q)
+	pr.Ext2.AssertIsEqual(&_Q.X, &psi3xxQ.X)
+	pr.Ext2.AssertIsEqual(&_Q.Y, &psi3xxQ.Y)
+}
+
+// psi computes ψ(Q) = (Q.X^p ⋅ (9+u)^((p-1)/3), Q.Y^p ⋅ (9+u)^((p-1)/2))
+func (pr Pairing) psi(Q *G2Affine) *G2Affine {
+	x := pr.Ext2.Conjugate(&Q.X)
+	x = pr.Ext2.MulByNonResidue1Power2(x)
+	y := pr.Ext2.Conjugate(&Q.Y)
+	y = pr.Ext2.MulByNonResidue1Power3(y)
+	return &G2Affine{X: *x, Y: *y}
+}
+
+// psi2 computes ψ²(Q) = (Q.X ⋅ (9+u)^((p²-1)/3), -Q.Y)
+func (pr Pairing) psi2(Q *G2Affine) *G2Affine {
+	x := pr.Ext2.MulByNonResidue2Power2(&Q.X)
+	y := pr.Ext2.Neg(&Q.Y)
+	return &G2Affine{X: *x, Y: *y}
+}
+
+// scalarMulBySeed computes [x₀]Q using a left-to-right double-and-add
+// algorithm where the double-and-add steps follow [ELM03] (Section 3.1).
+//
+// [ELM03]: https://arxiv.org/pdf/math/0208038.pdf
+func (pr Pairing) scalarMulBySeed(Q *G2Affine) *G2Affine {
+	res := Q
+	for i := 1; i < len(seedBits); i++ {
+		if seedBits[i] == '1' {
+			res = pr.doubleAndAddG2(res, Q)
+		} else {
+			res = pr.doubleG2(res)
+		}
+	}
+	return res
+}
+
+// ScalarMulSignedBitsG2 computes [s]Q where
+//
+//	s = 3⋅2ⁿ + ∑ⱼ (2bⱼ-1)⋅2ʲ
+//
+// and b are the n bits given in little-endian order. The bits are not
+// asserted to be boolean.
+//
+// As for [Pairing.ScalarMulSignedBitsG1], every step is a double-and-add step
+// and for s < r the incomplete formulas never hit an edge case when Q is in
+// G2 \ {0}.
+func (pr Pairing) ScalarMulSignedBitsG2(Q *G2Affine, bits []frontend.Variable) *G2Affine {
+	negY := pr.Ext2.Neg(&Q.Y)
+	res := pr.tripleG2(Q)
+	for j := len(bits) - 1; j >= 0; j-- {
+		R := &G2Affine{
+			X: Q.X,
+			Y: *pr.Ext2.Select(bits[j], &Q.Y, negY),
+		}
+		res = pr.doubleAndAddG2(res, R)
+	}
+	return res
+}
+
+// AddG2 adds p and q in affine coordinates.
+//
+// ⚠️  p must be different than q and -q.
+func (pr Pairing) AddG2(p, q *G2Affine) *G2Affine {
+	// compute λ = (q.y-p.y)/(q.x-p.x)
+	qypy := pr.Ext2.Sub(&q.Y, &p.Y)
+	qxpx := pr.Ext2.Sub(&q.X, &p.X)
+	λ := pr.Ext2.DivUnchecked(qypy, qxpx)
+
+	// xr = λ²-p.x-q.x
+	λλ := pr.Ext2.Square(λ)
+	qxpx = pr.Ext2.Add(&p.X, &q.X)
+	xr := pr.Ext2.Sub(λλ, qxpx)
+
+	// yr = λ(p.x-xr) - p.y
+	pxrx := pr.Ext2.Sub(&p.X, xr)
+	λpxrx := pr.Ext2.Mul(λ, pxrx)
+	yr := pr.Ext2.Sub(λpxrx, &p.Y)
+
+	return &G2Affine{X: *xr, Y: *yr}
+}
+
+// doubleG2 doubles p in affine coordinates.
+func (pr Pairing) doubleG2(p *G2Affine) *G2Affine {
+	// λ = 3x²/2y
+	n := pr.Ext2.Square(&p.X)
+	n = pr.Ext2.MulByConstElement(n, big.NewInt(3))
+	d := pr.Ext2.Double(&p.Y)
+	λ := pr.Ext2.DivUnchecked(n, d)
+
+	// xr = λ²-2x
+	xr := pr.Ext2.Square(λ)
+	xr = pr.Ext2.Sub(xr, &p.X)
+	xr = pr.Ext2.Sub(xr, &p.X)
+
+	// yr = λ(x-xr)-y
+	yr := pr.Ext2.Sub(&p.X, xr)
+	yr = pr.Ext2.Mul(λ, yr)
+	yr = pr.Ext2.Sub(yr, &p.Y)
+
+	return &G2Affine{X: *xr, Y: *yr}
+}
+
+// tripleG2 triples p in affine coordinates. It omits the computation of the y
+// coordinate of 2p.
+func (pr Pairing) tripleG2(p *G2Affine) *G2Affine {
+	// λ1 = 3x²/2y
+	n := pr.Ext2.Square(&p.X)
+	n = pr.Ext2.MulByConstElement(n, big.NewInt(3))
+	d := pr.Ext2.Double(&p.Y)
+	λ1 := pr.Ext2.DivUnchecked(n, d)
+
+	// x2 = λ1²-2x
+	x2 := pr.Ext2.Square(λ1)
+	x2 = pr.Ext2.Sub(x2, &p.X)
+	x2 = pr.Ext2.Sub(x2, &p.X)
+
+	// omit y2 computation, and
+	// compute λ2 = 2y/(x2 − x) − λ1.
+	x1x2 := pr.Ext2.Sub(&p.X, x2)
+	λ2 := pr.Ext2.DivUnchecked(d, x1x2)
+	λ2 = pr.Ext2.Sub(λ2, λ1)
+
+	// xr = λ2²-p.x-x2
+	λ2λ2 := pr.Ext2.Square(λ2)
+	qxrx := pr.Ext2.Add(x2, &p.X)
+	xr := pr.Ext2.Sub(λ2λ2, qxrx)
+
+	// yr = λ2(p.x-xr) - p.y
+	pxrx := pr.Ext2.Sub(&p.X, xr)
+	λ2pxrx := pr.Ext2.Mul(λ2, pxrx)
+	yr := pr.Ext2.Sub(λ2pxrx, &p.Y)
+
+	return &G2Affine{X: *xr, Y: *yr}
+}
+
+// doubleAndAddG2 computes 2p+q as (p+q)+p in affine coordinates. It omits the
+// computation of the y coordinate of p+q.
+//
+// ⚠️  p must be different than q and -q.
+func (pr Pairing) doubleAndAddG2(p, q *G2Affine) *G2Affine {
+	// compute λ1 = (q.y-p.y)/(q.x-p.x)
+	n := pr.Ext2.Sub(&q.Y, &p.Y)
+	d := pr.Ext2.Sub(&q.X, &p.X)
+	λ1 := pr.Ext2.DivUnchecked(n, d)
+
+	// compute x2 = λ1²-p.x-q.x
+	x2 := pr.Ext2.Square(λ1)
+	x2 = pr.Ext2.Sub(x2, &p.X)
+	x2 = pr.Ext2.Sub(x2, &q.X)
+
+	// omit y2 computation
+	// compute λ2 = -λ1-2*p.y/(x2-p.x)
+	n = pr.Ext2.Double(&p.Y)
+	d = pr.Ext2.Sub(x2, &p.X)
+	λ2 := pr.Ext2.DivUnchecked(n, d)
+	λ2 = pr.Ext2.Add(λ1, λ2)
+	λ2 = pr.Ext2.Neg(λ2)
+
+	// compute x3 = λ2²-p.x-x2
+	x3 := pr.Ext2.Square(λ2)
+	x3 = pr.Ext2.Sub(x3, &p.X)
+	x3 = pr.Ext2.Sub(x3, x2)
+
+	// compute y3 = λ2*(p.x - x3)-p.y
+	y3 := pr.Ext2.Sub(&p.X, x3)
+	y3 = pr.Ext2.Mul(λ2, y3)
+	y3 = pr.Ext2.Sub(y3, &p.Y)
+
+	return &G2Affine{X: *x3, Y: *y3}
+}
@@ -0,0 +1,179 @@
+package pairing_bn254
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/sha256"
+)
+
+// HashToG1 hashes the message msg to a point in G1 following the
+// BN254G1_XMD:SHA-256_SVDW_RO_ suite of [RFC9380] with the domain separation
+// tag dst. The bytes of msg are given as frontend.Variable and its length is
+// fixed at compile time.
+//
+// The map to curve uses incomplete affine formulas. Since its inputs are
+// outputs of SHA-256, the exceptional cases happen only with negligible
+// probability and lead to an unsatisfiable circuit.
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (pr Pairing) HashToG1(msg []frontend.Variable, dst []byte) (*G1Affine, error) {
+	// 1. u = hash_to_field(msg, 2)
+	u, err := pr.hashToFp(msg, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+	// 2. Q0 = map_to_curve(u[0])
+	Q0 := pr.mapToCurve1(u[0])
+	// 3. Q1 = map_to_curve(u[1])
+	Q1 := pr.mapToCurve1(u[1])
+	// 4. R = Q0 + Q1
+	// 5. P = clear_cofactor(R) is the identity as G1 has cofactor 1
+	return pr.AddG1(Q0, Q1), nil
+}
+
+// hashToFp implements hash_to_field from [RFC9380] (Section 5.2) for count
+// elements of 𝔽p. Each element is obtained by reducing L = 48 pseudo-random
+// bytes modulo p.
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (pr Pairing) hashToFp(msg []frontend.Variable, dst []byte, count int) ([]*baseEl, error) {
+	const L = 48
+	uniformBytes, err := sha256.ExpandMsgXmd(pr.api, msg, dst, count*L)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2²⁵⁶ mod p
+	shift := emulated.ValueOf[emulated.BN254Fp]("6350874878119819312338956282401532409788428879151445726012394534686998597021")
+	res := make([]*baseEl, count)
+	for i := range res {
+		// OS2IP(tv) = hi ⋅ 2²⁵⁶ + lo
+		hi := pr.fromBytesBE(uniformBytes[i*L : i*L+16])
+		lo := pr.fromBytesBE(uniformBytes[i*L+16 : (i+1)*L])
+		res[i] = pr.curveF.Add(pr.curveF.MulMod(hi, &shift), lo)
+	}
+	return res, nil
+}
+
+// fromBytesBE returns the element of 𝔽p whose big-endian encoding is b. The
+// integer encoded by b must be less than 2²⁵⁶.
+func (pr Pairing) fromBytesBE(b []frontend.Variable) *baseEl {
+	// 256 little-endian bits, zero-padded to fill all the limbs
+	res := make([]frontend.Variable, 0, 256)
+	for i := len(b) - 1; i >= 0; i-- {
+		res = append(res, bits.ToBinary(pr.api, b[i], bits.WithNbDigits(8))...)
+	}
+	for len(res) < 256 {
+		res = append(res, 0)
+	}
+	return pr.curveF.FromBits(res...)
+}
+
+// sgn0 returns the "sign" of x as defined in [RFC9380] (Section 4.1) for 𝔽p,
+// i.e. the parity of x in [0, p).
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (pr Pairing) sgn0(x *baseEl) frontend.Variable {
+	r := pr.curveF.Reduce(x)
+	pr.curveF.AssertIsInRange(r)
+	return pr.curveF.ToBits(r)[0]
+}
+
+// mapToCurve1 implements the Shallue–van de Woestijne map [RFC9380] (Section
+// 6.6.1) to the curve E(𝔽p): Y² = X³ + 3 with Z = 1.
+//
+// The map selects the first of three candidates x₁, x₂, x₃ such that g(xᵢ) is
+// a square. Instead of computing Legendre symbols in-circuit, we get from a
+// hint for i = 1, 2 an element wᵢ such that wᵢ² = g(xᵢ) if g(xᵢ) is a square
+// and wᵢ² = -g(xᵢ) otherwise. Since -1 is a non-square in 𝔽p, this proves
+// which of g(x₁), g(x₂) are squares (unless g(xᵢ) = 0, which happens with
+// negligible probability) so that the prover has no choice on x.
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (pr Pairing) mapToCurve1(u *baseEl) *G1Affine {
+	// c₁ = g(Z)
+	c1 := emulated.ValueOf[emulated.BN254Fp](4)
+	// c₂ = -Z / 2
+	c2 := emulated.ValueOf[emulated.BN254Fp]("10944121435919637611123202872628637544348155578648911831344518947322613104291")
+	// c₃ = sqrt(-g(Z) * (3 * Z² + 4 * A)) with sgn0(c₃) = 0
+	c3 := emulated.ValueOf[emulated.BN254Fp]("8815841940592487685674414971303048083897117035520822607866")
+	// c₄ = -4 * g(Z) / (3 * Z² + 4 * A)
+	c4 := emulated.ValueOf[emulated.BN254Fp]("7296080957279758407415468581752425029565437052432607887563012631548408736189")
+	Z := pr.curveF.One()
+	one := pr.curveF.One()
+
+	// tv1 = u²⋅c₁
+	tv1 := pr.curveF.MulMod(u, u)
+	tv1 = pr.curveF.MulMod(tv1, &c1)
+	// tv2 = 1 + tv1
+	tv2 := pr.curveF.Add(one, tv1)
+	// tv1 = 1 - tv1
+	tv1 = pr.curveF.Sub(one, tv1)
+	// tv3 = tv1⋅tv2
+	// The exceptional case tv3 = 0 makes the circuit unsatisfiable.
+	tv3 := pr.curveF.MulMod(tv1, tv2)
+	// tv4 = u⋅tv1⋅c₃/tv3
+	tv4 := pr.curveF.MulMod(u, tv1)
+	tv4 = pr.curveF.MulMod(tv4, &c3)
+	tv4 = pr.curveF.Div(tv4, tv3)
+	// x₁ = c₂ - tv4
+	x1 := pr.curveF.Sub(&c2, tv4)
+	// x₂ = c₂ + tv4
+	x2 := pr.curveF.Add(&c2, tv4)
+	// x₃ = c₄⋅(tv2²/tv3)² + Z
+	x3 := pr.curveF.MulMod(tv2, tv2)
+	x3 = pr.curveF.Div(x3, tv3)
+	x3 = pr.curveF.MulMod(x3, x3)
+	x3 = pr.curveF.MulMod(x3, &c4)
+	x3 = pr.curveF.Add(x3, Z)
+
+	gx1 := pr.g1CurveEval(x1)
+	gx2 := pr.g1CurveEval(x2)
+	gx3 := pr.g1CurveEval(x3)
+	e1 := pr.isSquare(gx1)
+	e2 := pr.isSquare(gx2)
+
+	// x = x₁ if g(x₁) is a square, else x₂ if g(x₂) is a square, else x₃
+	x := pr.curveF.Select(e2, x2, x3)
+	x = pr.curveF.Select(e1, x1, x)
+	gx := pr.curveF.Select(e2, gx2, gx3)
+	gx = pr.curveF.Select(e1, gx1, gx)
+
+	// y = sqrt(g(x))
+	res, err := pr.curveF.NewHint(sqrtHint, 1, gx)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	y := res[0]
+	pr.curveF.AssertIsEqual(pr.curveF.MulMod(y, y), gx)
+
+	// y = -y if sgn0(u) ≠ sgn0(y)
+	e3 := pr.api.Xor(pr.sgn0(u), pr.sgn0(y))
+	y = pr.curveF.Select(e3, pr.curveF.Neg(y), y)
+
+	return &G1Affine{X: *x, Y: *y}
+}
+
+// g1CurveEval returns g(x) = x³ + 3.
+func (pr Pairing) g1CurveEval(x *baseEl) *baseEl {
+	three := emulated.ValueOf[emulated.BN254Fp](3)
+	gx := pr.curveF.MulMod(x, x)
+	gx = pr.curveF.MulMod(gx, x)
+	return pr.curveF.Add(gx, &three)
+}
+
+// isSquare returns 1 if x is a square in 𝔽p and 0 otherwise, using a square
+// root of x or -x given by a hint. x must be non-zero.
+func (pr Pairing) isSquare(x *baseEl) frontend.Variable {
+	res, err := pr.curveF.NewHint(sqrtHint, 1, x)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	ww := pr.curveF.MulMod(res[0], res[0])
+	e := pr.isZeroFp(pr.curveF.Sub(ww, x))
+	pr.curveF.AssertIsEqual(ww, pr.curveF.Select(e, x, pr.curveF.Neg(x)))
+	return e
+}
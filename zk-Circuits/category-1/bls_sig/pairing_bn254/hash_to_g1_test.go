@@ -0,0 +1,67 @@
+package pairing_bn254
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+const testDST = "BLS_SIG_BN254G2_XMD:SHA-256_SVDW_RO_NUL_"
+
+func bytesToVariables(b []byte) []frontend.Variable {
+	res := make([]frontend.Variable, len(b))
+	for i := range b {
+		res[i] = b[i]
+	}
+	return res
+}
+
+type HashToG1Circuit struct {
+	Msg      []frontend.Variable
+	Expected G1Affine
+}
+
+func (c *HashToG1Circuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return err
+	}
+	res, err := pairing.HashToG1(c.Msg, []byte(testDST))
+	if err != nil {
+		return err
+	}
+	pairing.curveF.AssertIsEqual(&res.X, &c.Expected.X)
+	pairing.curveF.AssertIsEqual(&res.Y, &c.Expected.Y)
+	return nil
+}
+
+func TestHashToG1Solve(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, msg := range []string{"", "abc", "Hello, World!"} {
+		h, err := bn254.HashToG1([]byte(msg), []byte(testDST))
+		assert.NoError(err)
+		witness := HashToG1Circuit{
+			Msg:      bytesToVariables([]byte(msg)),
+			Expected: NewG1Affine(h),
+		}
+		circuit := HashToG1Circuit{Msg: make([]frontend.Variable, len(msg))}
+		err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+func TestHashToG1FailsWrongMessage(t *testing.T) {
+	assert := test.NewAssert(t)
+	h, err := bn254.HashToG1([]byte("abc"), []byte(testDST))
+	assert.NoError(err)
+	witness := HashToG1Circuit{
+		Msg:      bytesToVariables([]byte("abd")),
+		Expected: NewG1Affine(h),
+	}
+	circuit := HashToG1Circuit{Msg: make([]frontend.Variable, 3)}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -0,0 +1,182 @@
+package pairing_bn254
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// HashToG2 hashes the message msg to a point in G2 following the
+// BN254G2_XMD:SHA-256_SVDW_RO_ suite of [RFC9380] with the domain separation
+// tag dst. The bytes of msg are given as frontend.Variable and its length is
+// fixed at compile time.
+//
+// The map to curve uses incomplete affine formulas. Since its inputs are
+// outputs of SHA-256, the exceptional cases happen only with negligible
+// probability and lead to an unsatisfiable circuit.
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (pr Pairing) HashToG2(msg []frontend.Variable, dst []byte) (*G2Affine, error) {
+	// 1. u = hash_to_field(msg, 2)
+	u, err := pr.hashToFp(msg, dst, 4)
+	if err != nil {
+		return nil, err
+	}
+	// 2. Q0 = map_to_curve(u[0])
+	Q0 := pr.mapToCurve2(&E2{A0: *u[0], A1: *u[1]})
+	// 3. Q1 = map_to_curve(u[1])
+	Q1 := pr.mapToCurve2(&E2{A0: *u[2], A1: *u[3]})
+	// 4. R = Q0 + Q1
+	R := pr.AddG2(Q0, Q1)
+	// 5. P = clear_cofactor(R)
+	return pr.clearCofactorG2(R), nil
+}
+
+// sgn0E2 returns the "sign" of z as defined in [RFC9380] (Section 4.1) for
+// 𝔽p², i.e. sgn0(z.A0) ∨ (z.A0 == 0 ∧ sgn0(z.A1)).
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (pr Pairing) sgn0E2(z *E2) frontend.Variable {
+	a0 := pr.curveF.Reduce(&z.A0)
+	pr.curveF.AssertIsInRange(a0)
+	sign0 := pr.curveF.ToBits(a0)[0]
+	zero0 := pr.isZeroFp(a0)
+	sign1 := pr.sgn0(&z.A1)
+	return pr.api.Or(sign0, pr.api.And(zero0, sign1))
+}
+
+// mapToCurve2 implements the Shallue–van de Woestijne map [RFC9380] (Section
+// 6.6.1) to the twist E'(𝔽p²): Y² = X³ + 3/(9+u) with Z = 1.
+//
+// As in [Pairing.mapToCurve1], the square candidates are proven with hints:
+// for i = 1, 2 we get wᵢ such that wᵢ² = g(xᵢ) if g(xᵢ) is a square and
+// wᵢ² = (9+u)⋅g(xᵢ) otherwise, 9+u being a non-square in 𝔽p².
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (pr Pairing) mapToCurve2(u *E2) *G2Affine {
+	// c₁ = g(Z)
+	c1 := E2{
+		A0: emulated.ValueOf[emulated.BN254Fp]("19485874751759354771024239261021720505790618469301721065564631296452457478374"),
+		A1: emulated.ValueOf[emulated.BN254Fp]("266929791119991161246907387137283842545076965332900288569378510910307636690"),
+	}
+	// c₂ = -Z / 2
+	c2 := E2{
+		A0: emulated.ValueOf[emulated.BN254Fp]("10944121435919637611123202872628637544348155578648911831344518947322613104291"),
+		A1: emulated.ValueOf[emulated.BN254Fp](0),
+	}
+	// c₃ = sqrt(-g(Z) * (3 * Z² + 4 * A)) with sgn0(c₃) = 0
+	c3 := E2{
+		A0: emulated.ValueOf[emulated.BN254Fp]("18992192239972082890849143911285057164064277369389217330423471574879236301292"),
+		A1: emulated.ValueOf[emulated.BN254Fp]("21819008332247140148575583693947636719449476128975323941588917397607662637108"),
+	}
+	// c₄ = -4 * g(Z) / (3 * Z² + 4 * A)
+	c4 := E2{
+		A0: emulated.ValueOf[emulated.BN254Fp]("10499238450719652342378357227399831140106360636427411350395554762472100376473"),
+		A1: emulated.ValueOf[emulated.BN254Fp]("6940174569119770192419592065569379906172001098655407502803841283667998553941"),
+	}
+	Z := pr.Ext2.One()
+	one := pr.Ext2.One()
+
+	// tv1 = u²⋅c₁
+	tv1 := pr.Ext2.Square(u)
+	tv1 = pr.Ext2.Mul(tv1, &c1)
+	// tv2 = 1 + tv1
+	tv2 := pr.Ext2.Add(one, tv1)
+	// tv1 = 1 - tv1
+	tv1 = pr.Ext2.Sub(one, tv1)
+	// tv3 = tv1⋅tv2
+	// The exceptional case tv3 = 0 makes the circuit unsatisfiable.
+	tv3 := pr.Ext2.Mul(tv1, tv2)
+	// tv4 = u⋅tv1⋅c₃/tv3
+	tv4 := pr.Ext2.Mul(u, tv1)
+	tv4 = pr.Ext2.Mul(tv4, &c3)
+	tv4 = pr.Ext2.DivUnchecked(tv4, tv3)
+	// x₁ = c₂ - tv4
+	x1 := pr.Ext2.Sub(&c2, tv4)
+	// x₂ = c₂ + tv4
+	x2 := pr.Ext2.Add(&c2, tv4)
+	// x₃ = c₄⋅(tv2²/tv3)² + Z
+	x3 := pr.Ext2.Square(tv2)
+	x3 = pr.Ext2.DivUnchecked(x3, tv3)
+	x3 = pr.Ext2.Square(x3)
+	x3 = pr.Ext2.Mul(x3, &c4)
+	x3 = pr.Ext2.Add(x3, Z)
+
+	gx1 := pr.g2CurveEval(x1)
+	gx2 := pr.g2CurveEval(x2)
+	gx3 := pr.g2CurveEval(x3)
+	e1 := pr.isSquareE2(gx1)
+	e2 := pr.isSquareE2(gx2)
+
+	// x = x₁ if g(x₁) is a square, else x₂ if g(x₂) is a square, else x₃
+	x := pr.Ext2.Select(e2, x2, x3)
+	x = pr.Ext2.Select(e1, x1, x)
+	gx := pr.Ext2.Select(e2, gx2, gx3)
+	gx = pr.Ext2.Select(e1, gx1, gx)
+
+	// y = sqrt(g(x))
+	res, err := pr.curveF.NewHint(sqrtE2Hint, 2, &gx.A0, &gx.A1)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	y := &E2{A0: *res[0], A1: *res[1]}
+	pr.Ext2.AssertIsEqual(pr.Ext2.Square(y), gx)
+
+	// y = -y if sgn0(u) ≠ sgn0(y)
+	e3 := pr.api.Xor(pr.sgn0E2(u), pr.sgn0E2(y))
+	y = pr.Ext2.Select(e3, pr.Ext2.Neg(y), y)
+
+	return &G2Affine{X: *x, Y: *y}
+}
+
+// g2CurveEval returns g(x) = x³ + 3/(9+u).
+func (pr Pairing) g2CurveEval(x *E2) *E2 {
+	bTwist := E2{
+		A0: emulated.ValueOf[emulated.BN254Fp]("19485874751759354771024239261021720505790618469301721065564631296452457478373"),
+		A1: emulated.ValueOf[emulated.BN254Fp]("266929791119991161246907387137283842545076965332900288569378510910307636690"),
+	}
+	gx := pr.Ext2.Square(x)
+	gx = pr.Ext2.Mul(gx, x)
+	return pr.Ext2.Add(gx, &bTwist)
+}
+
+// isSquareE2 returns 1 if x is a square in 𝔽p² and 0 otherwise, using a
+// square root of x or (9+u)⋅x given by a hint. x must be non-zero.
+func (pr Pairing) isSquareE2(x *E2) frontend.Variable {
+	res, err := pr.curveF.NewHint(sqrtE2Hint, 2, &x.A0, &x.A1)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+	w := &E2{A0: *res[0], A1: *res[1]}
+	ww := pr.Ext2.Square(w)
+	e := pr.Ext2.IsZero(pr.Ext2.Sub(ww, x))
+	pr.Ext2.AssertIsEqual(ww, pr.Ext2.Select(e, x, pr.Ext2.MulByNonResidue(x)))
+	return e
+}
+
+// clearCofactorG2 maps a point Q on the twist to G2 by multiplying it by the
+// cofactor, following [FKR11] (Section 6.1):
+//
+//	[x₀]Q + ψ([3x₀]Q) + ψ²([x₀]Q) + ψ³(Q)
+//
+// The scalar multiplication and additions use incomplete affine formulas. For
+// Q output of the map to curve, the edge cases happen only with negligible
+// probability.
+//
+// [FKR11]: http://cacr.uwaterloo.ca/techreports/2011/cacr2011-26.pdf
+func (pr Pairing) clearCofactorG2(Q *G2Affine) *G2Affine {
+	// [x₀]Q
+	xQ := pr.scalarMulBySeed(Q)
+	// ψ([3x₀]Q)
+	res := pr.AddG2(pr.doubleG2(xQ), xQ)
+	res = pr.psi(res)
+	// + [x₀]Q
+	res = pr.AddG2(res, xQ)
+	// + ψ²([x₀]Q)
+	res = pr.AddG2(res, pr.psi2(xQ))
+	// + ψ³(Q)
+	res = pr.AddG2(res, pr.psi(pr.psi2(Q)))
+
+	return res
+}
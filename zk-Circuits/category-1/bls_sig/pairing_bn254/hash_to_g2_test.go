@@ -0,0 +1,98 @@
+package pairing_bn254
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+type HashToG2Circuit struct {
+	Msg      []frontend.Variable
+	Expected G2Affine
+}
+
+func (c *HashToG2Circuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return err
+	}
+	res, err := pairing.HashToG2(c.Msg, []byte(testDST))
+	if err != nil {
+		return err
+	}
+	pairing.Ext2.AssertIsEqual(&res.X, &c.Expected.X)
+	pairing.Ext2.AssertIsEqual(&res.Y, &c.Expected.Y)
+	return nil
+}
+
+func TestHashToG2Solve(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, msg := range []string{"", "abc", "Hello, World!"} {
+		h, err := bn254.HashToG2([]byte(msg), []byte(testDST))
+		assert.NoError(err)
+		witness := HashToG2Circuit{
+			Msg:      bytesToVariables([]byte(msg)),
+			Expected: NewG2Affine(h),
+		}
+		circuit := HashToG2Circuit{Msg: make([]frontend.Variable, len(msg))}
+		err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+func TestHashToG2FailsWrongMessage(t *testing.T) {
+	assert := test.NewAssert(t)
+	h, err := bn254.HashToG2([]byte("abc"), []byte(testDST))
+	assert.NoError(err)
+	witness := HashToG2Circuit{
+		Msg:      bytesToVariables([]byte("abd")),
+		Expected: NewG2Affine(h),
+	}
+	circuit := HashToG2Circuit{Msg: make([]frontend.Variable, 3)}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type Sgn0E2Circuit struct {
+	Z        E2
+	Expected frontend.Variable
+}
+
+func (c *Sgn0E2Circuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(pairing.sgn0E2(&c.Z), c.Expected)
+	return nil
+}
+
+func TestSgn0E2(t *testing.T) {
+	assert := test.NewAssert(t)
+	// z.A0 = 2⁶⁴ is even and nonzero although its first limb is zero, so that
+	// the sign of z is 0 whatever the sign of z.A1.
+	two64 := new(big.Int).Lsh(big.NewInt(1), 64)
+	for _, tc := range []struct {
+		a0, a1   *big.Int
+		expected int
+	}{
+		{two64, big.NewInt(1), 0},
+		{big.NewInt(0), big.NewInt(1), 1},
+		{big.NewInt(1), big.NewInt(0), 1},
+	} {
+		witness := Sgn0E2Circuit{
+			Z: E2{
+				A0: emulated.ValueOf[emulated.BN254Fp](tc.a0),
+				A1: emulated.ValueOf[emulated.BN254Fp](tc.a1),
+			},
+			Expected: tc.expected,
+		}
+		err := test.IsSolved(&Sgn0E2Circuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
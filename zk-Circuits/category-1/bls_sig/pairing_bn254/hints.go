@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/std/math/emulated"
 )
@@ -15,9 +16,12 @@ func init() {
 // GetHints returns all hint functions used in the package.
 func GetHints() []solver.Hint {
 	return []solver.Hint{
+		// Fp
+		sqrtHint,
 		// E2
 		divE2Hint,
 		inverseE2Hint,
+		sqrtE2Hint,
 		// E6
 		divE6Hint,
 		inverseE6Hint,
@@ -25,9 +29,31 @@ func GetHints() []solver.Hint {
 		// E12
 		divE12Hint,
 		inverseE12Hint,
+		// Pairing
+		residueWitnessHint,
 	}
 }
 
+// sqrtHint returns a square root of x if x is a square in 𝔽p, and a square
+// root of -x otherwise (-1 is a non-square in 𝔽p).
+func sqrtHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var x, c fp.Element
+
+			x.SetBigInt(inputs[0])
+
+			if x.Legendre() == -1 {
+				x.Neg(&x)
+			}
+			c.Sqrt(&x)
+
+			c.BigInt(outputs[0])
+
+			return nil
+		})
+}
+
 func inverseE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
 	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
 		func(mod *big.Int, inputs, outputs []*big.Int) error {
@@ -64,6 +90,28 @@ func divE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error
 		})
 }
 
+// sqrtE2Hint returns a square root of x if x is a square in 𝔽p², and a square
+// root of (9+u)⋅x otherwise (9+u is a non-square in 𝔽p²).
+func sqrtE2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			var x, c bn254.E2
+
+			x.A0.SetBigInt(inputs[0])
+			x.A1.SetBigInt(inputs[1])
+
+			if x.Legendre() == -1 {
+				x.MulByNonResidue(&x)
+			}
+			c.Sqrt(&x)
+
+			c.A0.BigInt(outputs[0])
+			c.A1.BigInt(outputs[1])
+
+			return nil
+		})
+}
+
 // E6 hints
 func inverseE6Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
 	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
@@ -236,3 +284,47 @@ func divE12Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) erro
 			return nil
 		})
 }
+
+// residueWitnessHint returns the residue witness c such that c^λ = f ⋅ u for
+// some u ∈ 𝔽p⁶, where f is the Miller loop of the input points and
+// λ = 6x₀+2 + p - p² + p³ (see [Pairing.PairingCheckResidue]).
+func residueWitnessHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			n := len(inputs) / 6
+			P := make([]bn254.G1Affine, n)
+			Q := make([]bn254.G2Affine, n)
+			for k := 0; k < n; k++ {
+				P[k].X.SetBigInt(inputs[6*k])
+				P[k].Y.SetBigInt(inputs[6*k+1])
+				Q[k].X.A0.SetBigInt(inputs[6*k+2])
+				Q[k].X.A1.SetBigInt(inputs[6*k+3])
+				Q[k].Y.A0.SetBigInt(inputs[6*k+4])
+				Q[k].Y.A1.SetBigInt(inputs[6*k+5])
+			}
+			f, err := bn254.MillerLoop(P, Q)
+			if err != nil {
+				return err
+			}
+
+			var u, c bn254.E12
+			u.Exp(f, scalingFactorExponent)
+			c.Mul(&f, &u)
+			c.Exp(c, residueWitnessExponent)
+
+			c.C0.B0.A0.BigInt(outputs[0])
+			c.C0.B0.A1.BigInt(outputs[1])
+			c.C0.B1.A0.BigInt(outputs[2])
+			c.C0.B1.A1.BigInt(outputs[3])
+			c.C0.B2.A0.BigInt(outputs[4])
+			c.C0.B2.A1.BigInt(outputs[5])
+			c.C1.B0.A0.BigInt(outputs[6])
+			c.C1.B0.A1.BigInt(outputs[7])
+			c.C1.B1.A0.BigInt(outputs[8])
+			c.C1.B1.A1.BigInt(outputs[9])
+			c.C1.B2.A0.BigInt(outputs[10])
+			c.C1.B2.A1.BigInt(outputs[11])
+
+			return nil
+		})
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/internal/residue"
 )
 
 type Pairing struct {
@@ -227,6 +229,55 @@ func (pr Pairing) PairingCheck(P []*G1Affine, Q []*G2Affine) error {
 	return nil
 }
 
+// PairingCheckWithTarget calculates the reduced pairing for a set of points
+// and asserts if the result is the constant T
+// ∏ᵢ e(Pᵢ, Qᵢ) =? T
+//
+// Instead of comparing to T after the final exponentiation, the Miller loop
+// output is multiplied by the inverse of a precomputed representative f of T,
+// i.e. such that the final exponentiation of f is T, so that a single final
+// exponentiation is needed.
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairingCheckWithTarget(P []*G1Affine, Q []*G2Affine, T bn254.GT) error {
+	res, err := pr.MillerLoop(P, Q)
+	if err != nil {
+		return fmt.Errorf("miller loop: %w", err)
+	}
+
+	// f⁻¹ = T^(-1/d' mod r)
+	var fInv bn254.GT
+	fInv.Exp(T, targetExponent)
+	t := NewGTEl(fInv)
+
+	res = pr.Mul(res, &t)
+	res = pr.finalExponentiation(res, false)
+	pr.AssertIsEqual(res, pr.One())
+
+	return nil
+}
+
+// targetExponent = -1/d' mod r, where d' = s ⋅ (p¹²-1)/r is the exponent of
+// the final exponentiation and s = 2x₀(6x₀²+3x₀+1) the cofactor. For T ∈ GT,
+// (T^targetExponent)^d' = T⁻¹.
+var targetExponent = func() *big.Int {
+	// s = 2x₀(6x₀²+3x₀+1)
+	x0 := big.NewInt(4965661367192848881)
+	s := new(big.Int).Mul(x0, x0)
+	s.Mul(s, big.NewInt(6)).
+		Add(s, new(big.Int).Mul(x0, big.NewInt(3))).
+		Add(s, big.NewInt(1)).
+		Mul(s, x0).
+		Lsh(s, 1)
+	p, r := ecc.BN254.BaseField(), ecc.BN254.ScalarField()
+	d := new(big.Int).Exp(p, big.NewInt(12), nil)
+	d.Sub(d, big.NewInt(1)).
+		Div(d, r).
+		Mul(d, s).
+		ModInverse(d, r)
+	return d.Sub(r, d)
+}()
+
 func (pr Pairing) AssertIsEqual(x, y *GTEl) {
 	pr.Ext12.AssertIsEqual(x, y)
 }
@@ -607,9 +658,10 @@ func (pr Pairing) lineCompute(p1, p2 *G2Affine) *lineEvaluation {
 
 // ----
 // Fixed argument pairing
-// TODO: DoublePairing where one of the point is fixed (special case of multi-pair)
 
-func (pr Pairing) MillerLoopFixedQ(P *G1Affine, Q *G2Affine) (*GTEl, error) {
+// MillerLoopFixedQ computes the Miller loop for a fixed G2 argument Q given by
+// its precomputed lines (see [PrecomputeLines]).
+func (pr Pairing) MillerLoopFixedQ(P *G1Affine, lines *FixedLines) (*GTEl, error) {
 
 	yInv := pr.curveF.Inverse(&P.Y)
 	xOverY := pr.curveF.MulMod(&P.X, yInv)
@@ -619,8 +671,8 @@ func (pr Pairing) MillerLoopFixedQ(P *G1Affine, Q *G2Affine) (*GTEl, error) {
 
 		// ℓ × res
 		res = pr.MulBy034(res,
-			pr.MulByElement(&PrecomputedLines[0][i], xOverY),
-			pr.MulByElement(&PrecomputedLines[1][i], yInv),
+			pr.MulByElement(&lines[0][i], xOverY),
+			pr.MulByElement(&lines[1][i], yInv),
 		)
 
 		if loopCounter[i] == 1 {
@@ -628,40 +680,545 @@ func (pr Pairing) MillerLoopFixedQ(P *G1Affine, Q *G2Affine) (*GTEl, error) {
 
 			// ℓ × res
 			res = pr.MulBy034(res,
-				pr.MulByElement(&PrecomputedLines[2][i], xOverY),
-				pr.MulByElement(&PrecomputedLines[3][i], yInv),
+				pr.MulByElement(&lines[2][i], xOverY),
+				pr.MulByElement(&lines[3][i], yInv),
 			)
 
 		} else if loopCounter[i] == -1 {
 
 			// ℓ × res
 			res = pr.MulBy034(res,
-				pr.MulByElement(&PrecomputedLines[2][i], xOverY),
-				pr.MulByElement(&PrecomputedLines[3][i], yInv),
+				pr.MulByElement(&lines[2][i], xOverY),
+				pr.MulByElement(&lines[3][i], yInv),
 			)
 		}
 	}
 
 	// line evaluation at P
 	res = pr.MulBy034(res,
-		pr.MulByElement(&PrecomputedLines[0][65], xOverY),
-		pr.MulByElement(&PrecomputedLines[1][65], yInv),
+		pr.MulByElement(&lines[0][65], xOverY),
+		pr.MulByElement(&lines[1][65], yInv),
 	)
 
 	// line evaluation at P
 	res = pr.MulBy034(res,
-		pr.MulByElement(&PrecomputedLines[0][66], xOverY),
-		pr.MulByElement(&PrecomputedLines[1][66], yInv),
+		pr.MulByElement(&lines[0][66], xOverY),
+		pr.MulByElement(&lines[1][66], yInv),
+	)
+
+	return res, nil
+}
+
+// DoubleMillerLoopFixedQ computes the double Miller loop
+// f_{6x₀+2,Q}(P)⋅f_{6x₀+2,R}(T) (including the final lines) for a variable Q
+// and a fixed R given by its precomputed lines (see [PrecomputeLines]).
+func (pr Pairing) DoubleMillerLoopFixedQ(P, T *G1Affine, Q *G2Affine, lines *FixedLines) (*GTEl, error) {
+	res := pr.Ext12.One()
+	var prodLines [5]E2
+
+	var l1, l2 *lineEvaluation
+	var Qacc *G2Affine
+	Qacc = Q
+	QNeg := &G2Affine{X: Q.X, Y: *pr.Ext2.Neg(&Q.Y)}
+	var yInv, xOverY, y2Inv, x2OverY2 *emulated.Element[emulated.BN254Fp]
+	yInv = pr.curveF.Inverse(&P.Y)
+	xOverY = pr.curveF.MulMod(&P.X, yInv)
+	y2Inv = pr.curveF.Inverse(&T.Y)
+	x2OverY2 = pr.curveF.MulMod(&T.X, y2Inv)
+
+	// i = 64, separately to avoid an E12 Square
+	// (Square(res) = 1² = 1)
+
+	// Qacc ← 2Qacc and l1 the tangent ℓ passing 2Qacc
+	Qacc, l1 = pr.doubleStep(Qacc)
+	// line evaluation at P
+	// and assign line to res
+	res.C1.B0 = *pr.MulByElement(&l1.R0, xOverY)
+	res.C1.B1 = *pr.MulByElement(&l1.R1, yInv)
+	// fixed line evaluation at T
+	res = pr.MulBy034(res,
+		pr.MulByElement(&lines[0][64], x2OverY2),
+		pr.MulByElement(&lines[1][64], y2Inv),
+	)
+
+	// i = 63, separately to avoid a doubleStep
+	// (see MillerLoop)
+	res = pr.Square(res)
+	// l2 the line passing Qacc and -Q
+	l2 = pr.lineCompute(Qacc, QNeg)
+	l2.R0 = *pr.MulByElement(&l2.R0, xOverY)
+	l2.R1 = *pr.MulByElement(&l2.R1, yInv)
+	// Qacc ← Qacc+Q and l1 the line ℓ passing Qacc and Q
+	Qacc, l1 = pr.addStep(Qacc, Q)
+	l1.R0 = *pr.MulByElement(&l1.R0, xOverY)
+	l1.R1 = *pr.MulByElement(&l1.R1, yInv)
+	// (ℓ × ℓ) × res
+	prodLines = *pr.Mul034By034(&l1.R0, &l1.R1, &l2.R0, &l2.R1)
+	res = pr.MulBy01234(res, &prodLines)
+	// fixed lines evaluation at T
+	res = pr.MulBy034(res,
+		pr.MulByElement(&lines[0][63], x2OverY2),
+		pr.MulByElement(&lines[1][63], y2Inv),
+	)
+	res = pr.MulBy034(res,
+		pr.MulByElement(&lines[2][63], x2OverY2),
+		pr.MulByElement(&lines[3][63], y2Inv),
+	)
+
+	for i := 62; i >= 0; i-- {
+		// mutualize the square among the two Miller loops
+		res = pr.Square(res)
+
+		// fixed line evaluation at T
+		res = pr.MulBy034(res,
+			pr.MulByElement(&lines[0][i], x2OverY2),
+			pr.MulByElement(&lines[1][i], y2Inv),
+		)
+
+		switch loopCounter[i] {
+		case 0:
+			// Qacc ← 2Qacc and l1 the tangent ℓ passing 2Qacc
+			Qacc, l1 = pr.doubleStep(Qacc)
+			// line evaluation at P
+			l1.R0 = *pr.MulByElement(&l1.R0, xOverY)
+			l1.R1 = *pr.MulByElement(&l1.R1, yInv)
+			// ℓ × res
+			res = pr.MulBy034(res, &l1.R0, &l1.R1)
+			continue
+		case 1:
+			// Qacc ← 2Qacc+Q,
+			// l1 the line ℓ passing Qacc and Q
+			// l2 the line ℓ passing (Qacc+Q) and Qacc
+			Qacc, l1, l2 = pr.doubleAndAddStep(Qacc, Q)
+		case -1:
+			// Qacc ← 2Qacc-Q,
+			// l1 the line ℓ passing Qacc and -Q
+			// l2 the line ℓ passing (Qacc-Q) and Qacc
+			Qacc, l1, l2 = pr.doubleAndAddStep(Qacc, QNeg)
+		default:
+			return nil, errors.New("invalid loopCounter")
+		}
+
+		// fixed line evaluation at T
+		res = pr.MulBy034(res,
+			pr.MulByElement(&lines[2][i], x2OverY2),
+			pr.MulByElement(&lines[3][i], y2Inv),
+		)
+		// line evaluation at P
+		l1.R0 = *pr.MulByElement(&l1.R0, xOverY)
+		l1.R1 = *pr.MulByElement(&l1.R1, yInv)
+		l2.R0 = *pr.MulByElement(&l2.R0, xOverY)
+		l2.R1 = *pr.MulByElement(&l2.R1, yInv)
+		// (ℓ × ℓ) × res
+		prodLines = *pr.Mul034By034(&l1.R0, &l1.R1, &l2.R0, &l2.R1)
+		res = pr.MulBy01234(res, &prodLines)
+	}
+
+	// Compute ℓ_{[6x₀+2]Q,π(Q)}(P) · ℓ_{[6x₀+2]Q+π(Q),-π²(Q)}(P)
+	Q1, Q2 := new(G2Affine), new(G2Affine)
+	// Q1 = π(Q)
+	Q1.X = *pr.Ext2.Conjugate(&Q.X)
+	Q1.X = *pr.Ext2.MulByNonResidue1Power2(&Q1.X)
+	Q1.Y = *pr.Ext2.Conjugate(&Q.Y)
+	Q1.Y = *pr.Ext2.MulByNonResidue1Power3(&Q1.Y)
+	// Q2 = -π²(Q)
+	Q2.X = *pr.Ext2.MulByNonResidue2Power2(&Q.X)
+	Q2.Y = *pr.Ext2.MulByNonResidue2Power3(&Q.Y)
+	Q2.Y = *pr.Ext2.Neg(&Q2.Y)
+
+	// Qacc ← Qacc+π(Q) and l1 the line passing Qacc and π(Q)
+	Qacc, l1 = pr.addStep(Qacc, Q1)
+	l1.R0 = *pr.MulByElement(&l1.R0, xOverY)
+	l1.R1 = *pr.MulByElement(&l1.R1, yInv)
+	// l2 the line passing Qacc and -π²(Q)
+	l2 = pr.lineCompute(Qacc, Q2)
+	l2.R0 = *pr.MulByElement(&l2.R0, xOverY)
+	l2.R1 = *pr.MulByElement(&l2.R1, yInv)
+	// (ℓ × ℓ) × res
+	prodLines = *pr.Mul034By034(&l1.R0, &l1.R1, &l2.R0, &l2.R1)
+	res = pr.MulBy01234(res, &prodLines)
+
+	// fixed lines evaluation at T
+	res = pr.MulBy034(res,
+		pr.MulByElement(&lines[0][65], x2OverY2),
+		pr.MulByElement(&lines[1][65], y2Inv),
 	)
+	res = pr.MulBy034(res,
+		pr.MulByElement(&lines[0][66], x2OverY2),
+		pr.MulByElement(&lines[1][66], y2Inv),
+	)
+
+	return res, nil
+}
+
+// MultiMillerLoopMixed computes the multi-Miller loop
+// ∏ᵢ f_{6x₀+2,Rᵢ}(Tᵢ) ⋅ ∏ⱼ f_{6x₀+2,Qⱼ}(Pⱼ) (including the final lines) for
+// fixed G2 arguments Rᵢ given by their precomputed lines (see
+// [PrecomputeLines]) and variable G2 arguments Qⱼ. The squarings of the
+// accumulator are shared among all the Miller loops.
+func (pr Pairing) MultiMillerLoopMixed(fixedP []*G1Affine, fixedLines []FixedLines, varP []*G1Affine, varQ []*G2Affine) (*GTEl, error) {
+	// check input size match
+	nFixed, nVar := len(fixedP), len(varP)
+	if nFixed != len(fixedLines) || nVar != len(varQ) || nFixed+nVar == 0 {
+		return nil, errors.New("invalid inputs sizes")
+	}
+
+	res := pr.Ext12.One()
+	var prodLines [5]E2
+	var l1, l2 *lineEvaluation
+
+	fixedYInv := make([]*emulated.Element[emulated.BN254Fp], nFixed)
+	fixedXOverY := make([]*emulated.Element[emulated.BN254Fp], nFixed)
+	for k := 0; k < nFixed; k++ {
+		fixedYInv[k] = pr.curveF.Inverse(&fixedP[k].Y)
+		fixedXOverY[k] = pr.curveF.MulMod(&fixedP[k].X, fixedYInv[k])
+	}
+	Qacc := make([]*G2Affine, nVar)
+	QNeg := make([]*G2Affine, nVar)
+	yInv := make([]*emulated.Element[emulated.BN254Fp], nVar)
+	xOverY := make([]*emulated.Element[emulated.BN254Fp], nVar)
+	for k := 0; k < nVar; k++ {
+		Qacc[k] = varQ[k]
+		QNeg[k] = &G2Affine{X: varQ[k].X, Y: *pr.Ext2.Neg(&varQ[k].Y)}
+		yInv[k] = pr.curveF.Inverse(&varP[k].Y)
+		xOverY[k] = pr.curveF.MulMod(&varP[k].X, yInv[k])
+	}
+
+	for i := 64; i >= 0; i-- {
+		// mutualize the square among n Miller loops
+		// (∏ᵢfᵢ)²
+		// (for i = 64, Square(res) = 1² = 1)
+		if i < 64 {
+			res = pr.Square(res)
+		}
+
+		// fixed lines evaluation at Tᵢ
+		for k := 0; k < nFixed; k++ {
+			lines := &fixedLines[k]
+			if loopCounter[i] == 0 {
+				// ℓ × res
+				res = pr.MulBy034(res,
+					pr.MulByElement(&lines[0][i], fixedXOverY[k]),
+					pr.MulByElement(&lines[1][i], fixedYInv[k]),
+				)
+				continue
+			}
+			// (ℓ × ℓ) × res
+			prodLines = *pr.Mul034By034(
+				pr.MulByElement(&lines[0][i], fixedXOverY[k]),
+				pr.MulByElement(&lines[1][i], fixedYInv[k]),
+				pr.MulByElement(&lines[2][i], fixedXOverY[k]),
+				pr.MulByElement(&lines[3][i], fixedYInv[k]),
+			)
+			res = pr.MulBy01234(res, &prodLines)
+		}
+
+		// variable lines evaluation at Pⱼ
+		for k := 0; k < nVar; k++ {
+			switch loopCounter[i] {
+			case 0:
+				// Qacc[k] ← 2Qacc[k] and l1 the tangent ℓ passing 2Qacc[k]
+				Qacc[k], l1 = pr.doubleStep(Qacc[k])
+				// line evaluation at P[k]
+				l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+				l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+				// ℓ × res
+				res = pr.MulBy034(res, &l1.R0, &l1.R1)
+				continue
+			case 1:
+				// Qacc[k] ← 2Qacc[k]+Q[k],
+				// l1 the line ℓ passing Qacc[k] and Q[k]
+				// l2 the line ℓ passing (Qacc[k]+Q[k]) and Qacc[k]
+				Qacc[k], l1, l2 = pr.doubleAndAddStep(Qacc[k], varQ[k])
+			case -1:
+				// Qacc[k] ← 2Qacc[k]-Q[k],
+				// l1 the line ℓ passing Qacc[k] and -Q[k]
+				// l2 the line ℓ passing (Qacc[k]-Q[k]) and Qacc[k]
+				Qacc[k], l1, l2 = pr.doubleAndAddStep(Qacc[k], QNeg[k])
+			default:
+				return nil, errors.New("invalid loopCounter")
+			}
+			// line evaluation at P[k]
+			l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+			l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+			l2.R0 = *pr.MulByElement(&l2.R0, xOverY[k])
+			l2.R1 = *pr.MulByElement(&l2.R1, yInv[k])
+			// (ℓ × ℓ) × res
+			prodLines = *pr.Mul034By034(&l1.R0, &l1.R1, &l2.R0, &l2.R1)
+			res = pr.MulBy01234(res, &prodLines)
+		}
+	}
+
+	// fixed final lines evaluation at Tᵢ
+	for k := 0; k < nFixed; k++ {
+		lines := &fixedLines[k]
+		// (ℓ × ℓ) × res
+		prodLines = *pr.Mul034By034(
+			pr.MulByElement(&lines[0][65], fixedXOverY[k]),
+			pr.MulByElement(&lines[1][65], fixedYInv[k]),
+			pr.MulByElement(&lines[0][66], fixedXOverY[k]),
+			pr.MulByElement(&lines[1][66], fixedYInv[k]),
+		)
+		res = pr.MulBy01234(res, &prodLines)
+	}
+
+	// Compute ∏ⱼ { ℓⱼ_{[6x₀+2]Q,π(Q)}(P) · ℓⱼ_{[6x₀+2]Q+π(Q),-π²(Q)}(P) }
+	Q1, Q2 := new(G2Affine), new(G2Affine)
+	for k := 0; k < nVar; k++ {
+		// Q1 = π(Q)
+		Q1.X = *pr.Ext2.Conjugate(&varQ[k].X)
+		Q1.X = *pr.Ext2.MulByNonResidue1Power2(&Q1.X)
+		Q1.Y = *pr.Ext2.Conjugate(&varQ[k].Y)
+		Q1.Y = *pr.Ext2.MulByNonResidue1Power3(&Q1.Y)
+
+		// Q2 = -π²(Q)
+		Q2.X = *pr.Ext2.MulByNonResidue2Power2(&varQ[k].X)
+		Q2.Y = *pr.Ext2.MulByNonResidue2Power3(&varQ[k].Y)
+		Q2.Y = *pr.Ext2.Neg(&Q2.Y)
+
+		// Qacc[k] ← Qacc[k]+π(Q) and
+		// l1 the line passing Qacc[k] and π(Q)
+		Qacc[k], l1 = pr.addStep(Qacc[k], Q1)
+		l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+		l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+
+		// l2 the line passing Qacc[k] and -π²(Q)
+		l2 = pr.lineCompute(Qacc[k], Q2)
+		l2.R0 = *pr.MulByElement(&l2.R0, xOverY[k])
+		l2.R1 = *pr.MulByElement(&l2.R1, yInv[k])
+
+		// (ℓ × ℓ) × res
+		prodLines = *pr.Mul034By034(&l1.R0, &l1.R1, &l2.R0, &l2.R1)
+		res = pr.MulBy01234(res, &prodLines)
+	}
 
 	return res, nil
 }
 
-func (pr Pairing) PairFixedQ(P *G1Affine, Q *G2Affine) (*GTEl, error) {
-	res, err := pr.MillerLoopFixedQ(P, Q)
+// PairFixedQ computes the reduced pairing e(P, Q) for a fixed Q given by its
+// precomputed lines (see [PrecomputeLines]).
+func (pr Pairing) PairFixedQ(P *G1Affine, lines *FixedLines) (*GTEl, error) {
+	res, err := pr.MillerLoopFixedQ(P, lines)
 	if err != nil {
 		return nil, fmt.Errorf("miller loop: %w", err)
 	}
 	res = pr.finalExponentiation(res, true)
 	return res, nil
 }
+
+// DoublePairFixedQ computes the reduced pairing product e(P, Q)⋅e(T, R) for a
+// variable Q and a fixed R given by its precomputed lines (see
+// [PrecomputeLines]).
+func (pr Pairing) DoublePairFixedQ(P, T *G1Affine, Q *G2Affine, lines *FixedLines) (*GTEl, error) {
+	res, err := pr.DoubleMillerLoopFixedQ(P, T, Q, lines)
+	if err != nil {
+		return nil, fmt.Errorf("double miller loop: %w", err)
+	}
+	res = pr.finalExponentiation(res, false)
+	return res, nil
+}
+
+// MultiPairMixed computes the reduced pairing product
+// ∏ᵢ e(Tᵢ, Rᵢ) ⋅ ∏ⱼ e(Pⱼ, Qⱼ) for fixed Rᵢ given by their precomputed lines
+// (see [PrecomputeLines]) and variable Qⱼ (see [Pairing.MultiMillerLoopMixed]).
+func (pr Pairing) MultiPairMixed(fixedP []*G1Affine, fixedLines []FixedLines, varP []*G1Affine, varQ []*G2Affine) (*GTEl, error) {
+	res, err := pr.MultiMillerLoopMixed(fixedP, fixedLines, varP, varQ)
+	if err != nil {
+		return nil, fmt.Errorf("multi miller loop: %w", err)
+	}
+	res = pr.finalExponentiation(res, len(fixedP)+len(varP) == 1)
+	return res, nil
+}
+
+// ----
+// Final-exponentiation-free pairing check
+
+// PairingCheckResidue asserts that the reduced pairing of a set of points is
+// One
+// ∏ᵢ e(Pᵢ, Qᵢ) =? 1
+//
+// without computing the final exponentiation in-circuit. It follows Section 4
+// of "On Proving Pairings" by A. Novakovic and L. Eagen
+// (https://eprint.iacr.org/2024/640.pdf).
+//
+// The Miller loop output f satisfies fᵈ = 1, with d = (p¹²-1)/r, iff there
+// exist c ∈ 𝔽p¹² and u ∈ 𝔽p⁶ such that
+//
+//	f ⋅ u = c^λ, with λ = 6x₀+2 + p - p² + p³
+//
+// a multiple of r. The residue witness c is given by a hint and c^(6x₀+2) is
+// computed in the Miller loop, sharing its squarings. Instead of providing the
+// scaling factor u as a witness, we check that c^λ / f is fixed by the
+// conjugation (i.e. lies in 𝔽p⁶). Any u ∈ 𝔽p⁶ is sound as (p⁶-1) divides d.
+//
+// This function doesn't check that the inputs are in the correct subgroups.
+func (pr Pairing) PairingCheckResidue(P []*G1Affine, Q []*G2Affine) error {
+	// check input size match
+	n := len(P)
+	if n == 0 || n != len(Q) {
+		return errors.New("invalid inputs sizes")
+	}
+
+	inputs := make([]*emulated.Element[emulated.BN254Fp], 0, 6*n)
+	for k := 0; k < n; k++ {
+		inputs = append(inputs, &P[k].X, &P[k].Y, &Q[k].X.A0, &Q[k].X.A1, &Q[k].Y.A0, &Q[k].Y.A1)
+	}
+	hint, err := pr.curveF.NewHint(residueWitnessHint, 12, inputs...)
+	if err != nil {
+		return fmt.Errorf("new hint: %w", err)
+	}
+	c := GTEl{
+		C0: E6{
+			B0: E2{A0: *hint[0], A1: *hint[1]},
+			B1: E2{A0: *hint[2], A1: *hint[3]},
+			B2: E2{A0: *hint[4], A1: *hint[5]},
+		},
+		C1: E6{
+			B0: E2{A0: *hint[6], A1: *hint[7]},
+			B1: E2{A0: *hint[8], A1: *hint[9]},
+			B2: E2{A0: *hint[10], A1: *hint[11]},
+		},
+	}
+	// c ≠ 0 as c ⋅ c⁻¹ = 1 is asserted
+	cInv := pr.Ext12.Inverse(&c)
+
+	// f ⋅ c^-(6x₀+2)
+	res, err := pr.millerLoopResidue(P, Q, &c, cInv)
+	if err != nil {
+		return fmt.Errorf("miller loop: %w", err)
+	}
+
+	// c^(p - p² + p³)
+	t := pr.Frobenius(&c)
+	t = pr.Mul(t, pr.FrobeniusCube(&c))
+	t = pr.Mul(t, pr.FrobeniusSquare(cInv))
+
+	// t / res ∈ 𝔽p⁶ ⇔ conj(t) ⋅ res = t ⋅ conj(res)
+	// (res ≠ 0 as the lines have a constant coefficient 1)
+	pr.AssertIsEqual(
+		pr.Mul(pr.Conjugate(t), res),
+		pr.Mul(t, pr.Conjugate(res)),
+	)
+
+	return nil
+}
+
+// millerLoopResidue computes the multi-Miller loop as in [MillerLoop] but
+// with the accumulator initialized to c⁻¹ and multiplied by c⁻¹ (resp. c) at
+// each non-zero digit 1 (resp. -1) of the loop counter. It returns
+// ∏ᵢ { fᵢ_{6x₀+2,Q}(P) · ℓᵢ_{[6x₀+2]Q,π(Q)}(P) · ℓᵢ_{[6x₀+2]Q+π(Q),-π²(Q)}(P) } ⋅ c^-(6x₀+2)
+func (pr Pairing) millerLoopResidue(P []*G1Affine, Q []*G2Affine, c, cInv *GTEl) (*GTEl, error) {
+	n := len(P)
+	var prodLines [5]E2
+	var l1, l2 *lineEvaluation
+	Qacc := make([]*G2Affine, n)
+	QNeg := make([]*G2Affine, n)
+	yInv := make([]*emulated.Element[emulated.BN254Fp], n)
+	xOverY := make([]*emulated.Element[emulated.BN254Fp], n)
+
+	for k := 0; k < n; k++ {
+		Qacc[k] = Q[k]
+		QNeg[k] = &G2Affine{X: Q[k].X, Y: *pr.Ext2.Neg(&Q[k].Y)}
+		yInv[k] = pr.curveF.Inverse(&P[k].Y)
+		xOverY[k] = pr.curveF.MulMod(&P[k].X, yInv[k])
+	}
+
+	// i = 65, the leading digit 1
+	res := cInv
+
+	for i := 64; i >= 0; i-- {
+		// mutualize the square among n Miller loops and c^-(6x₀+2)
+		res = pr.Square(res)
+
+		switch loopCounter[i] {
+		case 0:
+			for k := 0; k < n; k++ {
+				// Qacc[k] ← 2Qacc[k] and l1 the tangent ℓ passing 2Qacc[k]
+				Qacc[k], l1 = pr.doubleStep(Qacc[k])
+				// line evaluation at P[k]
+				l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+				l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+				// ℓ × res
+				res = pr.MulBy034(res, &l1.R0, &l1.R1)
+			}
+			continue
+		case 1:
+			res = pr.Mul(res, cInv)
+		case -1:
+			res = pr.Mul(res, c)
+		default:
+			return nil, errors.New("invalid loopCounter")
+		}
+
+		for k := 0; k < n; k++ {
+			// Qacc[k] ← 2Qacc[k]±Q[k],
+			// l1 the line ℓ passing Qacc[k] and ±Q[k]
+			// l2 the line ℓ passing (Qacc[k]±Q[k]) and Qacc[k]
+			if loopCounter[i] == 1 {
+				Qacc[k], l1, l2 = pr.doubleAndAddStep(Qacc[k], Q[k])
+			} else {
+				Qacc[k], l1, l2 = pr.doubleAndAddStep(Qacc[k], QNeg[k])
+			}
+			// line evaluation at P[k]
+			l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+			l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+			l2.R0 = *pr.MulByElement(&l2.R0, xOverY[k])
+			l2.R1 = *pr.MulByElement(&l2.R1, yInv[k])
+			// (ℓ × ℓ) × res
+			prodLines = *pr.Mul034By034(&l1.R0, &l1.R1, &l2.R0, &l2.R1)
+			res = pr.MulBy01234(res, &prodLines)
+		}
+	}
+
+	// Compute  ∏ᵢ { ℓᵢ_{[6x₀+2]Q,π(Q)}(P) · ℓᵢ_{[6x₀+2]Q+π(Q),-π²(Q)}(P) }
+	Q1, Q2 := new(G2Affine), new(G2Affine)
+	for k := 0; k < n; k++ {
+		// Q1 = π(Q)
+		Q1.X = *pr.Ext2.Conjugate(&Q[k].X)
+		Q1.X = *pr.Ext2.MulByNonResidue1Power2(&Q1.X)
+		Q1.Y = *pr.Ext2.Conjugate(&Q[k].Y)
+		Q1.Y = *pr.Ext2.MulByNonResidue1Power3(&Q1.Y)
+
+		// Q2 = -π²(Q)
+		Q2.X = *pr.Ext2.MulByNonResidue2Power2(&Q[k].X)
+		Q2.Y = *pr.Ext2.MulByNonResidue2Power3(&Q[k].Y)
+		Q2.Y = *pr.Ext2.Neg(&Q2.Y)
+
+		// Qacc[k] ← Qacc[k]+π(Q) and
+		// l1 the line passing Qacc[k] and π(Q)
+		Qacc[k], l1 = pr.addStep(Qacc[k], Q1)
+		l1.R0 = *pr.MulByElement(&l1.R0, xOverY[k])
+		l1.R1 = *pr.MulByElement(&l1.R1, yInv[k])
+
+		// l2 the line passing Qacc[k] and -π²(Q)
+		l2 = pr.lineCompute(Qacc[k], Q2)
+		l2.R0 = *pr.MulByElement(&l2.R0, xOverY[k])
+		l2.R1 = *pr.MulByElement(&l2.R1, yInv[k])
+
+		// (ℓ × ℓ) × res
+		prodLines = *pr.Mul034By034(&l1.R0, &l1.R1, &l2.R0, &l2.R1)
+		res = pr.MulBy01234(res, &prodLines)
+	}
+
+	return res, nil
+}
+
+// residueWitnessExponent and scalingFactorExponent are such that for any
+// f ∈ 𝔽p¹² with fᵈ = 1, d = (p¹²-1)/r, the element u = f^scalingFactorExponent
+// is in 𝔽p⁶ and c = (f ⋅ u)^residueWitnessExponent satisfies
+// c^λ = f ⋅ u, where λ = 6x₀+2 + p - p² + p³ (see [residue.Exponents]).
+// Here u is in the subgroup of order B = 27, which is in 𝔽p⁶ as B | p⁶-1.
+var residueWitnessExponent, scalingFactorExponent = func() (*big.Int, *big.Int) {
+	x0 := big.NewInt(4965661367192848881)
+	p, r := ecc.BN254.BaseField(), ecc.BN254.ScalarField()
+	// λ = 6x₀+2 + p - p² + p³
+	lambda := new(big.Int).Mul(x0, big.NewInt(6))
+	lambda.Add(lambda, big.NewInt(2))
+	pk := new(big.Int).Set(p)
+	lambda.Add(lambda, pk)
+	pk.Mul(pk, p)
+	lambda.Sub(lambda, pk)
+	pk.Mul(pk, p)
+	lambda.Add(lambda, pk)
+	return residue.Exponents(lambda, p, r)
+}()
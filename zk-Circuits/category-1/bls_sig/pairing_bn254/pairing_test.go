@@ -163,6 +163,86 @@ func TestPairingCheckTestSolve(t *testing.T) {
 	assert.NoError(err)
 }
 
+type PairingCheckWithTargetCircuit struct {
+	P1, P2 G1Affine
+	Q1, Q2 G2Affine
+	target bn254.GT
+}
+
+func (c *PairingCheckWithTargetCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	err = pairing.PairingCheckWithTarget([]*G1Affine{&c.P1, &c.P2}, []*G2Affine{&c.Q1, &c.Q2}, c.target)
+	if err != nil {
+		return fmt.Errorf("pair: %w", err)
+	}
+	return nil
+}
+
+func TestPairingCheckWithTargetTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p1, q1 := randomG1G2Affines(assert)
+	p2, q2 := randomG1G2Affines(assert)
+	target, err := bn254.Pair([]bn254.G1Affine{p1, p2}, []bn254.G2Affine{q1, q2})
+	assert.NoError(err)
+	witness := PairingCheckWithTargetCircuit{
+		P1: NewG1Affine(p1),
+		P2: NewG1Affine(p2),
+		Q1: NewG2Affine(q1),
+		Q2: NewG2Affine(q2),
+	}
+	err = test.IsSolved(&PairingCheckWithTargetCircuit{target: target}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// wrong target
+	target.Square(&target)
+	err = test.IsSolved(&PairingCheckWithTargetCircuit{target: target}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type PairingCheckResidueCircuit struct {
+	In1G1 G1Affine
+	In2G1 G1Affine
+	In1G2 G2Affine
+	In2G2 G2Affine
+}
+
+func (c *PairingCheckResidueCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	err = pairing.PairingCheckResidue([]*G1Affine{&c.In1G1, &c.In2G1}, []*G2Affine{&c.In1G2, &c.In2G2})
+	if err != nil {
+		return fmt.Errorf("pair: %w", err)
+	}
+	return nil
+}
+
+func TestPairingCheckResidueTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p1, q1 := randomG1G2Affines(assert)
+	var p2 bn254.G1Affine
+	p2.Neg(&p1)
+	witness := PairingCheckResidueCircuit{
+		In1G1: NewG1Affine(p1),
+		In1G2: NewG2Affine(q1),
+		In2G1: NewG1Affine(p2),
+		In2G2: NewG2Affine(q1),
+	}
+	err := test.IsSolved(&PairingCheckResidueCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// e(P1, Q1) ⋅ e(P1, Q2) ≠ 1
+	_, q2 := randomG1G2Affines(assert)
+	witness.In2G1 = NewG1Affine(p1)
+	witness.In2G2 = NewG2Affine(q2)
+	err = test.IsSolved(&PairingCheckResidueCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
 type FinalExponentiationSafeCircuit struct {
 	P1, P2 G1Affine
 	Q1, Q2 G2Affine
@@ -204,7 +284,6 @@ func TestFinalExponentiationSafeCircuit(t *testing.T) {
 
 type PairFixedCircuit struct {
 	InG1 G1Affine
-	InG2 G2Affine
 	Res  GTEl
 }
 
@@ -213,7 +292,7 @@ func (c *PairFixedCircuit) Define(api frontend.API) error {
 	if err != nil {
 		return fmt.Errorf("new pairing: %w", err)
 	}
-	res, err := pairing.PairFixedQ(&c.InG1, &c.InG2)
+	res, err := pairing.PairFixedQ(&c.InG1, &PrecomputedLines)
 	if err != nil {
 		return fmt.Errorf("pair: %w", err)
 	}
@@ -229,13 +308,121 @@ func TestPairFixedTestSolve(t *testing.T) {
 	assert.NoError(err)
 	witness := PairFixedCircuit{
 		InG1: NewG1Affine(p),
-		InG2: NewG2Affine(G2AffGen),
 		Res:  NewGTEl(res),
 	}
 	err = test.IsSolved(&PairFixedCircuit{}, &witness, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
 
+type DoublePairFixedCircuit struct {
+	In1G1 G1Affine
+	In2G1 G1Affine
+	In1G2 G2Affine
+	Res   GTEl
+}
+
+func (c *DoublePairFixedCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	res, err := pairing.DoublePairFixedQ(&c.In1G1, &c.In2G1, &c.In1G2, &PrecomputedLines)
+	if err != nil {
+		return fmt.Errorf("pair: %w", err)
+	}
+	pairing.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestDoublePairFixedTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p, q := randomG1G2Affines(assert)
+	_, _, _, G2AffGen := bn254.Generators()
+	res, err := bn254.Pair([]bn254.G1Affine{p, p}, []bn254.G2Affine{q, G2AffGen})
+	assert.NoError(err)
+	witness := DoublePairFixedCircuit{
+		In1G1: NewG1Affine(p),
+		In2G1: NewG1Affine(p),
+		In1G2: NewG2Affine(q),
+		Res:   NewGTEl(res),
+	}
+	err = test.IsSolved(&DoublePairFixedCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type MultiPairMixedCircuit struct {
+	T1, T2 G1Affine
+	P      G1Affine
+	Q      G2Affine
+	Res    GTEl
+	lines  []FixedLines `gnark:"-"`
+}
+
+func (c *MultiPairMixedCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	res, err := pairing.MultiPairMixed([]*G1Affine{&c.T1, &c.T2}, c.lines, []*G1Affine{&c.P}, []*G2Affine{&c.Q})
+	if err != nil {
+		return fmt.Errorf("pair: %w", err)
+	}
+	pairing.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestMultiPairMixedTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	t1, r1 := randomG1G2Affines(assert)
+	t2, r2 := randomG1G2Affines(assert)
+	p, q := randomG1G2Affines(assert)
+	res, err := bn254.Pair([]bn254.G1Affine{t1, t2, p}, []bn254.G2Affine{r1, r2, q})
+	assert.NoError(err)
+	lines := []FixedLines{PrecomputeLines(r1), PrecomputeLines(r2)}
+	witness := MultiPairMixedCircuit{
+		T1:  NewG1Affine(t1),
+		T2:  NewG1Affine(t2),
+		P:   NewG1Affine(p),
+		Q:   NewG2Affine(q),
+		Res: NewGTEl(res),
+	}
+	err = test.IsSolved(&MultiPairMixedCircuit{lines: lines}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type PairFixedLinesCircuit struct {
+	InG1  G1Affine
+	Res   GTEl
+	lines *FixedLines `gnark:"-"`
+}
+
+func (c *PairFixedLinesCircuit) Define(api frontend.API) error {
+	pairing, err := NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	res, err := pairing.PairFixedQ(&c.InG1, c.lines)
+	if err != nil {
+		return fmt.Errorf("pair: %w", err)
+	}
+	pairing.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestPairFixedLinesTestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	p, q := randomG1G2Affines(assert)
+	res, err := bn254.Pair([]bn254.G1Affine{p}, []bn254.G2Affine{q})
+	assert.NoError(err)
+	lines := PrecomputeLines(q)
+	witness := PairFixedLinesCircuit{
+		InG1: NewG1Affine(p),
+		Res:  NewGTEl(res),
+	}
+	err = test.IsSolved(&PairFixedLinesCircuit{lines: &lines}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
 // bench
 func BenchmarkPairing(b *testing.B) {
 	var c PairCircuit
@@ -245,6 +432,15 @@ func BenchmarkPairing(b *testing.B) {
 	fmt.Println("⏱️ Single BN254 pairing in a BN254 R1CS circuit: ", p.NbConstraints())
 }
 
+// bench
+func BenchmarkPairingCheckResidue(b *testing.B) {
+	var c PairingCheckResidueCircuit
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️ BN254 pairing check (2 pairs, residue witness) in a BN254 R1CS circuit: ", p.NbConstraints())
+}
+
 // bench
 func BenchmarkPairingFixedQ(b *testing.B) {
 	var c PairFixedCircuit
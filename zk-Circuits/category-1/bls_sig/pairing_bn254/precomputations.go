@@ -1,16 +1,20 @@
+// Code generated by linegen. DO NOT EDIT.
+
 package pairing_bn254
 
 import "github.com/consensys/gnark/std/math/emulated"
 
-var PrecomputedLines [4][67]E2
+// PrecomputedLines are the lines of the Miller loop for the canonical
+// generator of G2.
+var PrecomputedLines FixedLines
 
 func init() {
-	// i =  64
+	// i = 64
 	PrecomputedLines[0][64].A0 = emulated.ValueOf[emulated.BN254Fp]("5835204804648978854777809389163082959673580093383091483568092875198341589362")
 	PrecomputedLines[0][64].A1 = emulated.ValueOf[emulated.BN254Fp]("13632706003546654277482391832141703292091762015816023705040318800028245927696")
 	PrecomputedLines[1][64].A0 = emulated.ValueOf[emulated.BN254Fp]("1680434087217908762188513888731180967069012235541138281753317594838287941133")
 	PrecomputedLines[1][64].A1 = emulated.ValueOf[emulated.BN254Fp]("19491433686921975987918669077017867748435767299607210121547313497083429353042")
-	// i =  63
+	// i = 63
 	PrecomputedLines[0][63].A0 = emulated.ValueOf[emulated.BN254Fp]("8834747017950039806917730978057895018652669773221183534396319488771182322273")
 	PrecomputedLines[0][63].A1 = emulated.ValueOf[emulated.BN254Fp]("20569453214085543698303175670835565927230899674712780610087152439201543453755")
 	PrecomputedLines[1][63].A0 = emulated.ValueOf[emulated.BN254Fp]("12474451462113811170279691739806155555255035988412241892389371812852943279791")
@@ -19,12 +23,12 @@ func init() {
 	PrecomputedLines[2][63].A1 = emulated.ValueOf[emulated.BN254Fp]("5684094267725805546491764759679865993449104797108069757958938517705465746853")
 	PrecomputedLines[3][63].A0 = emulated.ValueOf[emulated.BN254Fp]("10353962084714942711958392698892131194533550945227135631964812972535754938740")
 	PrecomputedLines[3][63].A1 = emulated.ValueOf[emulated.BN254Fp]("1928175489709988399997528177153275473647160619426141896807527496697530478537")
-	// i =  62
+	// i = 62
 	PrecomputedLines[0][62].A0 = emulated.ValueOf[emulated.BN254Fp]("8235221535982217724508088798625418488934211808403480937732051788034499272591")
 	PrecomputedLines[0][62].A1 = emulated.ValueOf[emulated.BN254Fp]("21624988872631589384985418538844721483027269339384612878942921292555079826013")
 	PrecomputedLines[1][62].A0 = emulated.ValueOf[emulated.BN254Fp]("20348378478608120338812639349974340732102235507506642310441900724921087826247")
 	PrecomputedLines[1][62].A1 = emulated.ValueOf[emulated.BN254Fp]("8566792459109340182179639521038613097990900893660026554466166360331455617193")
-	// i =  61
+	// i = 61
 	PrecomputedLines[0][61].A0 = emulated.ValueOf[emulated.BN254Fp]("6627719691581136027519842318508717081939451729821280503007308537592603220412")
 	PrecomputedLines[0][61].A1 = emulated.ValueOf[emulated.BN254Fp]("11524325490035505497336553724692563653964162500918217938886754632362466082811")
 	PrecomputedLines[1][61].A0 = emulated.ValueOf[emulated.BN254Fp]("19731985605225575090697166005028587616606038298772396496599168985556363200631")
@@ -33,22 +37,22 @@ func init() {
 	PrecomputedLines[2][61].A1 = emulated.ValueOf[emulated.BN254Fp]("14721209502407905805334137781178425261132829203821641437369233014505902590526")
 	PrecomputedLines[3][61].A0 = emulated.ValueOf[emulated.BN254Fp]("5027080457503687104862577903377368485365806076692125497436352950815222879179")
 	PrecomputedLines[3][61].A1 = emulated.ValueOf[emulated.BN254Fp]("19289658640175986155793149849769305065167539432896819573858459105348955554557")
-	// i =  60
+	// i = 60
 	PrecomputedLines[0][60].A0 = emulated.ValueOf[emulated.BN254Fp]("14632988473650232706638308445044627656823840762055592679861976480201049786937")
 	PrecomputedLines[0][60].A1 = emulated.ValueOf[emulated.BN254Fp]("18477931370497242185852032413411380760829755240315614825130799062365486125783")
 	PrecomputedLines[1][60].A0 = emulated.ValueOf[emulated.BN254Fp]("18049101236999327864886811068212417971066320579871553559963860415669018134496")
 	PrecomputedLines[1][60].A1 = emulated.ValueOf[emulated.BN254Fp]("3340541398203178600550723254278705350179917842084176758931787480715875388093")
-	// i =  59
+	// i = 59
 	PrecomputedLines[0][59].A0 = emulated.ValueOf[emulated.BN254Fp]("17045135689595429000496650894177684583849536764983720634964441404516121899392")
 	PrecomputedLines[0][59].A1 = emulated.ValueOf[emulated.BN254Fp]("20874559392346445015406487528270220685966388146273495510982431769773849348197")
 	PrecomputedLines[1][59].A0 = emulated.ValueOf[emulated.BN254Fp]("8223740335264218373639292193525349753225213618348104360137437003379775025437")
 	PrecomputedLines[1][59].A1 = emulated.ValueOf[emulated.BN254Fp]("2548870952786184681128163676627501278690702287544993422150040172278516478745")
-	// i =  58
+	// i = 58
 	PrecomputedLines[0][58].A0 = emulated.ValueOf[emulated.BN254Fp]("10954828682858274260092126382718192953262472685421314903371241946559589624873")
 	PrecomputedLines[0][58].A1 = emulated.ValueOf[emulated.BN254Fp]("8743995751245898721379411778296781208636410240371573947003929850344297435525")
 	PrecomputedLines[1][58].A0 = emulated.ValueOf[emulated.BN254Fp]("17024182879676720943763727838881486298580583134896607657788582772952988629132")
 	PrecomputedLines[1][58].A1 = emulated.ValueOf[emulated.BN254Fp]("233080409219735443943562019109568508823238327587973458579303286089303864680")
-	// i =  57
+	// i = 57
 	PrecomputedLines[0][57].A0 = emulated.ValueOf[emulated.BN254Fp]("12410140729570783839406161286916119301565534084495020370980905806792203460134")
 	PrecomputedLines[0][57].A1 = emulated.ValueOf[emulated.BN254Fp]("14094884930234597736770350376505495314765932446313713250354944203186636575455")
 	PrecomputedLines[1][57].A0 = emulated.ValueOf[emulated.BN254Fp]("532840589523723053112594079794333892977065522820117356517422849664750230778")
@@ -57,12 +61,12 @@ func init() {
 	PrecomputedLines[2][57].A1 = emulated.ValueOf[emulated.BN254Fp]("16754765645720625866506074952008486046920919307557056709799845067180351678981")
 	PrecomputedLines[3][57].A0 = emulated.ValueOf[emulated.BN254Fp]("18040737120690367958636522976877072351597960312418873862053894642308039267165")
 	PrecomputedLines[3][57].A1 = emulated.ValueOf[emulated.BN254Fp]("12847080167727762220604062477143165139413815667631144830150678996896243094397")
-	// i =  56
+	// i = 56
 	PrecomputedLines[0][56].A0 = emulated.ValueOf[emulated.BN254Fp]("19645311236785860275323414293804502000247542616913482529314177973392964070041")
 	PrecomputedLines[0][56].A1 = emulated.ValueOf[emulated.BN254Fp]("11127380619767050390611672259834755402156496596006857437940271701361623250389")
 	PrecomputedLines[1][56].A0 = emulated.ValueOf[emulated.BN254Fp]("12866789663733235640870663615630153955969739486663450312836464903244952796759")
 	PrecomputedLines[1][56].A1 = emulated.ValueOf[emulated.BN254Fp]("20334247261155778215897034951123988527365486037964674499287611566337802994246")
-	// i =  55
+	// i = 55
 	PrecomputedLines[0][55].A0 = emulated.ValueOf[emulated.BN254Fp]("4909602325743718030494127948247513676144699561762181851595755686145634562165")
 	PrecomputedLines[0][55].A1 = emulated.ValueOf[emulated.BN254Fp]("4980795661388523093721831770060089904711582345302707214936616089440639723701")
 	PrecomputedLines[1][55].A0 = emulated.ValueOf[emulated.BN254Fp]("15971329859948607389718743711907428875739552759192560895900443046558268384833")
@@ -71,22 +75,22 @@ func init() {
 	PrecomputedLines[2][55].A1 = emulated.ValueOf[emulated.BN254Fp]("11531890680898353878360012539400900008526988690934761520248628457335690795123")
 	PrecomputedLines[3][55].A0 = emulated.ValueOf[emulated.BN254Fp]("20477902323488575991951213865423297622833142796361576949400620096696322938714")
 	PrecomputedLines[3][55].A1 = emulated.ValueOf[emulated.BN254Fp]("15588298079216618665269525070947878050724410941478388548632206918344265255660")
-	// i =  54
+	// i = 54
 	PrecomputedLines[0][54].A0 = emulated.ValueOf[emulated.BN254Fp]("2423954684975409421113106111483795343402357285046083347957019460241561263903")
 	PrecomputedLines[0][54].A1 = emulated.ValueOf[emulated.BN254Fp]("21686350663615057943513793308336218547546151034882551854568393652361503247048")
 	PrecomputedLines[1][54].A0 = emulated.ValueOf[emulated.BN254Fp]("17983889902010442989037806626756227210350021563685842847697610334754612024861")
 	PrecomputedLines[1][54].A1 = emulated.ValueOf[emulated.BN254Fp]("16240742027186297373450412352773641086393171254541832522856010008961570443994")
-	// i =  53
+	// i = 53
 	PrecomputedLines[0][53].A0 = emulated.ValueOf[emulated.BN254Fp]("3299397589651430835046889418728383096966563010050347574588126659195099410858")
 	PrecomputedLines[0][53].A1 = emulated.ValueOf[emulated.BN254Fp]("2702370610853020400314647094191315939694996588064902553275009984508683489928")
 	PrecomputedLines[1][53].A0 = emulated.ValueOf[emulated.BN254Fp]("3979157981082211890213327198809251890345860044112490020571189248429346769401")
 	PrecomputedLines[1][53].A1 = emulated.ValueOf[emulated.BN254Fp]("19944567808172014331943455052193835769131894893228158061444755101571479200005")
-	// i =  52
+	// i = 52
 	PrecomputedLines[0][52].A0 = emulated.ValueOf[emulated.BN254Fp]("6258196544160211102321925954410279782816574037374433438978246710964080754940")
 	PrecomputedLines[0][52].A1 = emulated.ValueOf[emulated.BN254Fp]("3005660902529334848218755993004858578785696325272369744839253118680617897204")
 	PrecomputedLines[1][52].A0 = emulated.ValueOf[emulated.BN254Fp]("814264396833942665903531553752517163857204609821495556934616064593387497987")
 	PrecomputedLines[1][52].A1 = emulated.ValueOf[emulated.BN254Fp]("18008736309258122452427755394070359806936368847348640156095452637685946009270")
-	// i =  51
+	// i = 51
 	PrecomputedLines[0][51].A0 = emulated.ValueOf[emulated.BN254Fp]("12420890053219508598807591154496379602809478224965227087113822934299408691873")
 	PrecomputedLines[0][51].A1 = emulated.ValueOf[emulated.BN254Fp]("17303367877986414511475092300779955134419370651210868516114472535617760078437")
 	PrecomputedLines[1][51].A0 = emulated.ValueOf[emulated.BN254Fp]("5654129892575944946690000888934348891080307733028900301840391685691529358356")
@@ -95,12 +99,12 @@ func init() {
 	PrecomputedLines[2][51].A1 = emulated.ValueOf[emulated.BN254Fp]("19086876210297108145367386096540798080237895501217269732090299553431756186874")
 	PrecomputedLines[3][51].A0 = emulated.ValueOf[emulated.BN254Fp]("20152602455257424885705855540594687474352896500023277194652697233085988612702")
 	PrecomputedLines[3][51].A1 = emulated.ValueOf[emulated.BN254Fp]("5047067997772839341060798895369969865071494197407643998682395775472163143236")
-	// i =  50
+	// i = 50
 	PrecomputedLines[0][50].A0 = emulated.ValueOf[emulated.BN254Fp]("9679722372596923253224153788895318885991703645078795262894298224910585336719")
 	PrecomputedLines[0][50].A1 = emulated.ValueOf[emulated.BN254Fp]("14514223747309844563686332289086628131330780550209469152711732915738910690017")
 	PrecomputedLines[1][50].A0 = emulated.ValueOf[emulated.BN254Fp]("21089781100202322623793764397713628697432828784246141248094996360041604110331")
 	PrecomputedLines[1][50].A1 = emulated.ValueOf[emulated.BN254Fp]("17528189601039012041588006220155360374406334568305836940619703484971862864495")
-	// i =  49
+	// i = 49
 	PrecomputedLines[0][49].A0 = emulated.ValueOf[emulated.BN254Fp]("11070264611763304059093376142120848903484793346053636108950365869037426569578")
 	PrecomputedLines[0][49].A1 = emulated.ValueOf[emulated.BN254Fp]("5566620684243985148174385257832990705507517654546203571578376077206518542096")
 	PrecomputedLines[1][49].A0 = emulated.ValueOf[emulated.BN254Fp]("21439244283016676608324861890592606819171700780439376409220912790618219709100")
@@ -109,12 +113,12 @@ func init() {
 	PrecomputedLines[2][49].A1 = emulated.ValueOf[emulated.BN254Fp]("21718155741215205740714059098811649981889099801055042894213824492357370586441")
 	PrecomputedLines[3][49].A0 = emulated.ValueOf[emulated.BN254Fp]("18117071879837371740798839911980038457907852615599242665408622350971595899012")
 	PrecomputedLines[3][49].A1 = emulated.ValueOf[emulated.BN254Fp]("8568859093111261946081380679772818415299601251733724245998939644866684988958")
-	// i =  48
+	// i = 48
 	PrecomputedLines[0][48].A0 = emulated.ValueOf[emulated.BN254Fp]("7653502376183972189528005574912937698901707992597867413732313005949090861078")
 	PrecomputedLines[0][48].A1 = emulated.ValueOf[emulated.BN254Fp]("18078299108818183297076160903115040870971029681103562344505507059920109498724")
 	PrecomputedLines[1][48].A0 = emulated.ValueOf[emulated.BN254Fp]("5958828994437554107496173026037633753578905513469664500371044380353413307215")
 	PrecomputedLines[1][48].A1 = emulated.ValueOf[emulated.BN254Fp]("4457857287549567054803825263261489919553034648299704807245359432847888563495")
-	// i =  47
+	// i = 47
 	PrecomputedLines[0][47].A0 = emulated.ValueOf[emulated.BN254Fp]("11785245607980003005839007342283447681532074921512117922377232062695696875063")
 	PrecomputedLines[0][47].A1 = emulated.ValueOf[emulated.BN254Fp]("3674471715303242650639836240573952653116010918695452109397036698752292897898")
 	PrecomputedLines[1][47].A0 = emulated.ValueOf[emulated.BN254Fp]("45627793441629135917601349379085503635034794546470734893491110198522468332")
@@ -123,17 +127,17 @@ func init() {
 	PrecomputedLines[2][47].A1 = emulated.ValueOf[emulated.BN254Fp]("14310914677517468679944086988957529739710673981285059899394344618236313440099")
 	PrecomputedLines[3][47].A0 = emulated.ValueOf[emulated.BN254Fp]("10374119938212414799339884240754452606245790388879855930781662754854289264542")
 	PrecomputedLines[3][47].A1 = emulated.ValueOf[emulated.BN254Fp]("7249803302968745898565931551390491553765429802954021150194461040817468653516")
-	// i =  46
+	// i = 46
 	PrecomputedLines[0][46].A0 = emulated.ValueOf[emulated.BN254Fp]("3322469610693328663691825947323331441415625540966696120651002654440253319052")
 	PrecomputedLines[0][46].A1 = emulated.ValueOf[emulated.BN254Fp]("1942780532870155484021476731037192630204446135294484553429490820308706502574")
 	PrecomputedLines[1][46].A0 = emulated.ValueOf[emulated.BN254Fp]("17202876709489003816438966092823149993355061110971733076837388178975675061543")
 	PrecomputedLines[1][46].A1 = emulated.ValueOf[emulated.BN254Fp]("1827707550130567292619674424799209527730638026770607682353371933371424896649")
-	// i =  45
+	// i = 45
 	PrecomputedLines[0][45].A0 = emulated.ValueOf[emulated.BN254Fp]("4454860302834193997922027643677141826335477685905943160881719665216396316023")
 	PrecomputedLines[0][45].A1 = emulated.ValueOf[emulated.BN254Fp]("13460420380135308103263096461528931372790583621207990906826840013420510427402")
 	PrecomputedLines[1][45].A0 = emulated.ValueOf[emulated.BN254Fp]("6173471785502118124181307330287807246726679438042165280456980585789204024261")
 	PrecomputedLines[1][45].A1 = emulated.ValueOf[emulated.BN254Fp]("9115487602626298454909684608831879314662251126849935581628519764110399653742")
-	// i =  44
+	// i = 44
 	PrecomputedLines[0][44].A0 = emulated.ValueOf[emulated.BN254Fp]("1808160525575058758917825482888189988480854443063379197012638697518523206015")
 	PrecomputedLines[0][44].A1 = emulated.ValueOf[emulated.BN254Fp]("17974039573517006177103900772046815180642726119197145299789772931932242373761")
 	PrecomputedLines[1][44].A0 = emulated.ValueOf[emulated.BN254Fp]("4836565830302726743825617174678612813412946168360212251833373381562334895992")
@@ -142,32 +146,32 @@ func init() {
 	PrecomputedLines[2][44].A1 = emulated.ValueOf[emulated.BN254Fp]("2908093977878325873797969441052414806114066218549559765693286267809031453390")
 	PrecomputedLines[3][44].A0 = emulated.ValueOf[emulated.BN254Fp]("11160891947094503976396935083892652810330554397537039864076674697178398903810")
 	PrecomputedLines[3][44].A1 = emulated.ValueOf[emulated.BN254Fp]("8932087540233051702344716353214580354819556708224293257744342595927104649379")
-	// i =  43
+	// i = 43
 	PrecomputedLines[0][43].A0 = emulated.ValueOf[emulated.BN254Fp]("21406534929501956719171835897604615263148474051911745550006151022849957388643")
 	PrecomputedLines[0][43].A1 = emulated.ValueOf[emulated.BN254Fp]("10279781929005127439140488793928581246508457419866317770606378400774241150772")
 	PrecomputedLines[1][43].A0 = emulated.ValueOf[emulated.BN254Fp]("9709078210642965619422714825761003836985439131762449854224392441007339412834")
 	PrecomputedLines[1][43].A1 = emulated.ValueOf[emulated.BN254Fp]("11129005740706905660803848575047973153280590126228714832868068527425037373751")
-	// i =  42
+	// i = 42
 	PrecomputedLines[0][42].A0 = emulated.ValueOf[emulated.BN254Fp]("5570866902587445338732530591687200111549728761628124016050692968437706751601")
 	PrecomputedLines[0][42].A1 = emulated.ValueOf[emulated.BN254Fp]("10275848058977370789435005534809927911069178479505311836113062825745267179381")
 	PrecomputedLines[1][42].A0 = emulated.ValueOf[emulated.BN254Fp]("3633887160270413594942060642216918996537999582294210057554627044590039786234")
 	PrecomputedLines[1][42].A1 = emulated.ValueOf[emulated.BN254Fp]("8939668248755020860948171356447789359992139917425514662320694855157405768150")
-	// i =  41
+	// i = 41
 	PrecomputedLines[0][41].A0 = emulated.ValueOf[emulated.BN254Fp]("18885470138073884864482120331203309626511755319182411648838895487000802451896")
 	PrecomputedLines[0][41].A1 = emulated.ValueOf[emulated.BN254Fp]("9622125734920158797843141885788251658263242254352775708729064763529753374409")
 	PrecomputedLines[1][41].A0 = emulated.ValueOf[emulated.BN254Fp]("19492892729379236004557729196741558779480783232265355978008529229208638077001")
 	PrecomputedLines[1][41].A1 = emulated.ValueOf[emulated.BN254Fp]("9026667103760964167291393581811590447916219414798161581254060489069424386713")
-	// i =  40
+	// i = 40
 	PrecomputedLines[0][40].A0 = emulated.ValueOf[emulated.BN254Fp]("7917086886314173678014982140498314563333885917374381676048136849306589970300")
 	PrecomputedLines[0][40].A1 = emulated.ValueOf[emulated.BN254Fp]("12261633934171134354022033316006453963392366695723701109381951932703857762414")
 	PrecomputedLines[1][40].A0 = emulated.ValueOf[emulated.BN254Fp]("10914160321677904051918987802671845728099932842880059488686382944858229492896")
 	PrecomputedLines[1][40].A1 = emulated.ValueOf[emulated.BN254Fp]("8359557585977416078793909793984425896635087139789596461899708508514130896302")
-	// i =  39
+	// i = 39
 	PrecomputedLines[0][39].A0 = emulated.ValueOf[emulated.BN254Fp]("2832877770969085666300187729756811975718245115828438742107798110254443365408")
 	PrecomputedLines[0][39].A1 = emulated.ValueOf[emulated.BN254Fp]("4015898436582302751338662177471784429329474816900569691956338217668304075143")
 	PrecomputedLines[1][39].A0 = emulated.ValueOf[emulated.BN254Fp]("6842872200246861427705093037496466614015446887756834045870833833876444837543")
 	PrecomputedLines[1][39].A1 = emulated.ValueOf[emulated.BN254Fp]("15273790836304547702356985139791848656234288403812600849950908226829046944652")
-	// i =  38
+	// i = 38
 	PrecomputedLines[0][38].A0 = emulated.ValueOf[emulated.BN254Fp]("17748964682733066496477674137304072265649362699626972384780286384184403231976")
 	PrecomputedLines[0][38].A1 = emulated.ValueOf[emulated.BN254Fp]("2838655568440449121815178130424124290652171075642869140451330756670997612885")
 	PrecomputedLines[1][38].A0 = emulated.ValueOf[emulated.BN254Fp]("1913181888602803253410490851322440164398255901127376824362120108174814218344")
@@ -176,17 +180,17 @@ func init() {
 	PrecomputedLines[2][38].A1 = emulated.ValueOf[emulated.BN254Fp]("13850937935385199095549631062956009887187878536528675610032140109569037386090")
 	PrecomputedLines[3][38].A0 = emulated.ValueOf[emulated.BN254Fp]("9571531692086612395026521386471462664121950697416902123408435110246350832630")
 	PrecomputedLines[3][38].A1 = emulated.ValueOf[emulated.BN254Fp]("9262658991286591312944640932012648892614883797518222287840538936149182139125")
-	// i =  37
+	// i = 37
 	PrecomputedLines[0][37].A0 = emulated.ValueOf[emulated.BN254Fp]("12457672116858821147570417815713622479940621522732096986429050939590597339051")
 	PrecomputedLines[0][37].A1 = emulated.ValueOf[emulated.BN254Fp]("18398985605215365318947837405711002640755060748945931179484765291655005193783")
 	PrecomputedLines[1][37].A0 = emulated.ValueOf[emulated.BN254Fp]("6761100916752031499470061472959664103643695753371128647767956417871801525935")
 	PrecomputedLines[1][37].A1 = emulated.ValueOf[emulated.BN254Fp]("11516568990599057505448657013624491615577778080330416531299339213631684799703")
-	// i =  36
+	// i = 36
 	PrecomputedLines[0][36].A0 = emulated.ValueOf[emulated.BN254Fp]("20157739938048499791825110639183940868502246006091528387643069694176344051024")
 	PrecomputedLines[0][36].A1 = emulated.ValueOf[emulated.BN254Fp]("2828679024172680272609250174898575639278853817285186536953499674429263641394")
 	PrecomputedLines[1][36].A0 = emulated.ValueOf[emulated.BN254Fp]("5507629493376754776780948128794176380876913178566429119195698066509123857842")
 	PrecomputedLines[1][36].A1 = emulated.ValueOf[emulated.BN254Fp]("11989866298421717405888814591756712355645135873368124241103659461001231130026")
-	// i =  35
+	// i = 35
 	PrecomputedLines[0][35].A0 = emulated.ValueOf[emulated.BN254Fp]("18803362902571183160534990574868966535501222775740632166562002643682331204055")
 	PrecomputedLines[0][35].A1 = emulated.ValueOf[emulated.BN254Fp]("2233847910285387579556930418866824465027863750525494540604564132801222851847")
 	PrecomputedLines[1][35].A0 = emulated.ValueOf[emulated.BN254Fp]("14894594813989329665437337634052128835849772709222460160323937655662277269998")
@@ -195,12 +199,12 @@ func init() {
 	PrecomputedLines[2][35].A1 = emulated.ValueOf[emulated.BN254Fp]("12531482429379825647834106004615712608548514329644694776489888393706881261235")
 	PrecomputedLines[3][35].A0 = emulated.ValueOf[emulated.BN254Fp]("9873979880229408105971223367529128014487901861772253050912642581888257648233")
 	PrecomputedLines[3][35].A1 = emulated.ValueOf[emulated.BN254Fp]("17024384825828294129839942917357501284449447510471307237753297102647863989056")
-	// i =  34
+	// i = 34
 	PrecomputedLines[0][34].A0 = emulated.ValueOf[emulated.BN254Fp]("9653366074145323295722703240755007396582576595924214989534987903378311878297")
 	PrecomputedLines[0][34].A1 = emulated.ValueOf[emulated.BN254Fp]("20335540665422011227662851543501012053614149159296868563872809593806311578153")
 	PrecomputedLines[1][34].A0 = emulated.ValueOf[emulated.BN254Fp]("3346681777791788671775024643719873028656163698396937227379634931654104469710")
 	PrecomputedLines[1][34].A1 = emulated.ValueOf[emulated.BN254Fp]("3307435597320603699014389476882124244457440216712858513447652502717610656034")
-	// i =  33
+	// i = 33
 	PrecomputedLines[0][33].A0 = emulated.ValueOf[emulated.BN254Fp]("18209443688907178902666130371838848829021303314723242040408374329257505874298")
 	PrecomputedLines[0][33].A1 = emulated.ValueOf[emulated.BN254Fp]("17012865229030954811928627442171265444826656974298650920955602237427025269570")
 	PrecomputedLines[1][33].A0 = emulated.ValueOf[emulated.BN254Fp]("13251168487096931816968492472936283913931508108412049848483510193471001761262")
@@ -209,17 +213,17 @@ func init() {
 	PrecomputedLines[2][33].A1 = emulated.ValueOf[emulated.BN254Fp]("3782019545450857785432418735694615956576199844498504213150631388689924073378")
 	PrecomputedLines[3][33].A0 = emulated.ValueOf[emulated.BN254Fp]("10219383385884950870363850119493120482938585228366425933545069183698860959545")
 	PrecomputedLines[3][33].A1 = emulated.ValueOf[emulated.BN254Fp]("4610898162835928978462538221841591782260443101663343291474718241976139555925")
-	// i =  32
+	// i = 32
 	PrecomputedLines[0][32].A0 = emulated.ValueOf[emulated.BN254Fp]("20934811687581609260873070602688515368109046845218194607210991416167897816402")
 	PrecomputedLines[0][32].A1 = emulated.ValueOf[emulated.BN254Fp]("5862163452113823219899111570982726953048159166456119375917149119349465378872")
 	PrecomputedLines[1][32].A0 = emulated.ValueOf[emulated.BN254Fp]("18680771072947791515144826030430400508598943175893875281965984728348827258637")
 	PrecomputedLines[1][32].A1 = emulated.ValueOf[emulated.BN254Fp]("21040117418693567065352610126251750729568112020473406293397044779880248456691")
-	// i =  31
+	// i = 31
 	PrecomputedLines[0][31].A0 = emulated.ValueOf[emulated.BN254Fp]("7964439593062737400984035539878664198651178025806860534579667949165595587005")
 	PrecomputedLines[0][31].A1 = emulated.ValueOf[emulated.BN254Fp]("2838157160991125797583478331796928440511613965670870614157463438056333538151")
 	PrecomputedLines[1][31].A0 = emulated.ValueOf[emulated.BN254Fp]("15229006183665644290447325495913132772531638749927123822183273630764466089789")
 	PrecomputedLines[1][31].A1 = emulated.ValueOf[emulated.BN254Fp]("16074291619482614777639379462192763167638195517822265884849973509949484533348")
-	// i =  30
+	// i = 30
 	PrecomputedLines[0][30].A0 = emulated.ValueOf[emulated.BN254Fp]("17216673940334785289516808707485909812399730071261828078641261494030193339151")
 	PrecomputedLines[0][30].A1 = emulated.ValueOf[emulated.BN254Fp]("1558246640723974010862813923593922625293354601470135041696925666565577625238")
 	PrecomputedLines[1][30].A0 = emulated.ValueOf[emulated.BN254Fp]("3854786674985460796726122882377437532974958505502673869156016507727783114650")
@@ -228,27 +232,27 @@ func init() {
 	PrecomputedLines[2][30].A1 = emulated.ValueOf[emulated.BN254Fp]("16864911664763277142359301452971910091257378264425969043189691884660256976414")
 	PrecomputedLines[3][30].A0 = emulated.ValueOf[emulated.BN254Fp]("6152358889597859795339082960365216775296352387558616966143609899597537421031")
 	PrecomputedLines[3][30].A1 = emulated.ValueOf[emulated.BN254Fp]("16653254093932107167798383542777416165270049087427470272658796467120270028484")
-	// i =  29
+	// i = 29
 	PrecomputedLines[0][29].A0 = emulated.ValueOf[emulated.BN254Fp]("14632407693471058889728140077691609987771263933332165632341567611801065809022")
 	PrecomputedLines[0][29].A1 = emulated.ValueOf[emulated.BN254Fp]("11272021435858583497469551493401241107077029606274078664163635970617420849248")
 	PrecomputedLines[1][29].A0 = emulated.ValueOf[emulated.BN254Fp]("4770200410992146769475852516775361139844234572488330099462736062550552604829")
 	PrecomputedLines[1][29].A1 = emulated.ValueOf[emulated.BN254Fp]("7530403448372346045695321765829491479483699869272077605645557759889158081085")
-	// i =  28
+	// i = 28
 	PrecomputedLines[0][28].A0 = emulated.ValueOf[emulated.BN254Fp]("11519923879341878511380494246354517413557387093469380775615805559630889395544")
 	PrecomputedLines[0][28].A1 = emulated.ValueOf[emulated.BN254Fp]("1407583269883799199470286256592915474697530621627456286943142767826758460634")
 	PrecomputedLines[1][28].A0 = emulated.ValueOf[emulated.BN254Fp]("11904307076773678983926013243694306017809942930429096021607731892636261237434")
 	PrecomputedLines[1][28].A1 = emulated.ValueOf[emulated.BN254Fp]("5418228693521695819333327920054742553191693728075186406978931357199320286728")
-	// i =  27
+	// i = 27
 	PrecomputedLines[0][27].A0 = emulated.ValueOf[emulated.BN254Fp]("13583649344163235628273822059455216086651158972357128597527291053634930639606")
 	PrecomputedLines[0][27].A1 = emulated.ValueOf[emulated.BN254Fp]("1580303658246025496517878831829049949728218261141271124320904477564504770538")
 	PrecomputedLines[1][27].A0 = emulated.ValueOf[emulated.BN254Fp]("21116413106194933456702972814632992147195390921459561292248712868055344887668")
 	PrecomputedLines[1][27].A1 = emulated.ValueOf[emulated.BN254Fp]("2722273224111047596007628929518646734380443821631939025258952090508660165266")
-	// i =  26
+	// i = 26
 	PrecomputedLines[0][26].A0 = emulated.ValueOf[emulated.BN254Fp]("18471407238975412722919516576273263250888565110885782624375161300902906625257")
 	PrecomputedLines[0][26].A1 = emulated.ValueOf[emulated.BN254Fp]("1703792855453408300033031347710143415065900804014322538641022938745091381930")
 	PrecomputedLines[1][26].A0 = emulated.ValueOf[emulated.BN254Fp]("10863470541316497325728428920551887981270488659171371431262418928646119718147")
 	PrecomputedLines[1][26].A1 = emulated.ValueOf[emulated.BN254Fp]("17241260064348476546268798180555052578472388987371855915353518730015387072298")
-	// i =  25
+	// i = 25
 	PrecomputedLines[0][25].A0 = emulated.ValueOf[emulated.BN254Fp]("8932519706584413797822306415256639256040689258169061374436911262999997993142")
 	PrecomputedLines[0][25].A1 = emulated.ValueOf[emulated.BN254Fp]("393522374513586188088711426908867437661499514838744902729423194492781594674")
 	PrecomputedLines[1][25].A0 = emulated.ValueOf[emulated.BN254Fp]("16189841300225557029339625228091337040532368631861952893841567332696428948407")
@@ -257,12 +261,12 @@ func init() {
 	PrecomputedLines[2][25].A1 = emulated.ValueOf[emulated.BN254Fp]("12996576065238071442557753878581565019438532181136511892084803477505718680757")
 	PrecomputedLines[3][25].A0 = emulated.ValueOf[emulated.BN254Fp]("10044194885594442142147609155416421922257472356796305453772928783393388899067")
 	PrecomputedLines[3][25].A1 = emulated.ValueOf[emulated.BN254Fp]("18263760812393990074188911070757643007564984011360754662297829292657271464150")
-	// i =  24
+	// i = 24
 	PrecomputedLines[0][24].A0 = emulated.ValueOf[emulated.BN254Fp]("3061883141739315602214563556302122268976467112226903626183003265864700797561")
 	PrecomputedLines[0][24].A1 = emulated.ValueOf[emulated.BN254Fp]("18877577761913543892554361883552980798297636968407602018463962047995764813219")
 	PrecomputedLines[1][24].A0 = emulated.ValueOf[emulated.BN254Fp]("3126746139599510717075143025311775654763541372790089146406042067854741163507")
 	PrecomputedLines[1][24].A1 = emulated.ValueOf[emulated.BN254Fp]("17167127260886017815263816291999617868265214449605859656444200044917253310911")
-	// i =  23
+	// i = 23
 	PrecomputedLines[0][23].A0 = emulated.ValueOf[emulated.BN254Fp]("16870441941931526976119500440658808713972889735578011962941661985358104161131")
 	PrecomputedLines[0][23].A1 = emulated.ValueOf[emulated.BN254Fp]("6001109932250934659271452903112877161042944352421424685637313477276198572097")
 	PrecomputedLines[1][23].A0 = emulated.ValueOf[emulated.BN254Fp]("16637142689871529585590417483409806422524779986734732708338690138313396058389")
@@ -271,22 +275,22 @@ func init() {
 	PrecomputedLines[2][23].A1 = emulated.ValueOf[emulated.BN254Fp]("20399818991869794833324015990855132322105497628093638572012296248349524670190")
 	PrecomputedLines[3][23].A0 = emulated.ValueOf[emulated.BN254Fp]("7342977470454632355392560878259850035965508595638789766324590700762099135681")
 	PrecomputedLines[3][23].A1 = emulated.ValueOf[emulated.BN254Fp]("13133475924936788812547393936820540309170125548865828094250523111922518822075")
-	// i =  22
+	// i = 22
 	PrecomputedLines[0][22].A0 = emulated.ValueOf[emulated.BN254Fp]("4748990156186402568189268203915060520971178335693786330788682736855281763837")
 	PrecomputedLines[0][22].A1 = emulated.ValueOf[emulated.BN254Fp]("1309123459585246519346967984684303594496756037532773816040933448912027627499")
 	PrecomputedLines[1][22].A0 = emulated.ValueOf[emulated.BN254Fp]("14774495602218432844736442669860287970046937120486943306048410603925270631316")
 	PrecomputedLines[1][22].A1 = emulated.ValueOf[emulated.BN254Fp]("7758103039306620389373197481991170462191047006336390431484191868400773850577")
-	// i =  21
+	// i = 21
 	PrecomputedLines[0][21].A0 = emulated.ValueOf[emulated.BN254Fp]("20467216100325522645996376085496391619753268832330437756343044572011862940545")
 	PrecomputedLines[0][21].A1 = emulated.ValueOf[emulated.BN254Fp]("14887102390814534704591565166101282155253192464472393232200334099849552058977")
 	PrecomputedLines[1][21].A0 = emulated.ValueOf[emulated.BN254Fp]("21078606515401393469046677323685463512748861024754049000789577483054033214476")
 	PrecomputedLines[1][21].A1 = emulated.ValueOf[emulated.BN254Fp]("4564303136472462460176799031863979133770926707479849529175620334944346563602")
-	// i =  20
+	// i = 20
 	PrecomputedLines[0][20].A0 = emulated.ValueOf[emulated.BN254Fp]("2934547587293842961452405179156964753642527525482090204385414595783458332955")
 	PrecomputedLines[0][20].A1 = emulated.ValueOf[emulated.BN254Fp]("13388881467399048052694263240074072556503679672952359032570384893452576065521")
 	PrecomputedLines[1][20].A0 = emulated.ValueOf[emulated.BN254Fp]("20835022106176713220060462057962830658550225802689628738113269878374295200567")
 	PrecomputedLines[1][20].A1 = emulated.ValueOf[emulated.BN254Fp]("16905312434058784658661572959194517726558593812351378232506422776680415520438")
-	// i =  19
+	// i = 19
 	PrecomputedLines[0][19].A0 = emulated.ValueOf[emulated.BN254Fp]("9379108182894698430849303731451612013091506165395461542418088794313609818924")
 	PrecomputedLines[0][19].A1 = emulated.ValueOf[emulated.BN254Fp]("2907967239075992964474535088161648903162956259142634038906064837044072456436")
 	PrecomputedLines[1][19].A0 = emulated.ValueOf[emulated.BN254Fp]("20782609764653852909287250407883639725034699195478521117170508454748489944739")
@@ -295,12 +299,12 @@ func init() {
 	PrecomputedLines[2][19].A1 = emulated.ValueOf[emulated.BN254Fp]("21262034978109692747726845264241152568669959314932048093743486024410820587806")
 	PrecomputedLines[3][19].A0 = emulated.ValueOf[emulated.BN254Fp]("4130709627975833054760191215714688654831243211864203332343057006624671877225")
 	PrecomputedLines[3][19].A1 = emulated.ValueOf[emulated.BN254Fp]("13472955160176293525849695637350417649009388964115438600570520044550603528353")
-	// i =  18
+	// i = 18
 	PrecomputedLines[0][18].A0 = emulated.ValueOf[emulated.BN254Fp]("16217086076844556529363966917086131649757503473381572790624279844617553496364")
 	PrecomputedLines[0][18].A1 = emulated.ValueOf[emulated.BN254Fp]("10713288731971058998221606378429065825113414556825913172815543429990736818376")
 	PrecomputedLines[1][18].A0 = emulated.ValueOf[emulated.BN254Fp]("12552050545636774575337026751447963392888877749601484102741403327500446156874")
 	PrecomputedLines[1][18].A1 = emulated.ValueOf[emulated.BN254Fp]("1889715733419853004961620455197495456081564113924277969675613143136837135513")
-	// i =  17
+	// i = 17
 	PrecomputedLines[0][17].A0 = emulated.ValueOf[emulated.BN254Fp]("281209871248542006516712473420034295321120298208074788459534204367198535142")
 	PrecomputedLines[0][17].A1 = emulated.ValueOf[emulated.BN254Fp]("11427002786003194988328672063057116278011347987499596141846392618935095919375")
 	PrecomputedLines[1][17].A0 = emulated.ValueOf[emulated.BN254Fp]("8249292749133127482785740847289832702955066233577280917498121478679299264218")
@@ -309,17 +313,17 @@ func init() {
 	PrecomputedLines[2][17].A1 = emulated.ValueOf[emulated.BN254Fp]("10626908974014138715216269718238311856490393026039163714213665951817067052590")
 	PrecomputedLines[3][17].A0 = emulated.ValueOf[emulated.BN254Fp]("6357700871494731822410049284748295785098346320944639515384097511493616712415")
 	PrecomputedLines[3][17].A1 = emulated.ValueOf[emulated.BN254Fp]("18094386343075402462056828857430458085585468610519347879110366976800945012238")
-	// i =  16
+	// i = 16
 	PrecomputedLines[0][16].A0 = emulated.ValueOf[emulated.BN254Fp]("14531595037104551623335765422361255737783733804534887309336561182386022894609")
 	PrecomputedLines[0][16].A1 = emulated.ValueOf[emulated.BN254Fp]("13693292038242340138317667055996944950242100560633566577428335327100558601931")
 	PrecomputedLines[1][16].A0 = emulated.ValueOf[emulated.BN254Fp]("19882300238879412083813185345065778671818694192928769536446198022672502614800")
 	PrecomputedLines[1][16].A1 = emulated.ValueOf[emulated.BN254Fp]("19023802829192861606681770049155217498069279515832660659542794970260956599076")
-	// i =  15
+	// i = 15
 	PrecomputedLines[0][15].A0 = emulated.ValueOf[emulated.BN254Fp]("214428669950930239037502001140346676398224589363492833374258252706619225095")
 	PrecomputedLines[0][15].A1 = emulated.ValueOf[emulated.BN254Fp]("9700482781441182965875593020977851473671521652260949157117738997229458832612")
 	PrecomputedLines[1][15].A0 = emulated.ValueOf[emulated.BN254Fp]("5707939202694442208311052687419434583655218004451905019053384532157090849511")
 	PrecomputedLines[1][15].A1 = emulated.ValueOf[emulated.BN254Fp]("9249003779150082855802401674917195099999438620295626021241732108833595933661")
-	// i =  14
+	// i = 14
 	PrecomputedLines[0][14].A0 = emulated.ValueOf[emulated.BN254Fp]("7805886586080369896587926569311726027953215021897217781996744411655756551999")
 	PrecomputedLines[0][14].A1 = emulated.ValueOf[emulated.BN254Fp]("4475945661578122172966964851067681040356518221787176436864599099315745378607")
 	PrecomputedLines[1][14].A0 = emulated.ValueOf[emulated.BN254Fp]("1436676498637654967294854037272027428354069617227014207131783637892060911873")
@@ -328,22 +332,22 @@ func init() {
 	PrecomputedLines[2][14].A1 = emulated.ValueOf[emulated.BN254Fp]("2358246181418771103293301333572732567327456223492056127888059507221213191432")
 	PrecomputedLines[3][14].A0 = emulated.ValueOf[emulated.BN254Fp]("3214429786637011410044793447049402874657838824607091686668942744472659699178")
 	PrecomputedLines[3][14].A1 = emulated.ValueOf[emulated.BN254Fp]("3311041522569135867590138245723395084506957431406246791002073444583824677944")
-	// i =  13
+	// i = 13
 	PrecomputedLines[0][13].A0 = emulated.ValueOf[emulated.BN254Fp]("7940653081686121254560574898875834993172673192201552554589347797020017756575")
 	PrecomputedLines[0][13].A1 = emulated.ValueOf[emulated.BN254Fp]("15974145135205498451459757927505164636050928369369965530985889343297252806673")
 	PrecomputedLines[1][13].A0 = emulated.ValueOf[emulated.BN254Fp]("7686257707869567857469834549336963702481639214633150259937731475944847915123")
 	PrecomputedLines[1][13].A1 = emulated.ValueOf[emulated.BN254Fp]("8581852611449322691153270314388156853032486316219977253503422966968101541654")
-	// i =  12
+	// i = 12
 	PrecomputedLines[0][12].A0 = emulated.ValueOf[emulated.BN254Fp]("7606607159448995415026938236473845673703071556204161879755865676515825543592")
 	PrecomputedLines[0][12].A1 = emulated.ValueOf[emulated.BN254Fp]("8956068938006055699967046837110471704135545397148189742180442797078694877053")
 	PrecomputedLines[1][12].A0 = emulated.ValueOf[emulated.BN254Fp]("11022564885667925490414698424833447218714993895366895350576595161435910155603")
 	PrecomputedLines[1][12].A1 = emulated.ValueOf[emulated.BN254Fp]("2937380761826300692577553924917170147052369549814500103731019151442626339958")
-	// i =  11
+	// i = 11
 	PrecomputedLines[0][11].A0 = emulated.ValueOf[emulated.BN254Fp]("12051248266980606591117486251497672077260922365434536343080997678580860092839")
 	PrecomputedLines[0][11].A1 = emulated.ValueOf[emulated.BN254Fp]("1101948046901408684769644765236167201751826825758237448303491655657473871883")
 	PrecomputedLines[1][11].A0 = emulated.ValueOf[emulated.BN254Fp]("19040361699025595665325643767494987684082158366896439415230194791631253146950")
 	PrecomputedLines[1][11].A1 = emulated.ValueOf[emulated.BN254Fp]("19593948793881594577280833499171904486133659865964978128524672716381353415110")
-	// i =  10
+	// i = 10
 	PrecomputedLines[0][10].A0 = emulated.ValueOf[emulated.BN254Fp]("2695861635377070245469834129082472669158214946653615403814586888514439809659")
 	PrecomputedLines[0][10].A1 = emulated.ValueOf[emulated.BN254Fp]("3517424455933415445379245336041862787934743784030363349436486456627711568935")
 	PrecomputedLines[1][10].A0 = emulated.ValueOf[emulated.BN254Fp]("1297121294706129311008967261335655974545941670322230404082189407981090136606")
@@ -352,17 +356,17 @@ func init() {
 	PrecomputedLines[2][10].A1 = emulated.ValueOf[emulated.BN254Fp]("106502518068427873618571970037977909894299363465928161471159218521528331255")
 	PrecomputedLines[3][10].A0 = emulated.ValueOf[emulated.BN254Fp]("9621932176503134785751044845073759336043415956999820764897251636989455619322")
 	PrecomputedLines[3][10].A1 = emulated.ValueOf[emulated.BN254Fp]("11988385993623676567525757027648256037399190847305440889617409768798277399896")
-	// i =  9
+	// i = 9
 	PrecomputedLines[0][9].A0 = emulated.ValueOf[emulated.BN254Fp]("19372185202903332212213460689668073391237276001679830995407820540229540001238")
 	PrecomputedLines[0][9].A1 = emulated.ValueOf[emulated.BN254Fp]("5616465305834971731681446522628928621391712075162309643124420681983851054131")
 	PrecomputedLines[1][9].A0 = emulated.ValueOf[emulated.BN254Fp]("15130750112907645494599377984115117159035751050040977084315605482681536587330")
 	PrecomputedLines[1][9].A1 = emulated.ValueOf[emulated.BN254Fp]("4112821409985026926465852755077338777425800159968275868518690696940060997519")
-	// i =  8
+	// i = 8
 	PrecomputedLines[0][8].A0 = emulated.ValueOf[emulated.BN254Fp]("1177350099731769374927755229371912682105254009198092299667981153894962892911")
 	PrecomputedLines[0][8].A1 = emulated.ValueOf[emulated.BN254Fp]("12783556398948310494078028567080245047035120485548200979455238088948172228620")
 	PrecomputedLines[1][8].A0 = emulated.ValueOf[emulated.BN254Fp]("12038222281185955050483388631945863715711114498195498301547718431783742484642")
 	PrecomputedLines[1][8].A1 = emulated.ValueOf[emulated.BN254Fp]("9264166105136475526327132322149055656031511662283307277292723856236620490189")
-	// i =  7
+	// i = 7
 	PrecomputedLines[0][7].A0 = emulated.ValueOf[emulated.BN254Fp]("21486077995282264447124488458477451264448290571356619721096235651158475590070")
 	PrecomputedLines[0][7].A1 = emulated.ValueOf[emulated.BN254Fp]("14259294332493846514184808308757331443862876275854439881679087349107324605887")
 	PrecomputedLines[1][7].A0 = emulated.ValueOf[emulated.BN254Fp]("21310181591085388723115701669056667379511330213691931034851726064119888872673")
@@ -371,12 +375,12 @@ func init() {
 	PrecomputedLines[2][7].A1 = emulated.ValueOf[emulated.BN254Fp]("6606562192543571614836403257264220466480425913053458051187575436069152776683")
 	PrecomputedLines[3][7].A0 = emulated.ValueOf[emulated.BN254Fp]("10163523267982706542267128230964678625528511744949894317202814240082601743946")
 	PrecomputedLines[3][7].A1 = emulated.ValueOf[emulated.BN254Fp]("6356510014456263346824710768921779981830557122736686713952594920913429569627")
-	// i =  6
+	// i = 6
 	PrecomputedLines[0][6].A0 = emulated.ValueOf[emulated.BN254Fp]("6993754568930208115692311734335110502575073944799196719243980186522826192500")
 	PrecomputedLines[0][6].A1 = emulated.ValueOf[emulated.BN254Fp]("8644183822713440026441857877903243046934367497877832294531055867710247192861")
 	PrecomputedLines[1][6].A0 = emulated.ValueOf[emulated.BN254Fp]("6162215014365963127944303642424328743998922537326512693704891583562046811368")
 	PrecomputedLines[1][6].A1 = emulated.ValueOf[emulated.BN254Fp]("10154626677971349735949904871597330658975481223864906253916895014919837738925")
-	// i =  5
+	// i = 5
 	PrecomputedLines[0][5].A0 = emulated.ValueOf[emulated.BN254Fp]("1937978127062894188242539535500798667590225073902686974782593130206599845007")
 	PrecomputedLines[0][5].A1 = emulated.ValueOf[emulated.BN254Fp]("17119013397235014212137323292998779519533594281540918081901210988375145292838")
 	PrecomputedLines[1][5].A0 = emulated.ValueOf[emulated.BN254Fp]("7459302385665395083210154080521520536970822621811543022810558924096495990803")
@@ -385,12 +389,12 @@ func init() {
 	PrecomputedLines[2][5].A1 = emulated.ValueOf[emulated.BN254Fp]("3506829931079437251122292736914870037093525843321317425550720179685754066171")
 	PrecomputedLines[3][5].A0 = emulated.ValueOf[emulated.BN254Fp]("17820182080707051991327549024823189851054031788540897864701560845270403753109")
 	PrecomputedLines[3][5].A1 = emulated.ValueOf[emulated.BN254Fp]("9899305209455152807161919079699365227282179194615715116357423849253490779682")
-	// i =  4
+	// i = 4
 	PrecomputedLines[0][4].A0 = emulated.ValueOf[emulated.BN254Fp]("5324324825155514158487515405280394681879278019015840159544809591606158257332")
 	PrecomputedLines[0][4].A1 = emulated.ValueOf[emulated.BN254Fp]("9103354596188444592813598822287678248145908880267402190573436459100897831528")
 	PrecomputedLines[1][4].A0 = emulated.ValueOf[emulated.BN254Fp]("18167200785083515576687640058379703244722940349781476704929858352562239814160")
 	PrecomputedLines[1][4].A1 = emulated.ValueOf[emulated.BN254Fp]("17137305555905969523835852662612004277081830974452307053507378102895278528589")
-	// i =  3
+	// i = 3
 	PrecomputedLines[0][3].A0 = emulated.ValueOf[emulated.BN254Fp]("10264790697161180899816663648211298388740251958120764796906064810666177499646")
 	PrecomputedLines[0][3].A1 = emulated.ValueOf[emulated.BN254Fp]("20258752168525716639405362594120556561630156450371244903071234737141561446514")
 	PrecomputedLines[1][3].A0 = emulated.ValueOf[emulated.BN254Fp]("1047785956871651181652762185265609154515890741811384676308782178186674721333")
@@ -399,23 +403,23 @@ func init() {
 	PrecomputedLines[2][3].A1 = emulated.ValueOf[emulated.BN254Fp]("11497587520289618598164153674981445132323171186923426171334582641683949994368")
 	PrecomputedLines[3][3].A0 = emulated.ValueOf[emulated.BN254Fp]("20758819501863100067579713445623163018598253994701203661225784213541257554016")
 	PrecomputedLines[3][3].A1 = emulated.ValueOf[emulated.BN254Fp]("13856843617321777013534357727779974444221810617686309708742406908090120962388")
-	// i =  2
+	// i = 2
 	PrecomputedLines[0][2].A0 = emulated.ValueOf[emulated.BN254Fp]("7373558527687620561422152162991916593489921204438285449301245125513755320314")
 	PrecomputedLines[0][2].A1 = emulated.ValueOf[emulated.BN254Fp]("11221203116796205233830200618441749399293664601851323715931973686288216972676")
 	PrecomputedLines[1][2].A0 = emulated.ValueOf[emulated.BN254Fp]("5779253402567033185739372381451694349656589328372930738070702115143767419084")
 	PrecomputedLines[1][2].A1 = emulated.ValueOf[emulated.BN254Fp]("14510717290459248510467845044717011062002712332273480525810490069657559246488")
-	// i =  1
+	// i = 1
 	PrecomputedLines[0][1].A0 = emulated.ValueOf[emulated.BN254Fp]("4363337419110373219314875355410347765942328123649693011784654840812725908680")
 	PrecomputedLines[0][1].A1 = emulated.ValueOf[emulated.BN254Fp]("14128521847906711846651015249783668960206638766994768661570084127609973543329")
 	PrecomputedLines[1][1].A0 = emulated.ValueOf[emulated.BN254Fp]("14274248424128087078986321168245052972625654514554370562089216041782293981548")
 	PrecomputedLines[1][1].A1 = emulated.ValueOf[emulated.BN254Fp]("1819524229057471418630268602559233583119735893806768677221723572182212858124")
-	// i =  0
+	// i = 0
 	PrecomputedLines[0][0].A0 = emulated.ValueOf[emulated.BN254Fp]("9362219973542874570450638939162889131446156209210285596288463924394915480984")
 	PrecomputedLines[0][0].A1 = emulated.ValueOf[emulated.BN254Fp]("2166292944666058936308575452356594861484875658446920124101542049719645145111")
 	PrecomputedLines[1][0].A0 = emulated.ValueOf[emulated.BN254Fp]("4110752764440847029993710333296870396753666785458870337440969782289123199548")
 	PrecomputedLines[1][0].A1 = emulated.ValueOf[emulated.BN254Fp]("2524539108828422865916215076272533083788815095038371749085255761852573579569")
 
-	// precompute ℓ_{[6x₀+2]G,π(G)} and ℓ_{[6x₀+2]Q+π(Q),-π²(Q)}
+	// lines ℓ_{[6x₀+2]Q,π(Q)} and ℓ_{[6x₀+2]Q+π(Q),-π²(Q)}
 	PrecomputedLines[0][65].A0 = emulated.ValueOf[emulated.BN254Fp]("1783675334639145815870644667302053681284809203074760789174282843314959992696")
 	PrecomputedLines[0][65].A1 = emulated.ValueOf[emulated.BN254Fp]("1951629468503798175241267767783740387858451447279377240163542122176909999042")
 	PrecomputedLines[1][65].A0 = emulated.ValueOf[emulated.BN254Fp]("11606810498377529077474160506241087507512991084990061027495602653306616416578")
@@ -0,0 +1,139 @@
+package pairing_bn254
+
+//go:generate go run ../cmd/linegen -curve bn254 -o precomputations.go
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// FixedLines are the lines of the Miller loop for a fixed G2 argument Q, as
+// used by [Pairing.MillerLoopFixedQ]. For each iteration i, lines[0][i] and
+// lines[1][i] are the coefficients R0 and R1 of the tangent line at the
+// accumulated point T and, when loopCounter[i] = ±1, lines[2][i] and
+// lines[3][i] those of the line through [2]T and ±Q. The lines of the two
+// final additions of π(Q) and -π²(Q) are stored at indices 65 and 66.
+type FixedLines [4][67]E2
+
+// NativeFixedLines are the out-of-circuit values of [FixedLines]. The entries
+// not used by the Miller loop are zero.
+type NativeFixedLines [4][67]bn254.E2
+
+// PrecomputeLines computes out of circuit the lines of the Miller loop for the
+// fixed G2 argument Q. Q must be in G2 and not the point at infinity.
+func PrecomputeLines(Q bn254.G2Affine) FixedLines {
+	nativeLines := PrecomputeNativeLines(Q)
+	var lines FixedLines
+	for k := range lines {
+		for i := range lines[k] {
+			lines[k][i] = FromE2(&nativeLines[k][i])
+		}
+	}
+	return lines
+}
+
+// PrecomputeNativeLines is the same as [PrecomputeLines] but returns the
+// lines as native 𝔽p² elements.
+func PrecomputeNativeLines(Q bn254.G2Affine) NativeFixedLines {
+	var lines NativeFixedLines
+	var l1, l2 nativeLine
+
+	var QNeg bn254.G2Affine
+	QNeg.Neg(&Q)
+	Qacc := Q
+	for i := 64; i >= 0; i-- {
+		switch loopCounter[i] {
+		case 0:
+			l1 = nativeDoubleStep(&Qacc)
+		case 1:
+			l1 = nativeDoubleStep(&Qacc)
+			l2 = nativeAddStep(&Qacc, &Q)
+		case -1:
+			l1 = nativeDoubleStep(&Qacc)
+			l2 = nativeAddStep(&Qacc, &QNeg)
+		}
+		lines[0][i], lines[1][i] = l1.R0, l1.R1
+		if loopCounter[i] != 0 {
+			lines[2][i], lines[3][i] = l2.R0, l2.R1
+		}
+	}
+
+	// Q1 = π(Q)
+	var Q1, Q2 bn254.G2Affine
+	Q1.X.Conjugate(&Q.X).MulByNonResidue1Power2(&Q1.X)
+	Q1.Y.Conjugate(&Q.Y).MulByNonResidue1Power3(&Q1.Y)
+	// Q2 = -π²(Q)
+	Q2.X.MulByNonResidue2Power2(&Q.X)
+	Q2.Y.MulByNonResidue2Power3(&Q.Y).Neg(&Q2.Y)
+
+	l1 = nativeAddStep(&Qacc, &Q1)
+	lines[0][65], lines[1][65] = l1.R0, l1.R1
+	l1 = nativeLineCompute(&Qacc, &Q2)
+	lines[0][66], lines[1][66] = l1.R0, l1.R1
+
+	return lines
+}
+
+// nativeLine is the out-of-circuit counterpart of lineEvaluation.
+type nativeLine struct {
+	R0, R1 bn254.E2
+}
+
+// newNativeLine returns the line of slope λ passing through p:
+// R0 = -λ and R1 = λ⋅p.x - p.y.
+func newNativeLine(λ *bn254.E2, p *bn254.G2Affine) nativeLine {
+	var l nativeLine
+	l.R0.Neg(λ)
+	l.R1.Mul(λ, &p.X).Sub(&l.R1, &p.Y)
+	return l
+}
+
+// nativeDoubleStep is the out-of-circuit counterpart of
+// [Pairing.doubleStep]. It sets p to 2p and returns the tangent line.
+func nativeDoubleStep(p *bn254.G2Affine) nativeLine {
+	// λ = 3x²/2y
+	var n, d, λ bn254.E2
+	n.Square(&p.X)
+	d.Double(&n)
+	n.Add(&n, &d)
+	d.Double(&p.Y)
+	λ.Div(&n, &d)
+	l := newNativeLine(&λ, p)
+
+	// xr = λ²-2x, yr = λ(x-xr)-y
+	var xr, yr bn254.E2
+	xr.Square(&λ).Sub(&xr, &p.X).Sub(&xr, &p.X)
+	yr.Sub(&p.X, &xr).Mul(&yr, &λ).Sub(&yr, &p.Y)
+	p.X, p.Y = xr, yr
+
+	return l
+}
+
+// nativeAddStep is the out-of-circuit counterpart of [Pairing.addStep]. It
+// sets p1 to p1+p2 and returns the line through p1 and p2.
+func nativeAddStep(p1, p2 *bn254.G2Affine) nativeLine {
+	// λ = (y2-y1)/(x2-x1)
+	var n, d, λ bn254.E2
+	n.Sub(&p2.Y, &p1.Y)
+	d.Sub(&p2.X, &p1.X)
+	λ.Div(&n, &d)
+	l := newNativeLine(&λ, p1)
+
+	// xr = λ²-x1-x2, yr = λ(x1-xr)-y1
+	var xr, yr bn254.E2
+	xr.Square(&λ).Sub(&xr, &p1.X).Sub(&xr, &p2.X)
+	yr.Sub(&p1.X, &xr).Mul(&yr, &λ).Sub(&yr, &p1.Y)
+	p1.X, p1.Y = xr, yr
+
+	return l
+}
+
+// nativeLineCompute is the out-of-circuit counterpart of
+// [Pairing.lineCompute]. It returns the line through p1 and p2.
+func nativeLineCompute(p1, p2 *bn254.G2Affine) nativeLine {
+	// λ = (y2-y1)/(x2-x1)
+	var n, d, λ bn254.E2
+	n.Sub(&p2.Y, &p1.Y)
+	d.Sub(&p2.X, &p1.X)
+	λ.Div(&n, &d)
+	return newNativeLine(&λ, p1)
+}
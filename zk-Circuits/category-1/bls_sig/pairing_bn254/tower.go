@@ -259,9 +259,22 @@ func (e Ext2) Zero() *E2 {
 	}
 }
 
+// isZeroFp returns 1 if x = 0 mod p and 0 otherwise. It asserts that the
+// reduced value of x is less than p. Contrary to [emulated.Field.IsZero], which
+// only checks the first limb, all the limbs are taken into account.
+func (e Ext2) isZeroFp(x *baseEl) frontend.Variable {
+	cx := e.fp.Reduce(x)
+	e.fp.AssertIsInRange(cx)
+	res := e.api.IsZero(cx.Limbs[0])
+	for i := 1; i < len(cx.Limbs); i++ {
+		res = e.api.And(res, e.api.IsZero(cx.Limbs[i]))
+	}
+	return res
+}
+
 func (e Ext2) IsZero(z *E2) frontend.Variable {
-	a0 := e.fp.IsZero(&z.A0)
-	a1 := e.fp.IsZero(&z.A1)
+	a0 := e.isZeroFp(&z.A0)
+	a1 := e.isZeroFp(&z.A1)
 	return e.api.And(a0, a1)
 }
 
@@ -876,6 +889,57 @@ func (e Ext12) Lookup2(s1, s2 frontend.Variable, a, b, c, d *E12) *E12 {
 	return &E12{C0: *c0, C1: *c1}
 }
 
+// Frobenius raises x ∈ E12 to the modulus p
+func (e Ext12) Frobenius(x *E12) *E12 {
+	t0 := e.Ext2.Conjugate(&x.C0.B0)
+	t1 := e.Ext2.Conjugate(&x.C0.B1)
+	t2 := e.Ext2.Conjugate(&x.C0.B2)
+	t3 := e.Ext2.Conjugate(&x.C1.B0)
+	t4 := e.Ext2.Conjugate(&x.C1.B1)
+	t5 := e.Ext2.Conjugate(&x.C1.B2)
+	t1 = e.Ext2.MulByNonResidue1Power2(t1)
+	t2 = e.Ext2.MulByNonResidue1Power4(t2)
+	t3 = e.Ext2.MulByNonResidue1Power1(t3)
+	t4 = e.Ext2.MulByNonResidue1Power3(t4)
+	t5 = e.Ext2.MulByNonResidue1Power5(t5)
+	return &E12{
+		C0: E6{B0: *t0, B1: *t1, B2: *t2},
+		C1: E6{B0: *t3, B1: *t4, B2: *t5},
+	}
+}
+
+// FrobeniusSquare raises x ∈ E12 to the square modulus p^2
+func (e Ext12) FrobeniusSquare(x *E12) *E12 {
+	t1 := e.Ext2.MulByNonResidue2Power2(&x.C0.B1)
+	t2 := e.Ext2.MulByNonResidue2Power4(&x.C0.B2)
+	t3 := e.Ext2.MulByNonResidue2Power1(&x.C1.B0)
+	t4 := e.Ext2.MulByNonResidue2Power3(&x.C1.B1)
+	t5 := e.Ext2.MulByNonResidue2Power5(&x.C1.B2)
+	return &E12{
+		C0: E6{B0: x.C0.B0, B1: *t1, B2: *t2},
+		C1: E6{B0: *t3, B1: *t4, B2: *t5},
+	}
+}
+
+// FrobeniusCube raises x ∈ E12 to the cube modulus p^3
+func (e Ext12) FrobeniusCube(x *E12) *E12 {
+	t0 := e.Ext2.Conjugate(&x.C0.B0)
+	t1 := e.Ext2.Conjugate(&x.C0.B1)
+	t2 := e.Ext2.Conjugate(&x.C0.B2)
+	t3 := e.Ext2.Conjugate(&x.C1.B0)
+	t4 := e.Ext2.Conjugate(&x.C1.B1)
+	t5 := e.Ext2.Conjugate(&x.C1.B2)
+	t1 = e.Ext2.MulByNonResidue3Power2(t1)
+	t2 = e.Ext2.MulByNonResidue3Power4(t2)
+	t3 = e.Ext2.MulByNonResidue3Power1(t3)
+	t4 = e.Ext2.MulByNonResidue3Power3(t4)
+	t5 = e.Ext2.MulByNonResidue3Power5(t5)
+	return &E12{
+		C0: E6{B0: *t0, B1: *t1, B2: *t2},
+		C1: E6{B0: *t3, B1: *t4, B2: *t5},
+	}
+}
+
 func (e Ext12) nSquareTorus(z *E6, n int) *E6 {
 	for i := 0; i < n; i++ {
 		z = e.SquareTorus(z)
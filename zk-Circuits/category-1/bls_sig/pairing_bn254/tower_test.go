@@ -994,6 +994,93 @@ func TestFp12MulBy034(t *testing.T) {
 }
 
 // Torus-based arithmetic
+type e12Frobenius struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12Frobenius) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.Frobenius(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestFp12Frobenius(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bn254.E12
+	_, _ = a.SetRandom()
+	c.Frobenius(&a)
+
+	witness := e12Frobenius{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12Frobenius{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e12FrobeniusSquare struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12FrobeniusSquare) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.FrobeniusSquare(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestFp12FrobeniusSquare(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bn254.E12
+	_, _ = a.SetRandom()
+	c.FrobeniusSquare(&a)
+
+	witness := e12FrobeniusSquare{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12FrobeniusSquare{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type e12FrobeniusCube struct {
+	A E12
+	C E12 `gnark:",public"`
+}
+
+func (circuit *e12FrobeniusCube) Define(api frontend.API) error {
+	e := NewExt12(api)
+	expected := e.FrobeniusCube(&circuit.A)
+	e.AssertIsEqual(expected, &circuit.C)
+	return nil
+}
+
+func TestFp12FrobeniusCube(t *testing.T) {
+
+	assert := test.NewAssert(t)
+	// witness values
+	var a, c bn254.E12
+	_, _ = a.SetRandom()
+	c.FrobeniusCube(&a)
+
+	witness := e12FrobeniusCube{
+		A: FromE12(&a),
+		C: FromE12(&c),
+	}
+
+	err := test.IsSolved(&e12FrobeniusCube{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
 type torusCompress struct {
 	A E12
 	C E6 `gnark:",public"`
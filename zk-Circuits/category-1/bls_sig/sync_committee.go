@@ -0,0 +1,42 @@
+package bls_sig
+
+import (
+	"github.com/consensys/gnark/frontend"
+	bls12 "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bls12381"
+)
+
+// SyncCommitteeSize is the number of validators in an Ethereum sync committee
+// (SYNC_COMMITTEE_SIZE in the Altair specification).
+const SyncCommitteeSize = 512
+
+// VerifySyncCommittee verifies the aggregate signature sig of an Ethereum
+// sync committee on the message whose hash to G2 is hash. The i-th public key
+// pks[i] participates if bits[i] = 1 and at least threshold of them must
+// participate.
+//
+// The participating public keys are summed with complete affine additions, so
+// that repeated public keys are handled. The aggregate public key must not be
+// the point at infinity. The signature is then checked with
+// [BLS_bls12.VerifyBLS_bls12_v1], which also performs the subgroup checks of
+// the aggregate public key and of the signature if [WithSubgroupChecks] is
+// set.
+//
+// N.B: The committee public keys are trusted, i.e. it is up to the caller to
+// bind them to the beacon state (e.g. by recomputing the committee root).
+func (bls BLS_bls12) VerifySyncCommittee(pks *[SyncCommitteeSize]bls12.G1Affine, bits *[SyncCommitteeSize]frontend.Variable, threshold frontend.Variable, sig, hash *bls12.G2Affine) error {
+	aggPK := bls.pr.InfinityG1()
+	var count frontend.Variable = 0
+	for i := range pks {
+		bls.api.AssertIsBoolean(bits[i])
+		count = bls.api.Add(count, bits[i])
+		pk := bls.pr.SelectG1(bits[i], &pks[i], bls.pr.InfinityG1())
+		aggPK = bls.pr.AddG1Complete(aggPK, pk)
+	}
+
+	// popcount(bits) ≥ threshold
+	bls.api.AssertIsLessOrEqual(threshold, count)
+	// the aggregate public key is not the point at infinity
+	bls.api.AssertIsEqual(bls.pr.IsInfinityG1(aggPK), 0)
+
+	return bls.VerifyBLS_bls12_v1(aggPK, sig, hash)
+}
@@ -0,0 +1,129 @@
+package bls_sig
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/profile"
+	"github.com/consensys/gnark/test"
+	bls12 "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bls12381"
+)
+
+type syncCommitteeCircuit struct {
+	PKs       [SyncCommitteeSize]bls12.G1Affine
+	Bits      [SyncCommitteeSize]frontend.Variable
+	Threshold frontend.Variable `gnark:",public"`
+	Sig       bls12.G2Affine
+	HM        bls12.G2Affine
+}
+
+func (c *syncCommitteeCircuit) Define(api frontend.API) error {
+	bls, err := NewBLS_bls12(api, WithSubgroupChecks())
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+
+	return bls.VerifySyncCommittee(&c.PKs, &c.Bits, c.Threshold, &c.Sig, &c.HM)
+}
+
+// randomSecrets returns SyncCommitteeSize random secret keys.
+func randomSecrets(assert *test.Assert) []*big.Int {
+	secrets := make([]*big.Int, SyncCommitteeSize)
+	for i := range secrets {
+		var err error
+		secrets[i], err = rand.Int(rand.Reader, bls12381.ID.ScalarField())
+		assert.NoError(err)
+	}
+	return secrets
+}
+
+// syncCommitteeWitness returns a witness where the validators i with secret
+// key secrets[i] such that participates(i) sign msg.
+func syncCommitteeWitness(assert *test.Assert, msg []byte, secrets []*big.Int, participates func(i int) bool) *syncCommitteeCircuit {
+	HM, err := bls12381.HashToG2(msg, []byte(dst_bls12))
+	assert.NoError(err)
+
+	var witness syncCommitteeCircuit
+	witness.HM = bls12.NewG2Affine(HM)
+	// σ = [∑ᵢ skᵢ]H(m) for the participating validators
+	aggSecret := new(big.Int)
+	for i, secret := range secrets {
+		var PK bls12381.G1Affine
+		PK.ScalarMultiplicationBase(secret)
+		witness.PKs[i] = bls12.NewG1Affine(PK)
+		witness.Bits[i] = 0
+		if participates(i) {
+			witness.Bits[i] = 1
+			aggSecret.Add(aggSecret, secret)
+		}
+	}
+	aggSecret.Mod(aggSecret, bls12381.ID.ScalarField())
+	var Sig bls12381.G2Affine
+	Sig.ScalarMultiplication(&HM, aggSecret)
+	witness.Sig = bls12.NewG2Affine(Sig)
+	return &witness
+}
+
+func TestBLS_bls12_VerifySyncCommittee(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("beacon block root")
+	secrets := randomSecrets(assert)
+	// 2/3 supermajority
+	const threshold = 2 * SyncCommitteeSize / 3
+
+	witness := syncCommitteeWitness(assert, msg, secrets, func(i int) bool { return i%4 != 3 })
+	witness.Threshold = threshold
+	err := test.IsSolved(&syncCommitteeCircuit{}, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the signature does not verify if a non-participating validator is
+	// counted
+	witness.Bits[3] = 1
+	err = test.IsSolved(&syncCommitteeCircuit{}, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// not enough validators participate
+	witness = syncCommitteeWitness(assert, msg, secrets, func(i int) bool { return i%2 == 0 })
+	witness.Threshold = threshold
+	err = test.IsSolved(&syncCommitteeCircuit{}, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+	witness.Threshold = SyncCommitteeSize / 2
+	err = test.IsSolved(&syncCommitteeCircuit{}, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestBLS_bls12_VerifySyncCommitteeEdgeCases(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("beacon block root")
+	secrets := randomSecrets(assert)
+	// pk₁ = pk₀ so that the partial sum is doubled, and pk₃ = -pk₂ so that the
+	// partial sum goes through the point at infinity.
+	secrets[1] = secrets[0]
+	secrets[3] = new(big.Int).Sub(bls12381.ID.ScalarField(), secrets[2])
+
+	witness := syncCommitteeWitness(assert, msg, secrets, func(i int) bool { return i < 5 })
+	witness.Threshold = 5
+	err := test.IsSolved(&syncCommitteeCircuit{}, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the aggregate public key is the point at infinity
+	witness = syncCommitteeWitness(assert, msg, secrets, func(i int) bool { return i == 2 || i == 3 })
+	witness.Threshold = 1
+	err = test.IsSolved(&syncCommitteeCircuit{}, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+// bench
+func BenchmarkSyncCommittee(b *testing.B) {
+	var c syncCommitteeCircuit
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  Ethereum sync committee (512 validators) verifier on BLS12-381 in a BN254 R1CS circuit: ", p.NbConstraints())
+}
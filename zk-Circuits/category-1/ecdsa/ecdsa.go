@@ -1,6 +1,9 @@
 package ecdsa
 
 import (
+	"fmt"
+	"math/big"
+
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/math/emulated"
 )
@@ -13,38 +16,203 @@ type Signature[Scalar emulated.FieldParams] struct {
 // PublicKey represents the public key to verify the signature for.
 type PublicKey[Base, Scalar emulated.FieldParams] AffinePoint[Base]
 
+// VerifyOptions defines the additional checks performed by
+// [PublicKey.VerifyWithOptions]. The zero value performs none of them and
+// corresponds to [PublicKey.Verify].
+type VerifyOptions struct {
+	// StrictRange asserts that r and s are in [1, n-1], where n is the group
+	// order, i.e. that the signature is given in its canonical form.
+	StrictRange bool
+	// LowS asserts that s ≤ (n-1)/2 to prevent signature malleability, as
+	// required by Ethereum (EIP-2) and Bitcoin (BIP-62).
+	LowS bool
+	// CheckPublicKey asserts that the public key is on the curve and in the
+	// subgroup generated by the base point. As b is nonzero for the supported
+	// curves, it also excludes (0,0). Only BLS12-381 has a nontrivial
+	// cofactor, for which it adds the cost of a subgroup check.
+	CheckPublicKey bool
+	// AllowZeroMessage handles a message msg = 0 mod n, for which [msg/s]g is
+	// the point at infinity. Otherwise the message is assumed to be nonzero.
+	AllowZeroMessage bool
+}
+
 // Verify asserts that the signature sig verifies for the message msg and public
-// key pk. The curve parameters params define the elliptic curve.
+// key pk. The curve parameters params define the elliptic curve. It returns an
+// error if initialising the curve fails.
 //
 // We assume that the message msg is already hashed to the scalar field.
-func (pk PublicKey[T, S]) Verify(api frontend.API, params CurveParams, msg *emulated.Element[S], sig *Signature[S]) {
+func (pk PublicKey[T, S]) Verify(api frontend.API, params CurveParams, msg *emulated.Element[S], sig *Signature[S]) error {
+	return pk.VerifyWithOptions(api, params, msg, sig, VerifyOptions{})
+}
+
+// IsValid returns 1 if the signature sig verifies for the message msg and
+// public key pk, and 0 otherwise. The curve parameters params define the
+// elliptic curve. Contrary to [PublicKey.Verify], it doesn't assert the
+// validity, so that it can be used to count valid signatures or to combine
+// them in threshold logic. It returns an error if initialising the curve
+// fails.
+//
+// The circuit is satisfiable whatever the signature, the message and the
+// public key: signatures with r or s outside of [1, n-1], where n is the group
+// order, public keys which are not on the curve and messages msg = 0 mod n
+// are handled and the first two are invalid. As in [PublicKey.Verify], we
+// assume that the message msg is already hashed to the scalar field. For
+// BLS12-381, which has a nontrivial cofactor, pk is assumed to be in the
+// subgroup generated by the base point if it is on the curve.
+func (pk PublicKey[T, S]) IsValid(api frontend.API, params CurveParams, msg *emulated.Element[S], sig *Signature[S]) (frontend.Variable, error) {
 	cr, err := New[T, S](api, params)
 	if err != nil {
-		// TODO: softer handling.
-		panic(err)
+		return nil, fmt.Errorf("new curve: %w", err)
 	}
-	scalarApi, err := emulated.NewField[S](api)
+	scalarApi := cr.scalarApi
+
+	// if r or s is not in [1, n-1], or if pk is not on the curve, we assign
+	// dummy values (1 and the base point) and continue so that the inversion
+	// and the scalar multiplications are well defined.
+	rInRange := isInRange(api, scalarApi, &sig.R)
+	sInRange := isInRange(api, scalarApi, &sig.S)
+	r := scalarApi.Select(rInRange, &sig.R, scalarApi.One())
+	s := scalarApi.Select(sInRange, &sig.S, scalarApi.One())
+	pkpt := AffinePoint[T](pk)
+	pkOnCurve := cr.isOnCurve(&pkpt)
+	dummyPk := PublicKey[T, S](*cr.Select(pkOnCurve, &pkpt, cr.Generator()))
+
+	isEqual := dummyPk.isValid(api, cr, msg, r, s, true, true)
+	return api.And(isEqual, api.And(pkOnCurve, api.And(rInRange, sInRange))), nil
+}
+
+// VerifyWithOptions asserts that the signature sig verifies for the message msg
+// and public key pk, and additionally performs the checks enabled in opts. The
+// curve parameters params define the elliptic curve. It returns an error if
+// initialising the curve fails.
+//
+// We assume that the message msg is already hashed to the scalar field.
+func (pk PublicKey[T, S]) VerifyWithOptions(api frontend.API, params CurveParams, msg *emulated.Element[S], sig *Signature[S], opts VerifyOptions) error {
+	cr, err := New[T, S](api, params)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("new curve: %w", err)
 	}
-	baseApi, err := emulated.NewField[T](api)
-	if err != nil {
-		panic(err)
+	scalarApi := cr.scalarApi
+
+	var st S
+	if opts.StrictRange {
+		scalarApi.AssertIsInRange(&sig.R)
+		scalarApi.AssertIsInRange(&sig.S)
+		api.AssertIsEqual(isZero(api, scalarApi, &sig.R), 0)
+		api.AssertIsEqual(isZero(api, scalarApi, &sig.S), 0)
 	}
+	if opts.LowS {
+		halfN := new(big.Int).Rsh(st.Modulus(), 1)
+		scalarApi.AssertIsLessOrEqual(&sig.S, scalarApi.NewElement(halfN))
+	}
+	if opts.CheckPublicKey {
+		pkpt := AffinePoint[T](pk)
+		if err := cr.assertIsInSubgroup(&pkpt); err != nil {
+			return fmt.Errorf("subgroup check: %w", err)
+		}
+	}
+
+	api.AssertIsEqual(pk.isValid(api, cr, msg, &sig.R, &sig.S, opts.AllowZeroMessage, false), 1)
+	return nil
+}
+
+// isValid returns 1 if r = q.X mod n, where q = [msg/s]g + [r/s]pk, and 0
+// otherwise. s must be nonzero. If allowZeroMessage is set, msg = 0 is
+// handled (see [VerifyOptions]). If complete is set, [msg/s]g may be equal to
+// ±[r/s]pk (see [Curve.jointScalarMulBase]).
+func (pk PublicKey[T, S]) isValid(api frontend.API, cr *Curve[T, S], msg, r, s *emulated.Element[S], allowZeroMessage, complete bool) frontend.Variable {
+	scalarApi := cr.scalarApi
+	baseApi := cr.baseApi
 	pkpt := AffinePoint[T](pk)
-	sInv := scalarApi.Inverse(&sig.S)
+
+	sInv := scalarApi.Inverse(s)
 	msInv := scalarApi.MulMod(msg, sInv)
-	rsInv := scalarApi.MulMod(&sig.R, sInv)
+	rsInv := scalarApi.MulMod(r, sInv)
 
-	// q = [rsInv]pkpt + [msInv]g
-	q := cr.jointScalarMulBase(&pkpt, rsInv, msInv)
+	var q *AffinePoint[T]
+	if allowZeroMessage {
+		// jointScalarMulBase requires a nonzero scalar for g. When msg = 0,
+		// we compute q = [rsInv]pkpt + [3]g instead and subtract [3]g. We
+		// don't use 1 as the algorithm would then compute g-g.
+		msgIsZero := isZero(api, scalarApi, msInv)
+		msInv = scalarApi.Select(msgIsZero, scalarApi.NewElement(3), msInv)
+		q = cr.jointScalarMulBase(&pkpt, rsInv, msInv, complete)
+		// gm[0] = 3g
+		q = cr.Select(msgIsZero, cr.AddUnified(q, cr.Neg(&cr.GeneratorMultiples()[0])), q)
+	} else {
+		// q = [rsInv]pkpt + [msInv]g
+		q = cr.jointScalarMulBase(&pkpt, rsInv, msInv, complete)
+	}
 	qx := baseApi.Reduce(&q.X)
 	qxBits := baseApi.ToBits(qx)
-	rbits := scalarApi.ToBits(&sig.R)
-	if len(rbits) != len(qxBits) {
-		panic("non-equal lengths")
+	rbits := scalarApi.ToBits(r)
+	if len(rbits) == len(qxBits) {
+		// r = q.X if and only if all the bits are equal.
+		var diff frontend.Variable = 0
+		for i := range rbits {
+			diff = api.Add(diff, api.Xor(rbits[i], qxBits[i]))
+		}
+		return api.IsZero(diff)
 	}
-	for i := range rbits {
-		api.AssertIsEqual(rbits[i], qxBits[i])
+	// the base field is larger than the scalar field (e.g. BLS12-381 G1), so
+	// q.X has to be reduced modulo the group order before comparing with r.
+	return isZero(api, scalarApi, scalarApi.Sub(r, toScalar(scalarApi, qxBits, len(rbits))))
+}
+
+// isZero returns 1 if a is zero modulo the field modulus and 0 otherwise. It
+// asserts that the reduced value of a is less than the modulus. Contrary to
+// [emulated.Field.IsZero], which only checks the first limb, all the limbs are
+// taken into account.
+func isZero[T emulated.FieldParams](api frontend.API, f *emulated.Field[T], a *emulated.Element[T]) frontend.Variable {
+	ca := f.Reduce(a)
+	f.AssertIsInRange(ca)
+	res := api.IsZero(ca.Limbs[0])
+	for i := 1; i < len(ca.Limbs); i++ {
+		res = api.And(res, api.IsZero(ca.Limbs[i]))
+	}
+	return res
+}
+
+// isInRange returns 1 if 0 < a < q, where q is the field modulus, and 0
+// otherwise. Contrary to [emulated.Field.AssertIsInRange], it doesn't assert
+// it, so that a non-reduced a, e.g. given in the witness, is handled.
+func isInRange[T emulated.FieldParams](api frontend.API, f *emulated.Field[T], a *emulated.Element[T]) frontend.Variable {
+	var fp T
+	q := fp.Modulus()
+	bs := f.ToBits(a)
+	// from the most significant bit, lt is 1 once a bit of a is less than the
+	// one of q, and eq is 1 as long as the bits are equal
+	var lt, eq, sum frontend.Variable = 0, 1, 0
+	for i := len(bs) - 1; i >= 0; i-- {
+		if q.Bit(i) == 1 {
+			lt = api.Add(lt, api.Mul(eq, api.Sub(1, bs[i])))
+			eq = api.Mul(eq, bs[i])
+		} else {
+			eq = api.Mul(eq, api.Sub(1, bs[i]))
+		}
+		sum = api.Add(sum, bs[i])
+	}
+	return api.And(lt, api.Sub(1, api.IsZero(sum)))
+}
+
+// toScalar returns the element of the scalar field given by the
+// little-endian bits bs. The bits are split in chunks of width w and
+// recombined modulo the scalar field order.
+func toScalar[S emulated.FieldParams](scalarApi *emulated.Field[S], bs []frontend.Variable, w int) *emulated.Element[S] {
+	var st S
+	res := scalarApi.FromBits(bs[:w]...)
+	shift := new(big.Int)
+	for i := w; i < len(bs); i += w {
+		end := i + w
+		if end > len(bs) {
+			end = len(bs)
+		}
+		// shift = 2^i mod r
+		shift.Lsh(big.NewInt(1), uint(i))
+		shift.Mod(shift, st.Modulus())
+		chunk := scalarApi.FromBits(bs[i:end]...)
+		chunk = scalarApi.MulMod(chunk, scalarApi.NewElement(new(big.Int).Set(shift)))
+		res = scalarApi.Add(res, chunk)
 	}
+	return res
 }
@@ -1,13 +1,23 @@
 package ecdsa
 
 import (
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
 
+	stdecdsa "crypto/ecdsa"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bls12381ecdsa "github.com/consensys/gnark-crypto/ecc/bls12-381/ecdsa"
+	bn254ecdsa "github.com/consensys/gnark-crypto/ecc/bn254/ecdsa"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
 	"github.com/consensys/gnark-crypto/ecc/secp256k1/ecdsa"
+	secp256k1fr "github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/profile"
@@ -22,8 +32,7 @@ type EcdsaCircuit[T, S emulated.FieldParams] struct {
 }
 
 func (c *EcdsaCircuit[T, S]) Define(api frontend.API) error {
-	c.Pub.Verify(api, GetCurveParams[T](), &c.Msg, &c.Sig)
-	return nil
+	return c.Pub.Verify(api, GetCurveParams[T](), &c.Msg, &c.Sig)
 }
 
 func TestEcdsaPreHashed(t *testing.T) {
@@ -117,6 +126,502 @@ func TestEcdsaSHA256(t *testing.T) {
 	assert.NoError(err)
 }
 
+// newEcdsaWitness returns the assignment of [EcdsaCircuit] for the signature
+// (r, s) of hash under the public key (x, y).
+func newEcdsaWitness[T, S emulated.FieldParams](r, s, hash, x, y *big.Int) frontend.Circuit {
+	return &EcdsaCircuit[T, S]{
+		Sig: Signature[S]{
+			R: emulated.ValueOf[S](r),
+			S: emulated.ValueOf[S](s),
+		},
+		Msg: emulated.ValueOf[S](hash),
+		Pub: PublicKey[T, S]{
+			X: emulated.ValueOf[T](x),
+			Y: emulated.ValueOf[T](y),
+		},
+	}
+}
+
+func TestEcdsaCurves(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// signStdlib signs msg with a fresh key of curve, hashed with h.
+	signStdlib := func(curve elliptic.Curve, msg []byte, h func([]byte) []byte) (r, s, hash, x, y *big.Int, err error) {
+		privKey, err := stdecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			return
+		}
+		hramBin := h(msg)
+		if r, s, err = stdecdsa.Sign(rand.Reader, privKey, hramBin); err != nil {
+			return
+		}
+		if !stdecdsa.Verify(&privKey.PublicKey, hramBin, r, s) {
+			err = errors.New("can't verify signature")
+			return
+		}
+		hash = new(big.Int).SetBytes(hramBin)
+		hash.Mod(hash, curve.Params().N)
+		return r, s, hash, privKey.PublicKey.X, privKey.PublicKey.Y, nil
+	}
+
+	for _, tc := range []struct {
+		name    string
+		circuit frontend.Circuit
+		witness func(r, s, hash, x, y *big.Int) frontend.Circuit
+		sign    func(msg []byte) (r, s, hash, x, y *big.Int, err error)
+	}{
+		{
+			name:    "P-256",
+			circuit: &EcdsaCircuit[P256Fp, P256Fr]{},
+			witness: newEcdsaWitness[P256Fp, P256Fr],
+			sign: func(msg []byte) (r, s, hash, x, y *big.Int, err error) {
+				return signStdlib(elliptic.P256(), msg, func(m []byte) []byte {
+					h := sha256.Sum256(m)
+					return h[:]
+				})
+			},
+		},
+		{
+			name:    "P-384",
+			circuit: &EcdsaCircuit[P384Fp, P384Fr]{},
+			witness: newEcdsaWitness[P384Fp, P384Fr],
+			sign: func(msg []byte) (r, s, hash, x, y *big.Int, err error) {
+				return signStdlib(elliptic.P384(), msg, func(m []byte) []byte {
+					h := sha512.Sum384(m)
+					return h[:]
+				})
+			},
+		},
+		{
+			name:    "BN254",
+			circuit: &EcdsaCircuit[emulated.BN254Fp, emulated.BN254Fr]{},
+			witness: newEcdsaWitness[emulated.BN254Fp, emulated.BN254Fr],
+			sign: func(msg []byte) (r, s, hash, x, y *big.Int, err error) {
+				privKey, err := bn254ecdsa.GenerateKey(rand.Reader)
+				if err != nil {
+					return
+				}
+				md := sha256.New()
+				sigBin, err := privKey.Sign(msg, md)
+				if err != nil {
+					return
+				}
+				if ok, _ := privKey.PublicKey.Verify(sigBin, msg, md); !ok {
+					err = errors.New("can't verify signature")
+					return
+				}
+				var sig bn254ecdsa.Signature
+				if _, err = sig.SetBytes(sigBin); err != nil {
+					return
+				}
+				md.Reset()
+				md.Write(msg)
+				return new(big.Int).SetBytes(sig.R[:]), new(big.Int).SetBytes(sig.S[:]),
+					bn254ecdsa.HashToInt(md.Sum(nil)),
+					privKey.PublicKey.A.X.BigInt(new(big.Int)), privKey.PublicKey.A.Y.BigInt(new(big.Int)), nil
+			},
+		},
+		{
+			name:    "BLS12-381",
+			circuit: &EcdsaCircuit[emulated.BLS12381Fp, BLS12381Fr]{},
+			witness: newEcdsaWitness[emulated.BLS12381Fp, BLS12381Fr],
+			sign: func(msg []byte) (r, s, hash, x, y *big.Int, err error) {
+				privKey, err := bls12381ecdsa.GenerateKey(rand.Reader)
+				if err != nil {
+					return
+				}
+				md := sha256.New()
+				sigBin, err := privKey.Sign(msg, md)
+				if err != nil {
+					return
+				}
+				if ok, _ := privKey.PublicKey.Verify(sigBin, msg, md); !ok {
+					err = errors.New("can't verify signature")
+					return
+				}
+				var sig bls12381ecdsa.Signature
+				if _, err = sig.SetBytes(sigBin); err != nil {
+					return
+				}
+				md.Reset()
+				md.Write(msg)
+				return new(big.Int).SetBytes(sig.R[:]), new(big.Int).SetBytes(sig.S[:]),
+					bls12381ecdsa.HashToInt(md.Sum(nil)),
+					privKey.PublicKey.A.X.BigInt(new(big.Int)), privKey.PublicKey.A.Y.BigInt(new(big.Int)), nil
+			},
+		},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			r, s, hash, x, y, err := tc.sign([]byte("testing ECDSA (" + tc.name + ")"))
+			assert.NoError(err)
+			err = test.IsSolved(tc.circuit, tc.witness(r, s, hash, x, y), testCurve.ScalarField())
+			assert.NoError(err)
+		}, tc.name)
+	}
+}
+
+type EcdsaOptionsCircuit[T, S emulated.FieldParams] struct {
+	Sig Signature[S]
+	Msg emulated.Element[S]
+	Pub PublicKey[T, S]
+
+	opts VerifyOptions
+}
+
+func (c *EcdsaOptionsCircuit[T, S]) Define(api frontend.API) error {
+	return c.Pub.VerifyWithOptions(api, GetCurveParams[T](), &c.Msg, &c.Sig, c.opts)
+}
+
+// signSecp256k1 returns a secp256k1 ECDSA signature (r, s) of hash with the
+// private key sk, with s in its low form.
+func signSecp256k1(sk, hash *big.Int) (r, s *big.Int) {
+	n := secp256k1fr.Modulus()
+	k, _ := rand.Int(rand.Reader, n)
+	var R secp256k1.G1Affine
+	R.ScalarMultiplicationBase(k)
+	r = R.X.BigInt(new(big.Int))
+	r.Mod(r, n)
+	// s = k⁻¹(hash + r⋅sk)
+	s = new(big.Int).Mul(r, sk)
+	s.Add(s, hash)
+	s.Mul(s, new(big.Int).ModInverse(k, n))
+	s.Mod(s, n)
+	if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+		s.Sub(n, s)
+	}
+	return r, s
+}
+
+// nonReduced returns the element of limbs the 64-bit words of v, which can be
+// larger than the modulus. Contrary to [emulated.ValueOf], v is not reduced.
+func nonReduced[T emulated.FieldParams](v *big.Int) emulated.Element[T] {
+	var fp T
+	limbs := make([]frontend.Variable, fp.NbLimbs())
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), fp.BitsPerLimb()), big.NewInt(1))
+	for i := range limbs {
+		limbs[i] = new(big.Int).And(new(big.Int).Rsh(v, uint(i)*fp.BitsPerLimb()), mask)
+	}
+	return emulated.Element[T]{Limbs: limbs}
+}
+
+func TestEcdsaVerifyOptions(t *testing.T) {
+	assert := test.NewAssert(t)
+	n := secp256k1fr.Modulus()
+
+	sk, _ := rand.Int(rand.Reader, n)
+	var pub secp256k1.G1Affine
+	pub.ScalarMultiplicationBase(sk)
+
+	witness := func(r, s, hash *big.Int) *EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr] {
+		return &EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			Sig: Signature[emulated.Secp256k1Fr]{
+				R: emulated.ValueOf[emulated.Secp256k1Fr](r),
+				S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+			},
+			Msg: emulated.ValueOf[emulated.Secp256k1Fr](hash),
+			Pub: PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+				X: emulated.ValueOf[emulated.Secp256k1Fp](pub.X.BigInt(new(big.Int))),
+				Y: emulated.ValueOf[emulated.Secp256k1Fp](pub.Y.BigInt(new(big.Int))),
+			},
+		}
+	}
+	all := VerifyOptions{StrictRange: true, LowS: true, CheckPublicKey: true, AllowZeroMessage: true}
+
+	// low-s signature passes with all the checks
+	hash, _ := rand.Int(rand.Reader, n)
+	r, s := signSecp256k1(sk, hash)
+	err := test.IsSolved(&EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{opts: all}, witness(r, s, hash), testCurve.ScalarField())
+	assert.NoError(err)
+
+	// r and s in [1, n-1]
+	for _, tc := range []struct {
+		name string
+		r, s *big.Int
+	}{
+		{"r = 0", big.NewInt(0), s},
+		{"s = 0", r, big.NewInt(0)},
+		{"r = n", n, s},
+		{"s = n", r, n},
+	} {
+		w := witness(r, s, hash)
+		w.Sig.R = nonReduced[emulated.Secp256k1Fr](tc.r)
+		w.Sig.S = nonReduced[emulated.Secp256k1Fr](tc.s)
+		err = test.IsSolved(&EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{opts: VerifyOptions{StrictRange: true}}, w, testCurve.ScalarField())
+		assert.Error(err, tc.name)
+	}
+	// s+n is a valid but non-canonical s. We choose the message so that s = 5
+	// and s+n fits in the limbs.
+	k, _ := rand.Int(rand.Reader, n)
+	var R secp256k1.G1Affine
+	R.ScalarMultiplicationBase(k)
+	r5 := R.X.BigInt(new(big.Int))
+	r5.Mod(r5, n)
+	s5 := big.NewInt(5)
+	// hash = s⋅k - r⋅sk
+	hash5 := new(big.Int).Mul(s5, k)
+	hash5.Sub(hash5, new(big.Int).Mul(r5, sk))
+	hash5.Mod(hash5, n)
+	w := witness(r5, s5, hash5)
+	err = test.IsSolved(&EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{opts: VerifyOptions{StrictRange: true}}, w, testCurve.ScalarField())
+	assert.NoError(err)
+	w.Sig.S = nonReduced[emulated.Secp256k1Fr](new(big.Int).Add(s5, n))
+	err = test.IsSolved(&EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}, w, testCurve.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(&EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{opts: VerifyOptions{StrictRange: true}}, w, testCurve.ScalarField())
+	assert.Error(err)
+
+	// high-s signature is valid but malleable
+	highS := new(big.Int).Sub(n, s)
+	err = test.IsSolved(&EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}, witness(r, highS, hash), testCurve.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(&EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{opts: VerifyOptions{LowS: true}}, witness(r, highS, hash), testCurve.ScalarField())
+	assert.Error(err)
+
+	// zero message
+	zero := big.NewInt(0)
+	r, s = signSecp256k1(sk, zero)
+	err = test.IsSolved(&EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{opts: all}, witness(r, s, zero), testCurve.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(&EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}, witness(r, s, zero), testCurve.ScalarField())
+	assert.Error(err)
+
+	// public key not on the curve
+	hash, _ = rand.Int(rand.Reader, n)
+	r, s = signSecp256k1(sk, hash)
+	w = witness(r, s, hash)
+	w.Pub.Y = emulated.ValueOf[emulated.Secp256k1Fp](new(big.Int).Add(pub.Y.BigInt(new(big.Int)), big.NewInt(1)))
+	err = test.IsSolved(&EcdsaOptionsCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{opts: VerifyOptions{CheckPublicKey: true}}, w, testCurve.ScalarField())
+	assert.Error(err)
+}
+
+func TestEcdsaCheckPublicKeyBLS12381(t *testing.T) {
+	assert := test.NewAssert(t)
+	p := emulated.BLS12381Fp{}.Modulus()
+	n := BLS12381Fr{}.Modulus()
+
+	witness := func(r, s, hash, x, y *big.Int) *EcdsaOptionsCircuit[emulated.BLS12381Fp, BLS12381Fr] {
+		return &EcdsaOptionsCircuit[emulated.BLS12381Fp, BLS12381Fr]{
+			Sig: Signature[BLS12381Fr]{
+				R: emulated.ValueOf[BLS12381Fr](r),
+				S: emulated.ValueOf[BLS12381Fr](s),
+			},
+			Msg: emulated.ValueOf[BLS12381Fr](hash),
+			Pub: PublicKey[emulated.BLS12381Fp, BLS12381Fr]{
+				X: emulated.ValueOf[emulated.BLS12381Fp](x),
+				Y: emulated.ValueOf[emulated.BLS12381Fp](y),
+			},
+		}
+	}
+	checkPK := VerifyOptions{CheckPublicKey: true}
+
+	// public key in G1
+	privKey, _ := bls12381ecdsa.GenerateKey(rand.Reader)
+	msg := []byte("testing ECDSA (BLS12-381)")
+	md := sha256.New()
+	sigBin, _ := privKey.Sign(msg, md)
+	var sig bls12381ecdsa.Signature
+	sig.SetBytes(sigBin)
+	md.Reset()
+	md.Write(msg)
+	w := witness(new(big.Int).SetBytes(sig.R[:]), new(big.Int).SetBytes(sig.S[:]),
+		bls12381ecdsa.HashToInt(md.Sum(nil)),
+		privKey.PublicKey.A.X.BigInt(new(big.Int)), privKey.PublicKey.A.Y.BigInt(new(big.Int)))
+	err := test.IsSolved(&EcdsaOptionsCircuit[emulated.BLS12381Fp, BLS12381Fr]{opts: checkPK}, w, testCurve.ScalarField())
+	assert.NoError(err)
+
+	// public key Q on the curve but not in G1. The signature is forged for
+	// random u1 = msg/s and u2 = r/s, i.e. r = R.x with R = [u1]g + [u2]Q.
+	var qx, qy *big.Int
+	for qy == nil {
+		qx, _ = rand.Int(rand.Reader, p)
+		rhs := new(big.Int).Exp(qx, big.NewInt(3), p)
+		rhs.Add(rhs, big.NewInt(4))
+		qy = rhs.ModSqrt(rhs, p)
+	}
+	u1, _ := rand.Int(rand.Reader, n)
+	u2, _ := rand.Int(rand.Reader, n)
+	// [u2]Q by double-and-add, as gnark-crypto assumes points in G1
+	zero := big.NewInt(0)
+	ux, uy, ok := qx, qy, true
+	for i := u2.BitLen() - 2; i >= 0; i-- {
+		ux, uy, ok = affineDouble(p, zero, ux, uy)
+		assert.True(ok)
+		if u2.Bit(i) == 1 {
+			ux, uy, ok = affineAdd(p, ux, uy, qx, qy)
+			assert.True(ok)
+		}
+	}
+	var u1g bls12381.G1Affine
+	u1g.ScalarMultiplicationBase(u1)
+	rx, _, ok := affineAdd(p, u1g.X.BigInt(new(big.Int)), u1g.Y.BigInt(new(big.Int)), ux, uy)
+	assert.True(ok)
+	r := rx.Mod(rx, n)
+	// s = r/u2 and msg = u1⋅s
+	s := new(big.Int).ModInverse(u2, n)
+	s.Mul(s, r).Mod(s, n)
+	hash := new(big.Int).Mul(u1, s)
+	hash.Mod(hash, n)
+	w = witness(r, s, hash, qx, qy)
+	err = test.IsSolved(&EcdsaOptionsCircuit[emulated.BLS12381Fp, BLS12381Fr]{}, w, testCurve.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(&EcdsaOptionsCircuit[emulated.BLS12381Fp, BLS12381Fr]{opts: checkPK}, w, testCurve.ScalarField())
+	assert.Error(err)
+}
+
+type EcdsaIsValidCircuit[T, S emulated.FieldParams] struct {
+	Sig      Signature[S]
+	Msg      emulated.Element[S]
+	Pub      PublicKey[T, S]
+	Expected frontend.Variable
+}
+
+func (c *EcdsaIsValidCircuit[T, S]) Define(api frontend.API) error {
+	res, err := c.Pub.IsValid(api, GetCurveParams[T](), &c.Msg, &c.Sig)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(res, c.Expected)
+	return nil
+}
+
+func TestEcdsaIsValid(t *testing.T) {
+	assert := test.NewAssert(t)
+	n := secp256k1fr.Modulus()
+
+	sk, _ := rand.Int(rand.Reader, n)
+	var pub secp256k1.G1Affine
+	pub.ScalarMultiplicationBase(sk)
+	hash, _ := rand.Int(rand.Reader, n)
+	r, s := signSecp256k1(sk, hash)
+	otherHash, _ := rand.Int(rand.Reader, n)
+	// [hash/s]g = ±[r/s]pub if hash = ±r*sk mod n
+	rsk := new(big.Int).Mul(r, sk)
+	rsk.Mod(rsk, n)
+	minusRsk := new(big.Int).Sub(n, rsk)
+	var offCurve secp256k1.G1Affine
+	offCurve.X.Set(&pub.X)
+	offCurve.Y.Double(&pub.Y)
+
+	for _, tc := range []struct {
+		name     string
+		r, s     *big.Int
+		hash     *big.Int
+		pub      secp256k1.G1Affine
+		expected int
+	}{
+		{"valid", r, s, hash, pub, 1},
+		{"wrong message", r, s, otherHash, pub, 0},
+		{"wrong s", r, new(big.Int).Add(s, big.NewInt(1)), hash, pub, 0},
+		{"r = 0", big.NewInt(0), s, hash, pub, 0},
+		{"s = 0", r, big.NewInt(0), hash, pub, 0},
+		{"r = n", n, s, hash, pub, 0},
+		{"s = n", r, n, hash, pub, 0},
+		{"r = n+r", new(big.Int).Add(n, r), s, hash, pub, 0},
+		{"public key off the curve", r, s, hash, offCurve, 0},
+		{"public key (0,0)", r, s, hash, secp256k1.G1Affine{}, 0},
+		{"message = 0", r, s, big.NewInt(0), pub, 0},
+		{"q = 2[hash/s]g", r, s, rsk, pub, 0},
+		{"q = 0", r, s, minusRsk, pub, 0},
+	} {
+		witness := EcdsaIsValidCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			Sig: Signature[emulated.Secp256k1Fr]{
+				R: nonReduced[emulated.Secp256k1Fr](tc.r),
+				S: nonReduced[emulated.Secp256k1Fr](tc.s),
+			},
+			Msg: emulated.ValueOf[emulated.Secp256k1Fr](tc.hash),
+			Pub: PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+				X: emulated.ValueOf[emulated.Secp256k1Fp](tc.pub.X.BigInt(new(big.Int))),
+				Y: emulated.ValueOf[emulated.Secp256k1Fp](tc.pub.Y.BigInt(new(big.Int))),
+			},
+			Expected: tc.expected,
+		}
+		err := test.IsSolved(&EcdsaIsValidCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}, &witness, testCurve.ScalarField())
+		assert.NoError(err, tc.name)
+	}
+}
+
+func TestEcdsaIsValidBLS12381(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// generate parameters
+	privKey, _ := bls12381ecdsa.GenerateKey(rand.Reader)
+
+	// sign
+	msg := []byte("testing ECDSA (BLS12-381)")
+	md := sha256.New()
+	sigBin, _ := privKey.Sign(msg, md)
+
+	// unmarshal signature
+	var sig bls12381ecdsa.Signature
+	sig.SetBytes(sigBin)
+	r, s := new(big.Int), new(big.Int)
+	r.SetBytes(sig.R[:])
+	s.SetBytes(sig.S[:])
+
+	// compute the hash of the message as an integer
+	md.Reset()
+	md.Write(msg)
+	hash := bls12381ecdsa.HashToInt(md.Sum(nil))
+
+	for _, tc := range []struct {
+		hash     *big.Int
+		expected int
+	}{
+		{hash, 1},
+		{new(big.Int).Add(hash, big.NewInt(1)), 0},
+	} {
+		witness := EcdsaIsValidCircuit[emulated.BLS12381Fp, BLS12381Fr]{
+			Sig: Signature[BLS12381Fr]{
+				R: emulated.ValueOf[BLS12381Fr](r),
+				S: emulated.ValueOf[BLS12381Fr](s),
+			},
+			Msg: emulated.ValueOf[BLS12381Fr](tc.hash),
+			Pub: PublicKey[emulated.BLS12381Fp, BLS12381Fr]{
+				X: emulated.ValueOf[emulated.BLS12381Fp](privKey.PublicKey.A.X),
+				Y: emulated.ValueOf[emulated.BLS12381Fp](privKey.PublicKey.A.Y),
+			},
+			Expected: tc.expected,
+		}
+		err := test.IsSolved(&EcdsaIsValidCircuit[emulated.BLS12381Fp, BLS12381Fr]{}, &witness, testCurve.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type isZeroCircuit struct {
+	A        emulated.Element[emulated.Secp256k1Fr]
+	Expected frontend.Variable
+}
+
+func (c *isZeroCircuit) Define(api frontend.API) error {
+	f, err := emulated.NewField[emulated.Secp256k1Fr](api)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(isZero(api, f, &c.A), c.Expected)
+	return nil
+}
+
+func TestIsZero(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, tc := range []struct {
+		a        *big.Int
+		expected int
+	}{
+		{big.NewInt(0), 1},
+		{big.NewInt(1), 0},
+		// the first limb is zero
+		{new(big.Int).Lsh(big.NewInt(1), 64), 0},
+		{new(big.Int).Lsh(big.NewInt(1), 192), 0},
+	} {
+		witness := isZeroCircuit{
+			A:        emulated.ValueOf[emulated.Secp256k1Fr](tc.a),
+			Expected: tc.expected,
+		}
+		err := test.IsSolved(&isZeroCircuit{}, &witness, testCurve.ScalarField())
+		assert.NoError(err, tc.a.String())
+	}
+}
+
 // Example how to verify the signature inside the circuit.
 func ExamplePublicKey_Verify() {
 	api := frontend.API(nil) // provider by the builder
@@ -135,7 +640,9 @@ func ExamplePublicKey_Verify() {
 		Y: emulated.ValueOf[emulated.Secp256k1Fp](puby),
 	}
 	// signature verification assertion is done in-circuit
-	Pub.Verify(api, GetCurveParams[emulated.Secp256k1Fp](), &Msg, &Sig)
+	if err := Pub.Verify(api, GetCurveParams[emulated.Secp256k1Fp](), &Msg, &Sig); err != nil {
+		panic(err)
+	}
 }
 
 // Example how to create a valid signature for secp256k1
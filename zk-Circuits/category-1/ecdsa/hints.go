@@ -0,0 +1,46 @@
+package ecdsa
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in the package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{
+		decompressHint,
+	}
+}
+
+// decompressHint returns the even square root of x³+ax+b. It fails if x³+ax+b
+// is not a square, i.e. if x is not the abscissa of a point of the curve.
+func decompressHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs,
+		func(mod *big.Int, inputs, outputs []*big.Int) error {
+			x, a, b := inputs[0], inputs[1], inputs[2]
+
+			// y² = x³+ax+b
+			y := new(big.Int).Mul(x, x)
+			y.Add(y, a)
+			y.Mul(y, x)
+			y.Add(y, b)
+			y.Mod(y, mod)
+			if y.ModSqrt(y, mod) == nil {
+				return errors.New("x is not on the curve")
+			}
+			if y.Bit(0) == 1 {
+				y.Sub(mod, y)
+			}
+
+			outputs[0].Set(y)
+
+			return nil
+		})
+}
@@ -1,12 +1,61 @@
 package ecdsa
 
 import (
+	"crypto/elliptic"
 	"math/big"
 
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark-crypto/ecc/secp256k1"
 	"github.com/consensys/gnark/std/math/emulated"
 )
 
+// P256Fp provides type parametrisation for the emulated base field of P-256
+// (secp256r1) on 4 limbs of width 64bits.
+type P256Fp struct{}
+
+func (fp P256Fp) NbLimbs() uint     { return 4 }
+func (fp P256Fp) BitsPerLimb() uint { return 64 }
+func (fp P256Fp) IsPrime() bool     { return true }
+func (fp P256Fp) Modulus() *big.Int { return elliptic.P256().Params().P }
+
+// P256Fr provides type parametrisation for the emulated scalar field of P-256
+// (secp256r1) on 4 limbs of width 64bits.
+type P256Fr struct{}
+
+func (fp P256Fr) NbLimbs() uint     { return 4 }
+func (fp P256Fr) BitsPerLimb() uint { return 64 }
+func (fp P256Fr) IsPrime() bool     { return true }
+func (fp P256Fr) Modulus() *big.Int { return elliptic.P256().Params().N }
+
+// P384Fp provides type parametrisation for the emulated base field of P-384
+// (secp384r1) on 6 limbs of width 64bits.
+type P384Fp struct{}
+
+func (fp P384Fp) NbLimbs() uint     { return 6 }
+func (fp P384Fp) BitsPerLimb() uint { return 64 }
+func (fp P384Fp) IsPrime() bool     { return true }
+func (fp P384Fp) Modulus() *big.Int { return elliptic.P384().Params().P }
+
+// P384Fr provides type parametrisation for the emulated scalar field of P-384
+// (secp384r1) on 6 limbs of width 64bits.
+type P384Fr struct{}
+
+func (fp P384Fr) NbLimbs() uint     { return 6 }
+func (fp P384Fr) BitsPerLimb() uint { return 64 }
+func (fp P384Fr) IsPrime() bool     { return true }
+func (fp P384Fr) Modulus() *big.Int { return elliptic.P384().Params().N }
+
+// BLS12381Fr provides type parametrisation for the emulated scalar field of
+// BLS12-381 on 4 limbs of width 64bits.
+type BLS12381Fr struct{}
+
+func (fp BLS12381Fr) NbLimbs() uint     { return 4 }
+func (fp BLS12381Fr) BitsPerLimb() uint { return 64 }
+func (fp BLS12381Fr) IsPrime() bool     { return true }
+func (fp BLS12381Fr) Modulus() *big.Int { return ecc.BLS12_381.ScalarField() }
+
 // CurveParams defines parameters of an elliptic curve in short Weierstrass form
 // given by the equation
 //
@@ -35,19 +84,106 @@ func GetSecp256k1Params() CurveParams {
 	}
 }
 
+// GetP256Params returns curve parameters for the NIST curve P-256 (also known
+// as secp256r1 or prime256v1), used for example by WebAuthn. When initialising
+// new curve, use the base field [P256Fp] and scalar field [P256Fr].
+func GetP256Params() CurveParams {
+	return getNISTParams[P256Fp](elliptic.P256())
+}
+
+// GetP384Params returns curve parameters for the NIST curve P-384 (also known
+// as secp384r1). When initialising new curve, use the base field [P384Fp] and
+// scalar field [P384Fr].
+func GetP384Params() CurveParams {
+	return getNISTParams[P384Fp](elliptic.P384())
+}
+
+// getNISTParams returns the parameters of a NIST prime curve, for which
+// a = -3 mod p. The NIST curves are not implemented in gnark-crypto.
+func getNISTParams[Base emulated.FieldParams](curve elliptic.Curve) CurveParams {
+	params := curve.Params()
+	return newCurveParams[Base](
+		new(big.Int).Sub(params.P, big.NewInt(3)),
+		new(big.Int).Set(params.B),
+		new(big.Int).Set(params.Gx),
+		new(big.Int).Set(params.Gy),
+		params.N.BitLen(),
+	)
+}
+
+// newCurveParams returns the parameters of the curve Y² = X³ + aX + b with
+// base point (gx, gy), the generator table being computed with
+// [ComputeGeneratorTable] for scalars of scalarBits bits. It panics if the
+// table cannot be computed.
+func newCurveParams[Base emulated.FieldParams](a, b, gx, gy *big.Int, scalarBits int) CurveParams {
+	res := CurveParams{A: a, B: b, Gx: gx, Gy: gy}
+	gm, err := ComputeGeneratorTable[Base](res, scalarBits)
+	if err != nil {
+		panic(err)
+	}
+	res.Gm = gm
+	return res
+}
+
+// GetBN254Params returns curve parameters for the G1 curve of BN254. When
+// initialising new curve, use the base field [emulated.BN254Fp] and scalar
+// field [emulated.BN254Fr].
+func GetBN254Params() CurveParams {
+	_, _, g1aff, _ := bn254.Generators()
+	return newCurveParams[emulated.BN254Fp](
+		big.NewInt(0),
+		big.NewInt(3),
+		g1aff.X.BigInt(new(big.Int)),
+		g1aff.Y.BigInt(new(big.Int)),
+		emulated.BN254Fr{}.Modulus().BitLen(),
+	)
+}
+
+// GetBLS12381Params returns curve parameters for the G1 curve of BLS12-381.
+// When initialising new curve, use the base field [emulated.BLS12381Fp] and
+// scalar field [BLS12381Fr].
+func GetBLS12381Params() CurveParams {
+	_, _, g1aff, _ := bls12381.Generators()
+	return newCurveParams[emulated.BLS12381Fp](
+		big.NewInt(0),
+		big.NewInt(4),
+		g1aff.X.BigInt(new(big.Int)),
+		g1aff.Y.BigInt(new(big.Int)),
+		BLS12381Fr{}.Modulus().BitLen(),
+	)
+}
+
 // GetCurveParams returns suitable curve parameters given the parametric type Base as base field.
 func GetCurveParams[Base emulated.FieldParams]() CurveParams {
 	var t Base
 	switch t.Modulus().Text(16) {
 	case "fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f":
 		return secp256k1Params
+	case "ffffffff00000001000000000000000000000000ffffffffffffffffffffffff":
+		return p256Params
+	case "fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffff0000000000000000ffffffff":
+		return p384Params
+	case "30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47":
+		return bn254Params
+	case "1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab":
+		return bls12381Params
 	default:
 		panic("no stored parameters")
 	}
 }
 
-var secp256k1Params CurveParams
+var (
+	secp256k1Params CurveParams
+	p256Params      CurveParams
+	p384Params      CurveParams
+	bn254Params     CurveParams
+	bls12381Params  CurveParams
+)
 
 func init() {
 	secp256k1Params = GetSecp256k1Params()
+	p256Params = GetP256Params()
+	p384Params = GetP384Params()
+	bn254Params = GetBN254Params()
+	bls12381Params = GetBLS12381Params()
 }
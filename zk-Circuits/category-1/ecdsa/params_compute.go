@@ -1,9 +1,12 @@
 package ecdsa
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark/std/math/emulated"
 )
 
 func computeSecp256k1Table() [][2]*big.Int {
@@ -31,3 +34,117 @@ func computeSecp256k1Table() [][2]*big.Int {
 	}
 	return table[:]
 }
+
+// ComputeGeneratorTable computes the pre-computed multiples of the base point
+// stored in [CurveParams.Gm] for the curve defined by params over the base
+// field Base. The first three entries are [3]g, [5]g and [7]g and the entry at
+// position i, for 3 ≤ i < scalarBits, is [2^i]g, where scalarBits is the
+// bit-length of the scalar field modulus.
+//
+// It only uses the coefficients A, B and the base point (Gx, Gy) in affine
+// coordinates, so that [New] can be initialised for any curve in short
+// Weierstrass form. It returns an error if the base point is not on the curve
+// or if one of the multiples is the point at infinity.
+func ComputeGeneratorTable[Base emulated.FieldParams](params CurveParams, scalarBits int) ([][2]*big.Int, error) {
+	var fp Base
+	p := fp.Modulus()
+	if scalarBits < 4 {
+		return nil, fmt.Errorf("scalar bit-length %d too small", scalarBits)
+	}
+	if !isOnCurve(p, params.A, params.B, params.Gx, params.Gy) {
+		return nil, errors.New("base point not on curve")
+	}
+	gx := new(big.Int).Mod(params.Gx, p)
+	gy := new(big.Int).Mod(params.Gy, p)
+	table := make([][2]*big.Int, scalarBits)
+	tmpx, tmpy := gx, gy
+	var ok bool
+	for i := 1; i < scalarBits; i++ {
+		if tmpx, tmpy, ok = affineDouble(p, params.A, tmpx, tmpy); !ok {
+			return nil, fmt.Errorf("[2^%d]g is the point at infinity", i)
+		}
+		switch i {
+		case 1, 2:
+			x, y, ok := affineAdd(p, tmpx, tmpy, gx, gy)
+			if !ok {
+				return nil, fmt.Errorf("[%d]g is the point at infinity", 1<<i+1)
+			}
+			table[i-1] = [2]*big.Int{x, y}
+		case 3:
+			x, y, ok := affineAdd(p, tmpx, tmpy, gx, new(big.Int).Sub(p, gy))
+			if !ok {
+				return nil, errors.New("[7]g is the point at infinity")
+			}
+			table[i-1] = [2]*big.Int{x, y}
+			fallthrough
+		default:
+			table[i] = [2]*big.Int{tmpx, tmpy}
+		}
+	}
+	return table, nil
+}
+
+// isOnCurve returns true if y² = x³ + ax + b mod p.
+func isOnCurve(p, a, b, x, y *big.Int) bool {
+	lhs := new(big.Int).Mul(y, y)
+	lhs.Mod(lhs, p)
+	rhs := new(big.Int).Mul(x, x)
+	rhs.Add(rhs, a)
+	rhs.Mul(rhs, x)
+	rhs.Add(rhs, b)
+	rhs.Mod(rhs, p)
+	return lhs.Cmp(rhs) == 0
+}
+
+// affineDouble returns 2(x, y) using affine coordinates. It returns false if
+// the result is the point at infinity.
+func affineDouble(p, a, x, y *big.Int) (*big.Int, *big.Int, bool) {
+	if y.Sign() == 0 {
+		return nil, nil, false
+	}
+	// λ = (3x²+a)/2y
+	num := new(big.Int).Mul(x, x)
+	num.Mul(num, big.NewInt(3))
+	num.Add(num, a)
+	den := new(big.Int).Lsh(y, 1)
+	den.ModInverse(den, p)
+	λ := num.Mul(num, den)
+	λ.Mod(λ, p)
+	// xr = λ²-2x
+	xr := new(big.Int).Mul(λ, λ)
+	xr.Sub(xr, x)
+	xr.Sub(xr, x)
+	xr.Mod(xr, p)
+	// yr = λ(x-xr) - y
+	yr := new(big.Int).Sub(x, xr)
+	yr.Mul(yr, λ)
+	yr.Sub(yr, y)
+	yr.Mod(yr, p)
+	return xr, yr, true
+}
+
+// affineAdd returns (x1, y1) + (x2, y2) using affine coordinates. The points
+// must be different. It returns false if the result is the point at infinity.
+func affineAdd(p, x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int, bool) {
+	// λ = (y2-y1)/(x2-x1)
+	den := new(big.Int).Sub(x2, x1)
+	den.Mod(den, p)
+	if den.Sign() == 0 {
+		return nil, nil, false
+	}
+	den.ModInverse(den, p)
+	λ := new(big.Int).Sub(y2, y1)
+	λ.Mul(λ, den)
+	λ.Mod(λ, p)
+	// xr = λ²-x1-x2
+	xr := new(big.Int).Mul(λ, λ)
+	xr.Sub(xr, x1)
+	xr.Sub(xr, x2)
+	xr.Mod(xr, p)
+	// yr = λ(x1-xr) - y1
+	yr := new(big.Int).Sub(x1, xr)
+	yr.Mul(yr, λ)
+	yr.Sub(yr, y1)
+	yr.Mod(yr, p)
+	return xr, yr, true
+}
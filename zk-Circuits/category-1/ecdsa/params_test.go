@@ -0,0 +1,77 @@
+package ecdsa
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+func TestComputeGeneratorTable(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// secp256k1
+	params := GetSecp256k1Params()
+	gm, err := ComputeGeneratorTable[emulated.Secp256k1Fp](params, 256)
+	assert.NoError(err)
+	assertTableEqual(assert, gm, computeSecp256k1Table())
+
+	// the other curves, against independent scalar multiplications
+	for _, tc := range []struct {
+		name           string
+		gm             [][2]*big.Int
+		scalarBaseMult func(m *big.Int) (x, y *big.Int)
+	}{
+		{"P-256", GetP256Params().Gm, func(m *big.Int) (*big.Int, *big.Int) {
+			return elliptic.P256().ScalarBaseMult(m.Bytes())
+		}},
+		{"BN254", GetBN254Params().Gm, func(m *big.Int) (*big.Int, *big.Int) {
+			var p bn254.G1Affine
+			p.ScalarMultiplicationBase(m)
+			return p.X.BigInt(new(big.Int)), p.Y.BigInt(new(big.Int))
+		}},
+		{"BLS12-381", GetBLS12381Params().Gm, func(m *big.Int) (*big.Int, *big.Int) {
+			var p bls12381.G1Affine
+			p.ScalarMultiplicationBase(m)
+			return p.X.BigInt(new(big.Int)), p.Y.BigInt(new(big.Int))
+		}},
+	} {
+		assert.Run(func(assert *test.Assert) {
+			expected := make([][2]*big.Int, len(tc.gm))
+			for i := range expected {
+				m := new(big.Int).Lsh(big.NewInt(1), uint(i))
+				if i < 3 {
+					m.SetInt64(int64(2*i + 3))
+				}
+				x, y := tc.scalarBaseMult(m)
+				expected[i] = [2]*big.Int{x, y}
+			}
+			assertTableEqual(assert, tc.gm, expected)
+		}, tc.name)
+	}
+}
+
+func TestComputeGeneratorTableInvalid(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	params := GetSecp256k1Params()
+	params.Gy = new(big.Int).Add(params.Gy, big.NewInt(1))
+	_, err := ComputeGeneratorTable[emulated.Secp256k1Fp](params, 256)
+	assert.Error(err)
+
+	params = GetSecp256k1Params()
+	_, err = ComputeGeneratorTable[emulated.Secp256k1Fp](params, 3)
+	assert.Error(err)
+}
+
+func assertTableEqual(assert *test.Assert, got, expected [][2]*big.Int) {
+	assert.Equal(len(expected), len(got))
+	for i := range expected {
+		assert.Equal(0, expected[i][0].Cmp(got[i][0]), "entry %d x", i)
+		assert.Equal(0, expected[i][1].Cmp(got[i][1]), "entry %d y", i)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/math/emulated"
+	bls12 "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bls12381"
 )
 
 // New returns a new [Curve] instance over the base field Base and scalar field
@@ -95,6 +96,57 @@ func (c *Curve[B, S]) AssertIsEqual(p, q *AffinePoint[B]) {
 	c.baseApi.AssertIsEqual(&p.Y, &q.Y)
 }
 
+// AssertIsOnCurve asserts that p satisfies the curve equation
+//
+//	Y² = X³ + aX + b
+//
+// It doesn't accept the conventional (0,0) neutral point.
+func (c *Curve[B, S]) AssertIsOnCurve(p *AffinePoint[B]) {
+	lhs, rhs := c.curveEquation(p)
+	c.baseApi.AssertIsEqual(lhs, rhs)
+}
+
+// isOnCurve returns 1 if p satisfies the curve equation and 0 otherwise.
+// Contrary to [Curve.AssertIsOnCurve], it doesn't assert it.
+func (c *Curve[B, S]) isOnCurve(p *AffinePoint[B]) frontend.Variable {
+	lhs, rhs := c.curveEquation(p)
+	return isZero(c.api, c.baseApi, c.baseApi.Sub(lhs, rhs))
+}
+
+// curveEquation returns the two sides Y² and X³ + aX + b of the curve
+// equation at p.
+func (c *Curve[B, S]) curveEquation(p *AffinePoint[B]) (lhs, rhs *emulated.Element[B]) {
+	// rhs = (x²+a)x + b
+	rhs = c.baseApi.MulMod(&p.X, &p.X)
+	if c.addA {
+		rhs = c.baseApi.Add(rhs, &c.a)
+	}
+	rhs = c.baseApi.MulMod(rhs, &p.X)
+	b := emulated.ValueOf[B](c.params.B)
+	rhs = c.baseApi.Add(rhs, &b)
+	lhs = c.baseApi.MulMod(&p.Y, &p.Y)
+	return lhs, rhs
+}
+
+// assertIsInSubgroup asserts that p is on the curve and in the subgroup
+// generated by the base point. All the supported curves have a cofactor 1
+// except BLS12-381, for which the subgroup membership is checked with
+// [bls12.Pairing.AssertIsOnG1]. It returns an error if initialising the
+// BLS12-381 arithmetic fails.
+func (c *Curve[B, S]) assertIsInSubgroup(p *AffinePoint[B]) error {
+	q, ok := any(p).(*AffinePoint[emulated.BLS12381Fp])
+	if !ok {
+		c.AssertIsOnCurve(p)
+		return nil
+	}
+	pr, err := bls12.NewPairing(c.api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	pr.AssertIsOnG1(&bls12.G1Affine{X: q.X, Y: q.Y})
+	return nil
+}
+
 // add adds p and q and returns it. It doesn't modify p nor q.
 //
 // ⚠️  p must be different than q and -q, and both nonzero.
@@ -135,9 +187,9 @@ func (c *Curve[B, S]) add(p, q *AffinePoint[B]) *AffinePoint[B] {
 func (c *Curve[B, S]) AddUnified(p, q *AffinePoint[B]) *AffinePoint[B] {
 
 	// selector1 = 1 when p is (0,0) and 0 otherwise
-	selector1 := c.api.And(c.baseApi.IsZero(&p.X), c.baseApi.IsZero(&p.Y))
+	selector1 := c.api.And(isZero(c.api, c.baseApi, &p.X), isZero(c.api, c.baseApi, &p.Y))
 	// selector2 = 1 when q is (0,0) and 0 otherwise
-	selector2 := c.api.And(c.baseApi.IsZero(&q.X), c.baseApi.IsZero(&q.Y))
+	selector2 := c.api.And(isZero(c.api, c.baseApi, &q.X), isZero(c.api, c.baseApi, &q.Y))
 
 	// λ = ((p.x+q.x)² - p.x*q.x + a)/(p.y + q.y)
 	pxqx := c.baseApi.MulMod(&p.X, &q.X)
@@ -149,7 +201,7 @@ func (c *Curve[B, S]) AddUnified(p, q *AffinePoint[B]) *AffinePoint[B] {
 	}
 	denum := c.baseApi.Add(&p.Y, &q.Y)
 	// if p.y + q.y = 0, assign dummy 1 to denum and continue
-	selector3 := c.baseApi.IsZero(denum)
+	selector3 := isZero(c.api, c.baseApi, denum)
 	denum = c.baseApi.Select(selector3, c.baseApi.One(), denum)
 	λ := c.baseApi.Div(num, denum)
 
@@ -356,7 +408,7 @@ func (c *Curve[B, S]) Lookup2(b0, b1 frontend.Variable, i0, i1, i2, i3 *AffinePo
 func (c *Curve[B, S]) ScalarMul(p *AffinePoint[B], s *emulated.Element[S]) *AffinePoint[B] {
 
 	// if p=(0,0) we assign a dummy (0,1) to p and continue
-	selector := c.api.And(c.baseApi.IsZero(&p.X), c.baseApi.IsZero(&p.Y))
+	selector := c.api.And(isZero(c.api, c.baseApi, &p.X), isZero(c.api, c.baseApi, &p.Y))
 	one := c.baseApi.One()
 	p = c.Select(selector, &AffinePoint[B]{X: *one, Y: *one}, p)
 
@@ -454,7 +506,11 @@ func (c *Curve[B, S]) ScalarMulBase(s *emulated.Element[S]) *AffinePoint[B] {
 //
 // This saves the Select logic related to (0,0) and the use of AddUnified to
 // handle the 0-scalar edge case.
-func (c *Curve[B, S]) jointScalarMulBase(p *AffinePoint[B], s2, s1 *emulated.Element[S]) *AffinePoint[B] {
+//
+// If complete is set, the two scalar multiplications are summed with
+// [Curve.AddUnified], so that [s2]p may be equal to ±[s1]g, e.g. for a
+// signature chosen by the prover. The result is then (0,0) if [s2]p = -[s1]g.
+func (c *Curve[B, S]) jointScalarMulBase(p *AffinePoint[B], s2, s1 *emulated.Element[S], complete bool) *AffinePoint[B] {
 	g := c.Generator()
 	gm := c.GeneratorMultiples()
 
@@ -502,5 +558,8 @@ func (c *Curve[B, S]) jointScalarMulBase(p *AffinePoint[B], s2, s1 *emulated.Ele
 	tmp2 = c.doubleAndAdd(acc, res2)
 	res2 = c.Select(s2Bits[n-1], tmp2, res2)
 
+	if complete {
+		return c.AddUnified(res1, res2)
+	}
 	return c.add(res1, res2)
 }
@@ -0,0 +1,166 @@
+package ecdsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/permutation/keccakf"
+)
+
+// Recover returns the public key which signed the message msg with the
+// signature sig and the recovery id v, as in Ethereum's ecrecover. The curve
+// parameters params define the elliptic curve.
+//
+// The recovery id v is in [0, 3]: its least significant bit is the parity of
+// the y coordinate of the commitment R = [k]g and its most significant bit is
+// set when the x coordinate of R is r+n instead of r (with n the group order).
+// Recover asserts that R is a point of the curve with these properties and
+// returns
+//
+//	Q = r⁻¹(sR − zG)
+//
+// where z is the message msg, which we assume is already hashed to the scalar
+// field. As in [PublicKey.Verify], r and z must be nonzero.
+func Recover[T, S emulated.FieldParams](api frontend.API, params CurveParams, msg *emulated.Element[S], sig *Signature[S], v frontend.Variable) (*PublicKey[T, S], error) {
+	cr, err := New[T, S](api, params)
+	if err != nil {
+		return nil, fmt.Errorf("new curve: %w", err)
+	}
+	scalarApi := cr.scalarApi
+	baseApi := cr.baseApi
+
+	var st S
+	var bt T
+	n := st.Modulus()
+	vBits := bits.ToBinary(api, v, bits.WithNbDigits(2))
+
+	// R.x = r + v₁n, which must be smaller than p when v₁ = 1. r must be
+	// canonical, otherwise Reduce could return r+n and R.x = r+n for v₁ = 0.
+	rr := scalarApi.Reduce(&sig.R)
+	scalarApi.AssertIsInRange(rr)
+	rBits := scalarApi.ToBits(rr)
+	rx := baseApi.FromBits(rBits[:n.BitLen()]...)
+	if n.Cmp(bt.Modulus()) < 0 {
+		bound := new(big.Int).Sub(bt.Modulus(), n)
+		bound.Sub(bound, big.NewInt(1))
+		baseApi.AssertIsLessOrEqual(baseApi.Select(vBits[1], rx, baseApi.Zero()), baseApi.NewElement(bound))
+		rx = baseApi.Add(rx, baseApi.Select(vBits[1], baseApi.NewElement(new(big.Int).Set(n)), baseApi.Zero()))
+	} else {
+		api.AssertIsEqual(vBits[1], 0)
+	}
+	rx = baseApi.Reduce(rx)
+
+	// R.y is the square root of R.x³+aR.x+b of parity v₀.
+	res, err := baseApi.NewHint(decompressHint, 1, rx, &cr.a, baseApi.NewElement(new(big.Int).Set(params.B)))
+	if err != nil {
+		return nil, fmt.Errorf("decompress hint: %w", err)
+	}
+	ry := res[0]
+	baseApi.AssertIsInRange(ry)
+	api.AssertIsEqual(baseApi.ToBits(ry)[0], 0)
+	ry = baseApi.Select(vBits[0], baseApi.Neg(ry), ry)
+	R := AffinePoint[T]{X: *rx, Y: *ry}
+	cr.AssertIsOnCurve(&R)
+
+	// Q = [s/r]R + [-z/r]g
+	rInv := scalarApi.Inverse(&sig.R)
+	u1 := scalarApi.MulMod(scalarApi.Neg(msg), rInv)
+	u2 := scalarApi.MulMod(&sig.S, rInv)
+	q := cr.jointScalarMulBase(&R, u2, u1, false)
+
+	pk := PublicKey[T, S](*q)
+	return &pk, nil
+}
+
+// EthereumAddress returns the Ethereum address of the public key pk, that is
+// the 20 least significant bytes of the Keccak-256 hash of the concatenation
+// of the big-endian encodings of pk.X and pk.Y. The address is returned as a
+// single variable holding its big-endian integer value.
+//
+// The encoding of the public key must fit in a single Keccak-256 block, which
+// is the case for all the curves in this package.
+func EthereumAddress[T, S emulated.FieldParams](api frontend.API, pk *PublicKey[T, S]) (frontend.Variable, error) {
+	baseApi, err := emulated.NewField[T](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base api: %w", err)
+	}
+	var bt T
+	nbBytes := (bt.Modulus().BitLen() + 7) / 8
+
+	// big-endian bytes of X ∥ Y, as little-endian bits per byte.
+	var in [][]frontend.Variable
+	for _, c := range []*emulated.Element[T]{&pk.X, &pk.Y} {
+		cBits := baseApi.ToBits(baseApi.Reduce(c))
+		for i := nbBytes - 1; i >= 0; i-- {
+			in = append(in, cBits[8*i:8*i+8])
+		}
+	}
+
+	digest, err := keccak256(api, in)
+	if err != nil {
+		return nil, err
+	}
+
+	// the address is the last 20 bytes of the digest, in big-endian.
+	addr := make([]frontend.Variable, 0, 160)
+	for i := 31; i >= 12; i-- {
+		addr = append(addr, digest[i]...)
+	}
+	return bits.FromBinary(api, addr), nil
+}
+
+// keccak256 returns the Keccak-256 digest of the message msg, given as bytes
+// in little-endian bits. The digest is returned in the same form. We use the
+// original Keccak padding as in Ethereum, not the SHA3 one.
+func keccak256(api frontend.API, msg [][]frontend.Variable) ([][]frontend.Variable, error) {
+	const rate = 136
+	if len(msg) >= rate {
+		return nil, fmt.Errorf("message of %d bytes does not fit in a single block", len(msg))
+	}
+	zero := make([]frontend.Variable, 8)
+	for i := range zero {
+		zero[i] = 0
+	}
+	block := make([][]frontend.Variable, 200)
+	copy(block, msg)
+	for i := len(msg); i < len(block); i++ {
+		block[i] = zero
+	}
+	// pad10*1: 0x01 after the message and 0x80 at the end of the block
+	pad := func(b int) []frontend.Variable {
+		res := make([]frontend.Variable, 8)
+		for i := range res {
+			res[i] = (b >> i) & 1
+		}
+		return res
+	}
+	if len(msg) == rate-1 {
+		block[len(msg)] = pad(0x81)
+	} else {
+		block[len(msg)] = pad(0x01)
+		block[rate-1] = pad(0x80)
+	}
+
+	// lanes are 64-bit little-endian words
+	var state [25]frontend.Variable
+	for i := range state {
+		lane := make([]frontend.Variable, 0, 64)
+		for j := 0; j < 8; j++ {
+			lane = append(lane, block[8*i+j]...)
+		}
+		state[i] = bits.FromBinary(api, lane)
+	}
+	state = keccakf.Permute(api, state)
+
+	digest := make([][]frontend.Variable, 0, 32)
+	for i := 0; i < 4; i++ {
+		lane := bits.ToBinary(api, state[i], bits.WithNbDigits(64))
+		for j := 0; j < 8; j++ {
+			digest = append(digest, lane[8*j:8*j+8])
+		}
+	}
+	return digest, nil
+}
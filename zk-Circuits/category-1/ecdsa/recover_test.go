@@ -0,0 +1,183 @@
+package ecdsa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/ecdsa"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/sha3"
+)
+
+type RecoverCircuit[T, S emulated.FieldParams] struct {
+	Sig  Signature[S]
+	Msg  emulated.Element[S]
+	V    frontend.Variable
+	Pub  PublicKey[T, S]
+	Addr frontend.Variable
+}
+
+func (c *RecoverCircuit[T, S]) Define(api frontend.API) error {
+	pk, err := Recover[T, S](api, GetCurveParams[T](), &c.Msg, &c.Sig, c.V)
+	if err != nil {
+		return err
+	}
+	baseApi, err := emulated.NewField[T](api)
+	if err != nil {
+		return err
+	}
+	baseApi.AssertIsEqual(&pk.X, &c.Pub.X)
+	baseApi.AssertIsEqual(&pk.Y, &c.Pub.Y)
+	addr, err := EthereumAddress(api, pk)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(addr, c.Addr)
+	return nil
+}
+
+// ethereumAddress returns the Ethereum address of the public key (x, y).
+func ethereumAddress(x, y *big.Int) *big.Int {
+	var buf [64]byte
+	x.FillBytes(buf[:32])
+	y.FillBytes(buf[32:])
+	h := sha3.NewLegacyKeccak256()
+	h.Write(buf[:])
+	return new(big.Int).SetBytes(h.Sum(nil)[12:])
+}
+
+func recoverWitness(v uint, r, s, hash, pubx, puby *big.Int) *RecoverCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr] {
+	return &RecoverCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Sig: Signature[emulated.Secp256k1Fr]{
+			R: emulated.ValueOf[emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		},
+		Msg: emulated.ValueOf[emulated.Secp256k1Fr](hash),
+		V:   v,
+		Pub: PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			X: emulated.ValueOf[emulated.Secp256k1Fp](pubx),
+			Y: emulated.ValueOf[emulated.Secp256k1Fp](puby),
+		},
+		Addr: ethereumAddress(pubx, puby),
+	}
+}
+
+func TestRecover(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// generate parameters
+	privKey, _ := ecdsa.GenerateKey(rand.Reader)
+
+	// sign
+	msg := []byte("testing ECDSA (recover)")
+	md := sha256.New()
+	v, r, s, err := privKey.SignForRecover(msg, md)
+	assert.NoError(err)
+
+	// gnark-crypto sets the lsb of v when R.y is the largest root, while
+	// Ethereum sets it when R.y is odd.
+	R, err := ecdsa.RecoverP(v, r)
+	assert.NoError(err)
+	v = v&2 | R.Y.BigInt(new(big.Int)).Bit(0)
+
+	md.Reset()
+	md.Write(msg)
+	hash := ecdsa.HashToInt(md.Sum(nil))
+
+	pubx := privKey.PublicKey.A.X.BigInt(new(big.Int))
+	puby := privKey.PublicKey.A.Y.BigInt(new(big.Int))
+	circuit := RecoverCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}
+	witness := recoverWitness(v, r, s, hash, pubx, puby)
+	err = test.IsSolved(&circuit, witness, testCurve.ScalarField())
+	assert.NoError(err)
+
+	// wrong recovery id
+	witness = recoverWitness(v^1, r, s, hash, pubx, puby)
+	err = test.IsSolved(&circuit, witness, testCurve.ScalarField())
+	assert.Error(err)
+}
+
+func TestRecoverOverflow(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// R.x ≥ n happens with negligible probability for honest signatures, so
+	// we pick such a point R and derive the public key from (r, s, z).
+	n := fr.Modulus()
+	var R secp256k1.G1Affine
+	x := new(big.Int).Set(n)
+	for {
+		x.Add(x, big.NewInt(1))
+		R.X.SetBigInt(x)
+		var y fp.Element
+		y.Square(&R.X).Mul(&y, &R.X).Add(&y, new(fp.Element).SetUint64(7))
+		if R.Y.Sqrt(&y) != nil {
+			break
+		}
+	}
+	r := new(big.Int).Sub(x, n)
+	s, _ := rand.Int(rand.Reader, n)
+	hash, _ := rand.Int(rand.Reader, n)
+	v := 2 | R.Y.BigInt(new(big.Int)).Bit(0)
+
+	// Q = r⁻¹(sR − zG)
+	_, g := secp256k1.Generators()
+	rInv := new(big.Int).ModInverse(r, n)
+	u1 := new(big.Int).Mul(hash, rInv)
+	u1.Neg(u1).Mod(u1, n)
+	u2 := new(big.Int).Mul(s, rInv)
+	u2.Mod(u2, n)
+	var Q, zG secp256k1.G1Affine
+	Q.ScalarMultiplication(&R, u2)
+	zG.ScalarMultiplication(&g, u1)
+	Q.Add(&Q, &zG)
+
+	pubx := Q.X.BigInt(new(big.Int))
+	puby := Q.Y.BigInt(new(big.Int))
+	circuit := RecoverCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}
+	witness := recoverWitness(uint(v), r, s, hash, pubx, puby)
+	err := test.IsSolved(&circuit, witness, testCurve.ScalarField())
+	assert.NoError(err)
+
+	// without the overflow bit R.x = r, which is a different point
+	witness = recoverWitness(uint(v&1), r, s, hash, pubx, puby)
+	err = test.IsSolved(&circuit, witness, testCurve.ScalarField())
+	assert.Error(err)
+}
+
+type ethereumAddressCircuit struct {
+	Pub  PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]
+	Addr frontend.Variable
+}
+
+func (c *ethereumAddressCircuit) Define(api frontend.API) error {
+	addr, err := EthereumAddress(api, &c.Pub)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(addr, c.Addr)
+	return nil
+}
+
+func TestEthereumAddress(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// the address of the private key 1 is the one of the generator.
+	_, g := secp256k1.Generators()
+	addr, _ := new(big.Int).SetString("7E5F4552091A69125d5DfCb7b8C2659029395Bdf", 16)
+	witness := ethereumAddressCircuit{
+		Pub: PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			X: emulated.ValueOf[emulated.Secp256k1Fp](g.X.BigInt(new(big.Int))),
+			Y: emulated.ValueOf[emulated.Secp256k1Fp](g.Y.BigInt(new(big.Int))),
+		},
+		Addr: addr,
+	}
+	err := test.IsSolved(&ethereumAddressCircuit{}, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
@@ -0,0 +1,146 @@
+// Package groth16_bn254 implements an in-circuit verifier of gnark's BN254
+// Groth16 proofs. The pairings are emulated in a BN254 circuit, so that BN254
+// proofs (e.g. verifiable on Ethereum) can be verified recursively without a
+// cycle of curves.
+//
+// The verification of a proof (A, B, C) for the public inputs xᵢ checks
+//
+//	e(A, B) * e(C, -[δ]2) * e(K₀ + ∑ᵢ[xᵢ]Kᵢ₊₁, -[γ]2) == e(α, β)
+//
+// where the verifying key is a circuit constant: e(α, β) is precomputed, the
+// lines of -[γ]2 and -[δ]2 are precomputed (see
+// [pairing_bn254.PrecomputeLines]) and the Miller loop of e(A, B) shares its
+// squarings with the one of e(C, -[δ]2) (DoubleMillerLoopFixedQ).
+package groth16_bn254
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	groth16 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	bn "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bn254"
+)
+
+// Proof is the in-circuit counterpart of a [groth16.Proof].
+type Proof struct {
+	Ar, Krs bn.G1Affine
+	Bs      bn.G2Affine
+}
+
+func NewProof(v *groth16.Proof) Proof {
+	return Proof{
+		Ar:  bn.NewG1Affine(v.Ar),
+		Krs: bn.NewG1Affine(v.Krs),
+		Bs:  bn.NewG2Affine(v.Bs),
+	}
+}
+
+// NewPublicInputs returns the in-circuit counterpart of the public witness v,
+// i.e. of the public inputs without the constant one wire.
+func NewPublicInputs(v fr.Vector) []emulated.Element[emulated.BN254Fr] {
+	res := make([]emulated.Element[emulated.BN254Fr], len(v))
+	for i := range v {
+		res[i] = emulated.ValueOf[emulated.BN254Fr](v[i])
+	}
+	return res
+}
+
+type Verifier struct {
+	api frontend.API
+	pr  *bn.Pairing
+	fr  *emulated.Field[emulated.BN254Fr]
+
+	// verifying key: [Kᵢ]1, e(α, β) and the lines of -[γ]2 and -[δ]2
+	k          []bn.G1Affine
+	eAlphaBeta bn.GTEl
+	gammaNeg   bn.FixedLines
+	deltaNeg   bn.FixedLines
+}
+
+// NewVerifier returns a verifier of the proofs of the BN254 Groth16 verifying
+// key vk, which is embedded in the circuit as constants. The verifying keys of
+// circuits with commitments are not supported.
+func NewVerifier(api frontend.API, vk *groth16.VerifyingKey) (*Verifier, error) {
+	if vk.CommitmentInfo.Is() {
+		return nil, errors.New("commitments are not supported")
+	}
+	if len(vk.G1.K) == 0 {
+		return nil, errors.New("invalid verifying key")
+	}
+	pairing_bn, err := bn.NewPairing(api)
+	if err != nil {
+		return nil, fmt.Errorf("new pairing: %w", err)
+	}
+	fr, err := emulated.NewField[emulated.BN254Fr](api)
+	if err != nil {
+		return nil, fmt.Errorf("new scalar field: %w", err)
+	}
+	k := make([]bn.G1Affine, len(vk.G1.K))
+	for i := range vk.G1.K {
+		k[i] = bn.NewG1Affine(vk.G1.K[i])
+	}
+	e, err := bn254.Pair([]bn254.G1Affine{vk.G1.Alpha}, []bn254.G2Affine{vk.G2.Beta})
+	if err != nil {
+		return nil, fmt.Errorf("pair: %w", err)
+	}
+	var gammaNeg, deltaNeg bn254.G2Affine
+	gammaNeg.Neg(&vk.G2.Gamma)
+	deltaNeg.Neg(&vk.G2.Delta)
+	return &Verifier{
+		api:        api,
+		pr:         pairing_bn,
+		fr:         fr,
+		k:          k,
+		eAlphaBeta: bn.NewGTEl(e),
+		gammaNeg:   bn.PrecomputeLines(gammaNeg),
+		deltaNeg:   bn.PrecomputeLines(deltaNeg),
+	}, nil
+}
+
+// Verify verifies the proof for the public inputs, given without the constant
+// one wire. As in gnark, the points of the proof are checked to be in G1 and
+// G2.
+//
+// The sum K₀ + ∑ᵢ[xᵢ]Kᵢ₊₁ is computed with a multi-scalar multiplication using
+// complete formulas. It is given to the Miller loop and must not be the point
+// at infinity, which happens only with negligible probability.
+func (v Verifier) Verify(proof *Proof, publicInputs []emulated.Element[emulated.BN254Fr]) error {
+	if len(publicInputs) != len(v.k)-1 {
+		return fmt.Errorf("invalid number of public inputs: got %d, expected %d", len(publicInputs), len(v.k)-1)
+	}
+	v.pr.AssertIsOnG1(&proof.Ar)
+	v.pr.AssertIsOnG1(&proof.Krs)
+	v.pr.AssertIsOnG2(&proof.Bs)
+
+	// K₀ + ∑ᵢ[xᵢ]Kᵢ₊₁
+	points := make([]*bn.G1Affine, len(v.k))
+	scalars := make([][]frontend.Variable, len(v.k))
+	points[0], scalars[0] = &v.k[0], []frontend.Variable{1}
+	for i := range publicInputs {
+		points[i+1] = &v.k[i+1]
+		scalars[i+1] = v.fr.ToBits(v.fr.Reduce(&publicInputs[i]))
+	}
+	kSum, err := v.pr.MultiScalarMulG1(points, scalars)
+	if err != nil {
+		return fmt.Errorf("msm: %w", err)
+	}
+
+	// e(A, B) * e(C, -[δ]2) * e(kSum, -[γ]2)
+	ml, err := v.pr.DoubleMillerLoopFixedQ(&proof.Ar, &proof.Krs, &proof.Bs, &v.deltaNeg)
+	if err != nil {
+		return fmt.Errorf("double miller loop: %w", err)
+	}
+	mlGamma, err := v.pr.MillerLoopFixedQ(kSum, &v.gammaNeg)
+	if err != nil {
+		return fmt.Errorf("miller loop: %w", err)
+	}
+	// the product is different from ±1 unless e(α, β) = 1, so that the unsafe
+	// final exponentiation is complete here.
+	res := v.pr.FinalExponentiationUnsafe(v.pr.Ext12.Mul(ml, mlGamma))
+	v.pr.AssertIsEqual(res, &v.eAlphaBeta)
+	return nil
+}
@@ -0,0 +1,110 @@
+package groth16_bn254
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/profile"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+// innerCircuit proves the knowledge of a cube root X of Y - X - 5.
+type innerCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *innerCircuit) Define(api frontend.API) error {
+	x3 := api.Mul(c.X, c.X, c.X)
+	api.AssertIsEqual(c.Y, api.Add(x3, c.X, 5))
+	return nil
+}
+
+// innerProof returns a verifying key, a proof and its public witness for the
+// inner circuit.
+func innerProof() (*groth16_bn254.VerifyingKey, *groth16_bn254.Proof, fr.Vector, error) {
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &innerCircuit{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	w, err := frontend.NewWitness(&innerCircuit{X: 3, Y: 35}, ecc.BN254.ScalarField())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	proof, err := groth16.Prove(ccs, pk, w)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pw, err := w.Public()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := groth16.Verify(proof, vk, pw); err != nil {
+		return nil, nil, nil, err
+	}
+	return vk.(*groth16_bn254.VerifyingKey), proof.(*groth16_bn254.Proof), pw.Vector().(fr.Vector), nil
+}
+
+type verifierCircuit struct {
+	Proof Proof
+	Y     emulated.Element[emulated.BN254Fr]
+	vk    *groth16_bn254.VerifyingKey `gnark:"-"`
+}
+
+func (c *verifierCircuit) Define(api frontend.API) error {
+	verifier, err := NewVerifier(api, c.vk)
+	if err != nil {
+		return err
+	}
+	return verifier.Verify(&c.Proof, []emulated.Element[emulated.BN254Fr]{c.Y})
+}
+
+func TestVerifier(t *testing.T) {
+	assert := test.NewAssert(t)
+	vk, proof, pw, err := innerProof()
+	assert.NoError(err)
+
+	witness := verifierCircuit{
+		Proof: NewProof(proof),
+		Y:     NewPublicInputs(pw)[0],
+	}
+	err = test.IsSolved(&verifierCircuit{vk: vk}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// wrong public input
+	witness.Y = emulated.ValueOf[emulated.BN254Fr](36)
+	err = test.IsSolved(&verifierCircuit{vk: vk}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// wrong proof
+	witness = verifierCircuit{
+		Proof: NewProof(proof),
+		Y:     NewPublicInputs(pw)[0],
+	}
+	witness.Proof.Ar, witness.Proof.Krs = witness.Proof.Krs, witness.Proof.Ar
+	err = test.IsSolved(&verifierCircuit{vk: vk}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func BenchmarkVerifier(b *testing.B) {
+	vk, _, _, err := innerProof()
+	if err != nil {
+		b.Fatal(err)
+	}
+	c := verifierCircuit{vk: vk}
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  Groth16 verifier on BN254 in a BN254 R1CS circuit: ", p.NbConstraints())
+}
@@ -0,0 +1,34 @@
+// Package residue computes the exponents of the residue witness of the
+// final-exponentiation-free pairing checks (Novakovic–Eagen) shared by the
+// emulated BN254 and BLS12-381 pairings.
+package residue
+
+import "math/big"
+
+// Exponents returns the exponents e, s such that for f ∈ 𝔽p¹² with
+// f^((p¹²-1)/r) = 1, c = (f ⋅ f^s)^e satisfies c^λ = f ⋅ f^s, where λ is a
+// multiple of r.
+//
+// Write p¹²-1 = B ⋅ M where B is the part of p¹²-1 made of the primes dividing
+// gcd(λ, p¹²-1)/r and gcd(B, M) = 1. Then f^s is f⁻¹ restricted to the
+// subgroup of order B, f ⋅ f^s is in the subgroup of order M/r and λ is
+// invertible modulo M/r.
+func Exponents(lambda, p, r *big.Int) (e, s *big.Int) {
+	one := big.NewInt(1)
+	N := new(big.Int).Exp(p, big.NewInt(12), nil)
+	N.Sub(N, one)
+	g := new(big.Int).GCD(nil, nil, lambda, N)
+	g.Div(g, r)
+	B, M := big.NewInt(1), new(big.Int).Set(N)
+	for t := new(big.Int).GCD(nil, nil, M, g); t.Cmp(one) != 0; t.GCD(nil, nil, M, g) {
+		B.Mul(B, t)
+		M.Div(M, t)
+	}
+	// e = λ⁻¹ mod M/r
+	e = new(big.Int).Div(M, r)
+	e.ModInverse(lambda, e)
+	// s = -M ⋅ (M⁻¹ mod B) mod N
+	s = new(big.Int).ModInverse(M, B)
+	s.Mul(s, M).Neg(s).Mod(s, N)
+	return e, s
+}
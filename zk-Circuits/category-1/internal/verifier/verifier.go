@@ -0,0 +1,76 @@
+// Package verifier gathers the configuration and the Fiat-Shamir challenges
+// shared by the in-circuit BLS signature and KZG verifiers.
+package verifier
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// Option allows to configure a verifier.
+type Option func(*Config)
+
+// Config is the configuration of a verifier.
+type Config struct {
+	// SubgroupChecks enables the in-circuit on-curve and subgroup membership
+	// checks of the untrusted inputs.
+	SubgroupChecks bool
+}
+
+// NewConfig returns the configuration set by opts.
+func NewConfig(opts ...Option) Config {
+	var cfg Config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithSubgroupChecks sets [Config.SubgroupChecks].
+func WithSubgroupChecks() Option {
+	return func(c *Config) {
+		c.SubgroupChecks = true
+	}
+}
+
+// NbChallengeBits is the size of the random coefficients of the batch
+// verifications. An invalid batch verifies with probability at most
+// 2⁻¹²⁸.
+const NbChallengeBits = 128
+
+// BatchChallenges derives n challenges from the transcript with the MiMC hash
+// function (Fiat-Shamir) and returns for each of them NbChallengeBits bits in
+// little-endian order. The transcript must contain all the inputs of the
+// batch.
+func BatchChallenges(api frontend.API, n int, transcript []frontend.Variable) ([][]frontend.Variable, error) {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return nil, fmt.Errorf("new hasher: %w", err)
+	}
+	h.Write(transcript...)
+	seed := h.Sum()
+
+	res := make([][]frontend.Variable, n)
+	for i := range res {
+		h.Reset()
+		h.Write(seed, i)
+		res[i] = api.ToBinary(h.Sum())[:NbChallengeBits]
+	}
+	return res, nil
+}
+
+// AppendCanonical appends the limbs of the reduced values of the elements to
+// the transcript. The reduced values are asserted to be less than the modulus,
+// so that each value has a single representation in the transcript and the
+// prover cannot choose among several challenges for the same inputs.
+func AppendCanonical[T emulated.FieldParams](f *emulated.Field[T], transcript []frontend.Variable, els ...*emulated.Element[T]) []frontend.Variable {
+	for _, el := range els {
+		r := f.Reduce(el)
+		f.AssertIsInRange(r)
+		transcript = append(transcript, r.Limbs...)
+	}
+	return transcript
+}
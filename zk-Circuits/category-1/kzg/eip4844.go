@@ -0,0 +1,107 @@
+package kzg
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/kzg"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	bls12 "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bls12381"
+	"github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/sha256"
+)
+
+// VersionedHashVersionKZG is the version byte of the versioned hashes of the
+// KZG commitments (VERSIONED_HASH_VERSION_KZG in [EIP-4844]).
+//
+// [EIP-4844]: https://eips.ethereum.org/EIPS/eip-4844
+const VersionedHashVersionKZG = 0x01
+
+// VerifyPointEvaluation verifies the input of the point evaluation precompile
+// of [EIP-4844]: versionedHash is the versioned hash of the commitment to a
+// blob, and proof shows that the polynomial of the blob evaluates to y at z.
+//
+// All the inputs are big-endian bytes as in the input of the precompile: the
+// versioned hash, z and y are 32 bytes, z and y being canonical elements of
+// the BLS12-381 scalar field, and the commitment and the proof are G1 points
+// compressed on 48 bytes. The versioned hash is checked to be
+// VersionedHashVersionKZG followed by the last 31 bytes of the SHA-256 of the
+// commitment, and the points are decompressed and checked to be in G1.
+//
+// The G2 points of the trusted setup of the KZG ceremony are circuit
+// constants.
+//
+// [EIP-4844]: https://eips.ethereum.org/EIPS/eip-4844
+func VerifyPointEvaluation(api frontend.API, versionedHash, z, y, commitment, proof []frontend.Variable) error {
+	k, err := NewKZG_bls12(api, eip4844SRS)
+	if err != nil {
+		return fmt.Errorf("new verifier: %w", err)
+	}
+	return k.verifyPointEvaluation(versionedHash, z, y, commitment, proof)
+}
+
+// verifyPointEvaluation is [VerifyPointEvaluation] with the SRS of k.
+func (k KZG_bls12) verifyPointEvaluation(versionedHash, z, y, commitment, proof []frontend.Variable) error {
+	if len(versionedHash) != sha256.Size || len(z) != 32 || len(y) != 32 {
+		return errors.New("invalid scalars sizes")
+	}
+	if len(commitment) != bls12.SizeOfG1AffineCompressed || len(proof) != bls12.SizeOfG1AffineCompressed {
+		return errors.New("invalid points sizes")
+	}
+
+	// versioned hash = 0x01 ‖ SHA-256(commitment)[1:]
+	digest := sha256.Sum(k.api, commitment)
+	k.api.AssertIsEqual(versionedHash[0], VersionedHashVersionKZG)
+	for i := 1; i < sha256.Size; i++ {
+		k.api.AssertIsEqual(versionedHash[i], digest[i])
+	}
+
+	C, err := k.pr.DecompressG1(commitment)
+	if err != nil {
+		return fmt.Errorf("decompress commitment: %w", err)
+	}
+	H, err := k.pr.DecompressG1(proof)
+	if err != nil {
+		return fmt.Errorf("decompress proof: %w", err)
+	}
+	k.assertIsOnG1(C)
+	k.assertIsOnG1(H)
+
+	return k.verifyOpening(C, &OpeningProof_bls12{H: *H, ClaimedValue: *k.fromBytes(y)}, k.fromBytes(z))
+}
+
+// fromBytes returns the scalar encoded in big-endian order in b. The bytes are
+// range checked and the encoding must be canonical.
+func (k KZG_bls12) fromBytes(b []frontend.Variable) *emulated.Element[BLS12381Fr] {
+	bits := make([]frontend.Variable, 0, 8*len(b))
+	for i := len(b) - 1; i >= 0; i-- {
+		bits = append(bits, k.api.ToBinary(b[i], 8)...)
+	}
+	s := k.fr.FromBits(bits...)
+	k.fr.AssertIsInRange(s)
+	return s
+}
+
+// eip4844TauG2 is the compressed [τ]G2 of the trusted setup of the KZG
+// ceremony (KZG_SETUP_G2_MONOMIAL[1] in the consensus specs).
+const eip4844TauG2 = "b5bfd7dd8cdeb128843bc287230af38926187075cbfbefa81009a2ce615ac53d2914e5870cb452d2afaaab24f3499f72185cbfee53492714734429b7b38608e23926c911cceceac9a36851477ba4c60b087041de621000edc98edada20c1def2"
+
+// eip4844SRS is the part of the trusted setup of the KZG ceremony used by the
+// verifier: the generators of G1 and G2, and [τ]G2.
+var eip4844SRS = func() *kzg_bls12381.SRS {
+	b, err := hex.DecodeString(eip4844TauG2)
+	if err != nil {
+		panic(err)
+	}
+	var tauG2 bls12381.G2Affine
+	if _, err := tauG2.SetBytes(b); err != nil {
+		panic(err)
+	}
+	_, _, g1, g2 := bls12381.Generators()
+	return &kzg_bls12381.SRS{
+		G1: []bls12381.G1Affine{g1},
+		G2: [2]bls12381.G2Affine{g2, tauG2},
+	}
+}()
@@ -0,0 +1,132 @@
+package kzg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/kzg"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type pointEvaluationCircuit struct {
+	VersionedHash [32]frontend.Variable
+	Z, Y          [32]frontend.Variable
+	Commitment    [48]frontend.Variable
+	Proof         [48]frontend.Variable
+	// srs replaces the trusted setup of EIP-4844 when set
+	srs *kzg_bls12381.SRS `gnark:"-"`
+}
+
+func (c *pointEvaluationCircuit) Define(api frontend.API) error {
+	if c.srs == nil {
+		return VerifyPointEvaluation(api, c.VersionedHash[:], c.Z[:], c.Y[:], c.Commitment[:], c.Proof[:])
+	}
+	kzg, err := NewKZG_bls12(api, c.srs)
+	if err != nil {
+		return err
+	}
+	return kzg.verifyPointEvaluation(c.VersionedHash[:], c.Z[:], c.Y[:], c.Commitment[:], c.Proof[:])
+}
+
+// pointEvaluationWitness returns the witness of the 192-byte input of the
+// point evaluation precompile: versioned hash ‖ z ‖ y ‖ commitment ‖ proof.
+func pointEvaluationWitness(input []byte) *pointEvaluationCircuit {
+	var witness pointEvaluationCircuit
+	for i := 0; i < 32; i++ {
+		witness.VersionedHash[i] = input[i]
+		witness.Z[i] = input[32+i]
+		witness.Y[i] = input[64+i]
+	}
+	for i := 0; i < 48; i++ {
+		witness.Commitment[i] = input[96+i]
+		witness.Proof[i] = input[144+i]
+	}
+	return &witness
+}
+
+// pointEvaluationInput returns the input of the point evaluation precompile
+// for the opening of the commitment at z.
+func pointEvaluationInput(commitment bls12381.G1Affine, proof kzg_bls12381.OpeningProof, z fr.Element) *pointEvaluationCircuit {
+	c := commitment.Bytes()
+	h := sha256.Sum256(c[:])
+	h[0] = VersionedHashVersionKZG
+	zb := z.Bytes()
+	yb := proof.ClaimedValue.Bytes()
+	pb := proof.H.Bytes()
+	input := make([]byte, 0, 192)
+	for _, b := range [][]byte{h[:], zb[:], yb[:], c[:], pb[:]} {
+		input = append(input, b...)
+	}
+	return pointEvaluationWitness(input)
+}
+
+func TestKZG_bls12_VerifyPointEvaluation(t *testing.T) {
+	assert := test.NewAssert(t)
+	r := ecc.BLS12_381.ScalarField()
+	srs, err := kzg_bls12381.NewSRS(polySize, randomScalar(assert, r))
+	assert.NoError(err)
+
+	var z fr.Element
+	_, err = z.SetRandom()
+	assert.NoError(err)
+
+	for _, tc := range []struct {
+		name string
+		poly []fr.Element
+	}{
+		{"random", polynomial_bls12(randomPolynomial(assert, r))},
+		{"zero", make([]fr.Element, polySize)},
+	} {
+		commitment, err := kzg_bls12381.Commit(tc.poly, srs)
+		assert.NoError(err)
+		proof, err := kzg_bls12381.Open(tc.poly, z, srs)
+		assert.NoError(err)
+
+		witness := pointEvaluationInput(commitment, proof, z)
+		err = test.IsSolved(&pointEvaluationCircuit{srs: srs}, witness, ecc.BN254.ScalarField())
+		assert.NoError(err, tc.name)
+
+		// wrong version
+		witness.VersionedHash[0] = 0
+		err = test.IsSolved(&pointEvaluationCircuit{srs: srs}, witness, ecc.BN254.ScalarField())
+		assert.Error(err, tc.name)
+
+		// wrong evaluation
+		proof.ClaimedValue.SetOne()
+		witness = pointEvaluationInput(commitment, proof, z)
+		err = test.IsSolved(&pointEvaluationCircuit{srs: srs}, witness, ecc.BN254.ScalarField())
+		assert.Error(err, tc.name)
+	}
+}
+
+// pointEvaluationVector is the valid input of the point evaluation precompile
+// of the tests of go-ethereum (core/vm/testdata/precompiles/pointEvaluation.json).
+const pointEvaluationVector = "01e798154708fe7789429634053cbf9f99b619f9f084048927333fce637f549b564c0a11a0f704f4fc3e8acfe0f8245f0ad1347b378fbf96e206da11a5d3630624d25032e67a7e6a4910df5834b8fe70e6bcfeeac0352434196bdf4b2485d5a18f59a8d2a1a625a17f3fea0fe5eb8c896db3764f3185481bc22f91b4aaffcca25f26936857bc3a7c2539ea8ec3a952b7873033e038326e87ed3e1276fd140253fa08e9fc25fb2d9a98527fc22a2c9612fbeafdad446cbc7bcdbdcd780af2c16a"
+
+func TestKZG_bls12_VerifyPointEvaluationVector(t *testing.T) {
+	assert := test.NewAssert(t)
+	input, err := hex.DecodeString(pointEvaluationVector)
+	assert.NoError(err)
+
+	err = test.IsSolved(&pointEvaluationCircuit{}, pointEvaluationWitness(input), ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// wrong evaluation
+	wrong := append([]byte{}, input...)
+	wrong[95] ^= 1
+	err = test.IsSolved(&pointEvaluationCircuit{}, pointEvaluationWitness(wrong), ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// z + r encodes the same scalar as z on 32 bytes
+	zr := new(big.Int).SetBytes(input[32:64])
+	zr.Add(zr, fr.Modulus()).FillBytes(wrong[32:64])
+	copy(wrong[64:96], input[64:96])
+	err = test.IsSolved(&pointEvaluationCircuit{}, pointEvaluationWitness(wrong), ecc.BN254.ScalarField())
+	assert.Error(err)
+}
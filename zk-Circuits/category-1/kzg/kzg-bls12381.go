@@ -0,0 +1,151 @@
+package kzg
+
+import (
+	"errors"
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/kzg"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	bls12 "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bls12381"
+)
+
+// OpeningProof_bls12 is the in-circuit counterpart of a BLS12-381
+// [kzg_bls12381.OpeningProof].
+type OpeningProof_bls12 struct {
+	// H is the commitment to the quotient polynomial (f(X)-f(z))/(X-z).
+	H bls12.G1Affine
+	// ClaimedValue is the claimed evaluation f(z).
+	ClaimedValue emulated.Element[BLS12381Fr]
+}
+
+func NewOpeningProof_bls12(v kzg_bls12381.OpeningProof) OpeningProof_bls12 {
+	return OpeningProof_bls12{
+		H:            bls12.NewG1Affine(v.H),
+		ClaimedValue: emulated.ValueOf[BLS12381Fr](v.ClaimedValue),
+	}
+}
+
+type KZG_bls12 struct {
+	kzgVerifier[emulated.BLS12381Fp, BLS12381Fr, bls12.G1Affine]
+	pr *bls12.Pairing
+
+	// verifying key: [1]G1 (in kzgVerifier) and the lines of G2 and -[τ]G2
+	lines [2]bls12.FixedLines
+	// e([1]G1, G2)ᵃ⋅e([1]G1, -[τ]G2)ᵇ at index a+2b, see pairingCheck
+	eInf [4]bls12.GTEl
+}
+
+// NewKZG_bls12 returns a verifier of the openings of the commitments of the
+// BLS12-381 SRS srs. Only the first point of G1 and the two points of G2 of
+// srs are used, as circuit constants.
+func NewKZG_bls12(api frontend.API, srs *kzg_bls12381.SRS, opts ...Option) (*KZG_bls12, error) {
+	if len(srs.G1) == 0 {
+		return nil, errors.New("empty SRS")
+	}
+	pairing_bls12, err := bls12.NewPairing(api)
+	if err != nil {
+		return nil, fmt.Errorf("new pairing: %w", err)
+	}
+	coordinates := func(p *bls12.G1Affine) (x, y *emulated.Element[emulated.BLS12381Fp]) { return &p.X, &p.Y }
+	k, err := newKZGVerifier[emulated.BLS12381Fp, BLS12381Fr](api, bls12.NewG1Affine(srs.G1[0]), pairing_bls12.MultiScalarMulG1, coordinates, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var tauG2Neg bls12381.G2Affine
+	tauG2Neg.Neg(&srs.G2[1])
+	e, err := bls12381.Pair([]bls12381.G1Affine{srs.G1[0]}, []bls12381.G2Affine{srs.G2[0]})
+	if err != nil {
+		return nil, fmt.Errorf("pair: %w", err)
+	}
+	eTau, err := bls12381.Pair([]bls12381.G1Affine{srs.G1[0]}, []bls12381.G2Affine{tauG2Neg})
+	if err != nil {
+		return nil, fmt.Errorf("pair: %w", err)
+	}
+	var one, eBoth bls12381.GT
+	one.SetOne()
+	eBoth.Mul(&e, &eTau)
+	return &KZG_bls12{
+		kzgVerifier: k,
+		pr:          pairing_bls12,
+		lines:       [2]bls12.FixedLines{bls12.PrecomputeLines(srs.G2[0]), bls12.PrecomputeLines(tauG2Neg)},
+		eInf:        [4]bls12.GTEl{bls12.NewGTEl(one), bls12.NewGTEl(e), bls12.NewGTEl(eTau), bls12.NewGTEl(eBoth)},
+	}, nil
+}
+
+// VerifyOpening verifies the opening proof of the commitment at point. It
+// checks
+//
+//	e(C - [f(z)]G1 + [z]H, G2) * e(H, -[τ]G2) == 1
+func (k KZG_bls12) VerifyOpening(commitment *bls12.G1Affine, proof *OpeningProof_bls12, point *emulated.Element[BLS12381Fr]) error {
+	if k.cfg.SubgroupChecks {
+		k.assertIsOnG1(commitment)
+		k.assertIsOnG1(&proof.H)
+	}
+	return k.verifyOpening(commitment, proof, point)
+}
+
+// verifyOpening is [KZG_bls12.VerifyOpening] without the subgroup checks.
+func (k KZG_bls12) verifyOpening(commitment *bls12.G1Affine, proof *OpeningProof_bls12, point *emulated.Element[BLS12381Fr]) error {
+	return k.verifyOpenings([]*bls12.G1Affine{commitment}, []*OpeningProof_bls12{proof}, []*emulated.Element[BLS12381Fr]{point})
+}
+
+// BatchVerifyMultiPoints verifies the opening proofs of the commitments at
+// the points, the i-th commitment being opened at the i-th point. As in
+// gnark-crypto, the openings are folded with random coefficients λᵢ (derived
+// in-circuit by Fiat-Shamir) so that a single double pairing is computed:
+//
+//	e(∑ᵢλᵢ(Cᵢ - [f(zᵢ)]G1 + [zᵢ]Hᵢ), G2) * e(∑ᵢλᵢHᵢ, -[τ]G2) == 1
+func (k KZG_bls12) BatchVerifyMultiPoints(commitments []*bls12.G1Affine, proofs []*OpeningProof_bls12, points []*emulated.Element[BLS12381Fr]) error {
+	n := len(commitments)
+	if n == 0 || n != len(proofs) || n != len(points) {
+		return errors.New("invalid inputs sizes")
+	}
+	if k.cfg.SubgroupChecks {
+		for i := range commitments {
+			k.assertIsOnG1(commitments[i])
+			k.assertIsOnG1(&proofs[i].H)
+		}
+	}
+	return k.verifyOpenings(commitments, proofs, points)
+}
+
+// verifyOpenings folds the openings (see [kzgVerifier.fold]) and checks the
+// pairing equation.
+func (k KZG_bls12) verifyOpenings(commitments []*bls12.G1Affine, proofs []*OpeningProof_bls12, points []*emulated.Element[BLS12381Fr]) error {
+	quotients := make([]*bls12.G1Affine, len(proofs))
+	claimedValues := make([]*emulated.Element[BLS12381Fr], len(proofs))
+	for i := range proofs {
+		quotients[i] = &proofs[i].H
+		claimedValues[i] = &proofs[i].ClaimedValue
+	}
+	T, H, err := k.fold(commitments, quotients, claimedValues, points)
+	if err != nil {
+		return err
+	}
+	return k.pairingCheck(T, H)
+}
+
+// pairingCheck checks that e(T, G2) * e(H, -[τ]G2) == 1. The Miller loops
+// are not defined at the point at infinity, so T and H are replaced by [1]G1
+// when they are zero and the result is compared with the corresponding
+// precomputed product of e([1]G1, G2) and e([1]G1, -[τ]G2) instead of 1.
+func (k KZG_bls12) pairingCheck(T, H *bls12.G1Affine) error {
+	tInf := k.pr.IsInfinityG1(T)
+	hInf := k.pr.IsInfinityG1(H)
+	T = k.pr.SelectG1(tInf, &k.g1, T)
+	H = k.pr.SelectG1(hInf, &k.g1, H)
+	res, err := k.pr.MultiPairMixed([]*bls12.G1Affine{T, H}, k.lines[:], nil, nil)
+	if err != nil {
+		return fmt.Errorf("pair: %w", err)
+	}
+	expected := k.pr.Ext12.Lookup2(tInf, hInf, &k.eInf[0], &k.eInf[1], &k.eInf[2], &k.eInf[3])
+	k.pr.AssertIsEqual(res, expected)
+	return nil
+}
+
+// assertIsOnG1 asserts that P is in G1, the point at infinity (0,0) included.
+func (k KZG_bls12) assertIsOnG1(P *bls12.G1Affine) {
+	k.pr.AssertIsOnG1(k.pr.SelectG1(k.pr.IsInfinityG1(P), &k.g1, P))
+}
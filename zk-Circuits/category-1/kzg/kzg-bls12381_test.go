@@ -0,0 +1,128 @@
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	kzg_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/kzg"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/profile"
+	"github.com/consensys/gnark/std/math/emulated"
+	bls12 "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bls12381"
+)
+
+type openingCircuit_bls12 struct {
+	Commitment bls12.G1Affine
+	Proof      OpeningProof_bls12
+	Point      emulated.Element[BLS12381Fr]
+	srs        *kzg_bls12381.SRS `gnark:"-"`
+}
+
+func (c *openingCircuit_bls12) Define(api frontend.API) error {
+	kzg, err := NewKZG_bls12(api, c.srs)
+	if err != nil {
+		return err
+	}
+	return kzg.VerifyOpening(&c.Commitment, &c.Proof, &c.Point)
+}
+
+type batchCircuit_bls12 struct {
+	Openings [2]openingCircuit_bls12
+	srs      *kzg_bls12381.SRS `gnark:"-"`
+}
+
+func (c *batchCircuit_bls12) Define(api frontend.API) error {
+	kzg, err := NewKZG_bls12(api, c.srs)
+	if err != nil {
+		return err
+	}
+	var commitments []*bls12.G1Affine
+	var proofs []*OpeningProof_bls12
+	var points []*emulated.Element[BLS12381Fr]
+	for i := range c.Openings {
+		commitments = append(commitments, &c.Openings[i].Commitment)
+		proofs = append(proofs, &c.Openings[i].Proof)
+		points = append(points, &c.Openings[i].Point)
+	}
+	return kzg.BatchVerifyMultiPoints(commitments, proofs, points)
+}
+
+// polynomial_bls12 returns the coefficients of p in 𝔽_r.
+func polynomial_bls12(p []*big.Int) []fr.Element {
+	res := make([]fr.Element, len(p))
+	for i := range p {
+		res[i].SetBigInt(p[i])
+	}
+	return res
+}
+
+// open_bls12 commits to poly, opens it at z and verifies the opening natively.
+func open_bls12(srs *kzg_bls12381.SRS, poly []*big.Int, z *big.Int) (openingCircuit_bls12, error) {
+	p := polynomial_bls12(poly)
+	var point fr.Element
+	point.SetBigInt(z)
+	commitment, err := kzg_bls12381.Commit(p, srs)
+	if err != nil {
+		return openingCircuit_bls12{}, err
+	}
+	proof, err := kzg_bls12381.Open(p, point, srs)
+	if err != nil {
+		return openingCircuit_bls12{}, err
+	}
+	if err := kzg_bls12381.Verify(&commitment, &proof, point, srs); err != nil {
+		return openingCircuit_bls12{}, err
+	}
+	return openingCircuit_bls12{
+		Commitment: bls12.NewG1Affine(commitment),
+		Proof:      NewOpeningProof_bls12(proof),
+		Point:      emulated.ValueOf[BLS12381Fr](point),
+	}, nil
+}
+
+// opening_bls12 implements [kzgTestCurve.opening] for BLS12-381.
+func opening_bls12(tau *big.Int, poly []*big.Int, z, y *big.Int) (frontend.Circuit, frontend.Circuit, error) {
+	srs, err := kzg_bls12381.NewSRS(polySize, tau)
+	if err != nil {
+		return nil, nil, err
+	}
+	witness, err := open_bls12(srs, poly, z)
+	if err != nil {
+		return nil, nil, err
+	}
+	if y != nil {
+		witness.Proof.ClaimedValue = emulated.ValueOf[BLS12381Fr](y)
+	}
+	return &openingCircuit_bls12{srs: srs}, &witness, nil
+}
+
+// batch_bls12 implements [kzgTestCurve.batch] for BLS12-381.
+func batch_bls12(tau *big.Int, polys [2][]*big.Int, zs, witnessZs [2]*big.Int) (frontend.Circuit, frontend.Circuit, error) {
+	srs, err := kzg_bls12381.NewSRS(polySize, tau)
+	if err != nil {
+		return nil, nil, err
+	}
+	var witness batchCircuit_bls12
+	for i := range witness.Openings {
+		if witness.Openings[i], err = open_bls12(srs, polys[i], zs[i]); err != nil {
+			return nil, nil, err
+		}
+		witness.Openings[i].Point = emulated.ValueOf[BLS12381Fr](witnessZs[i])
+	}
+	return &batchCircuit_bls12{srs: srs}, &witness, nil
+}
+
+func BenchmarkKZGVerifyOpening_bls12(b *testing.B) {
+	srs, err := kzg_bls12381.NewSRS(polySize, big.NewInt(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+	c := openingCircuit_bls12{srs: srs}
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  KZG opening verifier on BLS12-381 in a BN254 R1CS circuit: ", p.NbConstraints())
+}
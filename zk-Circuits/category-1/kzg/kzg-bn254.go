@@ -0,0 +1,151 @@
+package kzg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/kzg"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	bn "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bn254"
+)
+
+// OpeningProof_bn is the in-circuit counterpart of a BN254
+// [kzg_bn254.OpeningProof].
+type OpeningProof_bn struct {
+	// H is the commitment to the quotient polynomial (f(X)-f(z))/(X-z).
+	H bn.G1Affine
+	// ClaimedValue is the claimed evaluation f(z).
+	ClaimedValue emulated.Element[emulated.BN254Fr]
+}
+
+func NewOpeningProof_bn(v kzg_bn254.OpeningProof) OpeningProof_bn {
+	return OpeningProof_bn{
+		H:            bn.NewG1Affine(v.H),
+		ClaimedValue: emulated.ValueOf[emulated.BN254Fr](v.ClaimedValue),
+	}
+}
+
+type KZG_bn struct {
+	kzgVerifier[emulated.BN254Fp, emulated.BN254Fr, bn.G1Affine]
+	pr *bn.Pairing
+
+	// verifying key: [1]G1 (in kzgVerifier) and the lines of G2 and -[τ]G2
+	lines [2]bn.FixedLines
+	// e([1]G1, G2)ᵃ⋅e([1]G1, -[τ]G2)ᵇ at index a+2b, see pairingCheck
+	eInf [4]bn.GTEl
+}
+
+// NewKZG_bn returns a verifier of the openings of the commitments of the
+// BN254 SRS srs. Only the first point of G1 and the two points of G2 of srs
+// are used, as circuit constants.
+func NewKZG_bn(api frontend.API, srs *kzg_bn254.SRS, opts ...Option) (*KZG_bn, error) {
+	if len(srs.G1) == 0 {
+		return nil, errors.New("empty SRS")
+	}
+	pairing_bn, err := bn.NewPairing(api)
+	if err != nil {
+		return nil, fmt.Errorf("new pairing: %w", err)
+	}
+	coordinates := func(p *bn.G1Affine) (x, y *emulated.Element[emulated.BN254Fp]) { return &p.X, &p.Y }
+	k, err := newKZGVerifier[emulated.BN254Fp, emulated.BN254Fr](api, bn.NewG1Affine(srs.G1[0]), pairing_bn.MultiScalarMulG1, coordinates, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var tauG2Neg bn254.G2Affine
+	tauG2Neg.Neg(&srs.G2[1])
+	e, err := bn254.Pair([]bn254.G1Affine{srs.G1[0]}, []bn254.G2Affine{srs.G2[0]})
+	if err != nil {
+		return nil, fmt.Errorf("pair: %w", err)
+	}
+	eTau, err := bn254.Pair([]bn254.G1Affine{srs.G1[0]}, []bn254.G2Affine{tauG2Neg})
+	if err != nil {
+		return nil, fmt.Errorf("pair: %w", err)
+	}
+	var one, eBoth bn254.GT
+	one.SetOne()
+	eBoth.Mul(&e, &eTau)
+	return &KZG_bn{
+		kzgVerifier: k,
+		pr:          pairing_bn,
+		lines:       [2]bn.FixedLines{bn.PrecomputeLines(srs.G2[0]), bn.PrecomputeLines(tauG2Neg)},
+		eInf:        [4]bn.GTEl{bn.NewGTEl(one), bn.NewGTEl(e), bn.NewGTEl(eTau), bn.NewGTEl(eBoth)},
+	}, nil
+}
+
+// VerifyOpening verifies the opening proof of the commitment at point. It
+// checks
+//
+//	e(C - [f(z)]G1 + [z]H, G2) * e(H, -[τ]G2) == 1
+func (k KZG_bn) VerifyOpening(commitment *bn.G1Affine, proof *OpeningProof_bn, point *emulated.Element[emulated.BN254Fr]) error {
+	if k.cfg.SubgroupChecks {
+		k.assertIsOnG1(commitment)
+		k.assertIsOnG1(&proof.H)
+	}
+	return k.verifyOpening(commitment, proof, point)
+}
+
+// verifyOpening is [KZG_bn.VerifyOpening] without the subgroup checks.
+func (k KZG_bn) verifyOpening(commitment *bn.G1Affine, proof *OpeningProof_bn, point *emulated.Element[emulated.BN254Fr]) error {
+	return k.verifyOpenings([]*bn.G1Affine{commitment}, []*OpeningProof_bn{proof}, []*emulated.Element[emulated.BN254Fr]{point})
+}
+
+// BatchVerifyMultiPoints verifies the opening proofs of the commitments at
+// the points, the i-th commitment being opened at the i-th point. As in
+// gnark-crypto, the openings are folded with random coefficients λᵢ (derived
+// in-circuit by Fiat-Shamir) so that a single double pairing is computed:
+//
+//	e(∑ᵢλᵢ(Cᵢ - [f(zᵢ)]G1 + [zᵢ]Hᵢ), G2) * e(∑ᵢλᵢHᵢ, -[τ]G2) == 1
+func (k KZG_bn) BatchVerifyMultiPoints(commitments []*bn.G1Affine, proofs []*OpeningProof_bn, points []*emulated.Element[emulated.BN254Fr]) error {
+	n := len(commitments)
+	if n == 0 || n != len(proofs) || n != len(points) {
+		return errors.New("invalid inputs sizes")
+	}
+	if k.cfg.SubgroupChecks {
+		for i := range commitments {
+			k.assertIsOnG1(commitments[i])
+			k.assertIsOnG1(&proofs[i].H)
+		}
+	}
+	return k.verifyOpenings(commitments, proofs, points)
+}
+
+// verifyOpenings folds the openings (see [kzgVerifier.fold]) and checks the
+// pairing equation.
+func (k KZG_bn) verifyOpenings(commitments []*bn.G1Affine, proofs []*OpeningProof_bn, points []*emulated.Element[emulated.BN254Fr]) error {
+	quotients := make([]*bn.G1Affine, len(proofs))
+	claimedValues := make([]*emulated.Element[emulated.BN254Fr], len(proofs))
+	for i := range proofs {
+		quotients[i] = &proofs[i].H
+		claimedValues[i] = &proofs[i].ClaimedValue
+	}
+	T, H, err := k.fold(commitments, quotients, claimedValues, points)
+	if err != nil {
+		return err
+	}
+	return k.pairingCheck(T, H)
+}
+
+// pairingCheck checks that e(T, G2) * e(H, -[τ]G2) == 1. The Miller loops
+// are not defined at the point at infinity, so T and H are replaced by [1]G1
+// when they are zero and the result is compared with the corresponding
+// precomputed product of e([1]G1, G2) and e([1]G1, -[τ]G2) instead of 1.
+func (k KZG_bn) pairingCheck(T, H *bn.G1Affine) error {
+	tInf := k.pr.IsInfinityG1(T)
+	hInf := k.pr.IsInfinityG1(H)
+	T = k.pr.SelectG1(tInf, &k.g1, T)
+	H = k.pr.SelectG1(hInf, &k.g1, H)
+	res, err := k.pr.MultiPairMixed([]*bn.G1Affine{T, H}, k.lines[:], nil, nil)
+	if err != nil {
+		return fmt.Errorf("pair: %w", err)
+	}
+	expected := k.pr.Ext12.Lookup2(tInf, hInf, &k.eInf[0], &k.eInf[1], &k.eInf[2], &k.eInf[3])
+	k.pr.AssertIsEqual(res, expected)
+	return nil
+}
+
+// assertIsOnG1 asserts that P is in G1, the point at infinity (0,0) included.
+func (k KZG_bn) assertIsOnG1(P *bn.G1Affine) {
+	k.pr.AssertIsOnG1(k.pr.SelectG1(k.pr.IsInfinityG1(P), &k.g1, P))
+}
@@ -0,0 +1,128 @@
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/kzg"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/profile"
+	"github.com/consensys/gnark/std/math/emulated"
+	bn "github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/bls_sig/pairing_bn254"
+)
+
+type openingCircuit_bn struct {
+	Commitment bn.G1Affine
+	Proof      OpeningProof_bn
+	Point      emulated.Element[emulated.BN254Fr]
+	srs        *kzg_bn254.SRS `gnark:"-"`
+}
+
+func (c *openingCircuit_bn) Define(api frontend.API) error {
+	kzg, err := NewKZG_bn(api, c.srs)
+	if err != nil {
+		return err
+	}
+	return kzg.VerifyOpening(&c.Commitment, &c.Proof, &c.Point)
+}
+
+type batchCircuit_bn struct {
+	Openings [2]openingCircuit_bn
+	srs      *kzg_bn254.SRS `gnark:"-"`
+}
+
+func (c *batchCircuit_bn) Define(api frontend.API) error {
+	kzg, err := NewKZG_bn(api, c.srs)
+	if err != nil {
+		return err
+	}
+	var commitments []*bn.G1Affine
+	var proofs []*OpeningProof_bn
+	var points []*emulated.Element[emulated.BN254Fr]
+	for i := range c.Openings {
+		commitments = append(commitments, &c.Openings[i].Commitment)
+		proofs = append(proofs, &c.Openings[i].Proof)
+		points = append(points, &c.Openings[i].Point)
+	}
+	return kzg.BatchVerifyMultiPoints(commitments, proofs, points)
+}
+
+// polynomial_bn returns the coefficients of p in 𝔽_r.
+func polynomial_bn(p []*big.Int) []fr.Element {
+	res := make([]fr.Element, len(p))
+	for i := range p {
+		res[i].SetBigInt(p[i])
+	}
+	return res
+}
+
+// open_bn commits to poly, opens it at z and verifies the opening natively.
+func open_bn(srs *kzg_bn254.SRS, poly []*big.Int, z *big.Int) (openingCircuit_bn, error) {
+	p := polynomial_bn(poly)
+	var point fr.Element
+	point.SetBigInt(z)
+	commitment, err := kzg_bn254.Commit(p, srs)
+	if err != nil {
+		return openingCircuit_bn{}, err
+	}
+	proof, err := kzg_bn254.Open(p, point, srs)
+	if err != nil {
+		return openingCircuit_bn{}, err
+	}
+	if err := kzg_bn254.Verify(&commitment, &proof, point, srs); err != nil {
+		return openingCircuit_bn{}, err
+	}
+	return openingCircuit_bn{
+		Commitment: bn.NewG1Affine(commitment),
+		Proof:      NewOpeningProof_bn(proof),
+		Point:      emulated.ValueOf[emulated.BN254Fr](point),
+	}, nil
+}
+
+// opening_bn implements [kzgTestCurve.opening] for BN254.
+func opening_bn(tau *big.Int, poly []*big.Int, z, y *big.Int) (frontend.Circuit, frontend.Circuit, error) {
+	srs, err := kzg_bn254.NewSRS(polySize, tau)
+	if err != nil {
+		return nil, nil, err
+	}
+	witness, err := open_bn(srs, poly, z)
+	if err != nil {
+		return nil, nil, err
+	}
+	if y != nil {
+		witness.Proof.ClaimedValue = emulated.ValueOf[emulated.BN254Fr](y)
+	}
+	return &openingCircuit_bn{srs: srs}, &witness, nil
+}
+
+// batch_bn implements [kzgTestCurve.batch] for BN254.
+func batch_bn(tau *big.Int, polys [2][]*big.Int, zs, witnessZs [2]*big.Int) (frontend.Circuit, frontend.Circuit, error) {
+	srs, err := kzg_bn254.NewSRS(polySize, tau)
+	if err != nil {
+		return nil, nil, err
+	}
+	var witness batchCircuit_bn
+	for i := range witness.Openings {
+		if witness.Openings[i], err = open_bn(srs, polys[i], zs[i]); err != nil {
+			return nil, nil, err
+		}
+		witness.Openings[i].Point = emulated.ValueOf[emulated.BN254Fr](witnessZs[i])
+	}
+	return &batchCircuit_bn{srs: srs}, &witness, nil
+}
+
+func BenchmarkKZGVerifyOpening_bn(b *testing.B) {
+	srs, err := kzg_bn254.NewSRS(polySize, big.NewInt(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+	c := openingCircuit_bn{srs: srs}
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  KZG opening verifier on BN254 in a BN254 R1CS circuit: ", p.NbConstraints())
+}
@@ -0,0 +1,160 @@
+// Package kzg implements in-circuit verifiers of KZG polynomial commitment
+// openings over BN254 and BLS12-381, compatible with the commitments and
+// proofs of gnark-crypto's kzg packages. The pairings are emulated in a BN254
+// circuit.
+//
+// The verification of an opening of a commitment C at a point z with the
+// claimed value y and the quotient commitment H checks
+//
+//	e(C - [y]G1 + [z]H, G2) * e(H, -[τ]G2) == 1
+//
+// where the G2 points come from the SRS and are circuit constants: the lines
+// of G2 and -[τ]G2 are precomputed and the two Miller loops share their
+// squarings (MultiMillerLoopMixed). The G1 combination is computed with a
+// variable-base multi-scalar multiplication using complete formulas, and the
+// commitments and proofs can be the point at infinity (e.g. for constant
+// polynomials).
+package kzg
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/internal/verifier"
+)
+
+// BLS12381Fr provides type parametrisation for the emulated scalar field of
+// BLS12-381 on 4 limbs of width 64bits.
+type BLS12381Fr struct{}
+
+func (fp BLS12381Fr) NbLimbs() uint     { return 4 }
+func (fp BLS12381Fr) BitsPerLimb() uint { return 64 }
+func (fp BLS12381Fr) IsPrime() bool     { return true }
+func (fp BLS12381Fr) Modulus() *big.Int { return ecc.BLS12_381.ScalarField() }
+
+// Option allows to configure the KZG verifiers.
+type Option = verifier.Option
+
+// WithSubgroupChecks enables the in-circuit on-curve and subgroup membership
+// checks of the commitments and of the quotient commitments of the proofs. It
+// must be set when they are not trusted.
+func WithSubgroupChecks() Option {
+	return verifier.WithSubgroupChecks()
+}
+
+// batchChallenges returns n challenges derived from the transcript (see
+// [verifier.BatchChallenges]), the first one being 1.
+func batchChallenges(api frontend.API, n int, transcript []frontend.Variable) ([][]frontend.Variable, error) {
+	λ, err := verifier.BatchChallenges(api, n-1, transcript)
+	if err != nil {
+		return nil, err
+	}
+	return append([][]frontend.Variable{{1}}, λ...), nil
+}
+
+// kzgVerifier is the part of [KZG_bn] and [KZG_bls12] which doesn't depend on
+// the curve: the G1 points are of type G1, with coordinates in the base field
+// Fp, and the scalars are in the scalar field Fr.
+type kzgVerifier[Fp, Fr emulated.FieldParams, G1 any] struct {
+	api frontend.API
+	fp  *emulated.Field[Fp]
+	fr  *emulated.Field[Fr]
+	cfg verifier.Config
+
+	// g1 is [1]G1 of the SRS
+	g1 G1
+	// msm is the multi-scalar multiplication in G1 of the pairing package
+	msm func(points []*G1, scalars [][]frontend.Variable) (*G1, error)
+	// coordinates returns the affine coordinates of a point of G1
+	coordinates func(p *G1) (x, y *emulated.Element[Fp])
+}
+
+func newKZGVerifier[Fp, Fr emulated.FieldParams, G1 any](api frontend.API, g1 G1, msm func([]*G1, [][]frontend.Variable) (*G1, error), coordinates func(*G1) (x, y *emulated.Element[Fp]), opts ...Option) (kzgVerifier[Fp, Fr, G1], error) {
+	fp, err := emulated.NewField[Fp](api)
+	if err != nil {
+		return kzgVerifier[Fp, Fr, G1]{}, fmt.Errorf("new base field: %w", err)
+	}
+	fr, err := emulated.NewField[Fr](api)
+	if err != nil {
+		return kzgVerifier[Fp, Fr, G1]{}, fmt.Errorf("new scalar field: %w", err)
+	}
+	return kzgVerifier[Fp, Fr, G1]{
+		api:         api,
+		fp:          fp,
+		fr:          fr,
+		cfg:         verifier.NewConfig(opts...),
+		g1:          g1,
+		msm:         msm,
+		coordinates: coordinates,
+	}, nil
+}
+
+// fold returns the G1 arguments T and H of the pairing check
+//
+//	e(T, G2) * e(H, -[τ]G2) == 1
+//
+// of the openings of the commitments Cᵢ at the points zᵢ, with the quotient
+// commitments Hᵢ and the claimed values f(zᵢ). As in gnark-crypto, the
+// openings are folded with random coefficients λᵢ so that
+//
+//	T = ∑ᵢλᵢ(Cᵢ - [f(zᵢ)]G1 + [zᵢ]Hᵢ) and H = ∑ᵢλᵢHᵢ
+//
+// where λ₀ = 1 and, if there are several openings, the other λᵢ are derived
+// in-circuit by Fiat-Shamir from all the inputs.
+func (k kzgVerifier[Fp, Fr, G1]) fold(commitments, quotients []*G1, claimedValues, points []*emulated.Element[Fr]) (T, H *G1, err error) {
+	n := len(commitments)
+	if n == 1 {
+		// C - [f(z)]G1 + [z]H
+		T, err = k.msm(
+			[]*G1{commitments[0], &k.g1, quotients[0]},
+			[][]frontend.Variable{{1}, k.toBits(k.fr.Neg(claimedValues[0])), k.toBits(points[0])},
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("msm: %w", err)
+		}
+		return T, quotients[0], nil
+	}
+
+	var transcript []frontend.Variable
+	for i := range commitments {
+		cx, cy := k.coordinates(commitments[i])
+		hx, hy := k.coordinates(quotients[i])
+		transcript = verifier.AppendCanonical(k.fp, transcript, cx, cy, hx, hy)
+		transcript = verifier.AppendCanonical(k.fr, transcript, claimedValues[i], points[i])
+	}
+	λ, err := batchChallenges(k.api, n, transcript)
+	if err != nil {
+		return nil, nil, fmt.Errorf("challenges: %w", err)
+	}
+
+	// ∑ᵢλᵢCᵢ - [∑ᵢλᵢf(zᵢ)]G1 + ∑ᵢ[λᵢzᵢ]Hᵢ and ∑ᵢλᵢHᵢ
+	points1 := make([]*G1, 0, 2*n+1)
+	scalars1 := make([][]frontend.Variable, 0, 2*n+1)
+	foldedEvals := k.fr.Zero()
+	for i := range commitments {
+		λi := k.fr.FromBits(λ[i]...)
+		foldedEvals = k.fr.Add(foldedEvals, k.fr.MulMod(λi, claimedValues[i]))
+		points1 = append(points1, commitments[i], quotients[i])
+		scalars1 = append(scalars1, λ[i], k.toBits(k.fr.MulMod(λi, points[i])))
+	}
+	points1 = append(points1, &k.g1)
+	scalars1 = append(scalars1, k.toBits(k.fr.Neg(foldedEvals)))
+
+	T, err = k.msm(points1, scalars1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("msm: %w", err)
+	}
+	H, err = k.msm(quotients, λ)
+	if err != nil {
+		return nil, nil, fmt.Errorf("msm: %w", err)
+	}
+	return T, H, nil
+}
+
+// toBits returns the bits of s in little-endian order.
+func (k kzgVerifier[Fp, Fr, G1]) toBits(s *emulated.Element[Fr]) []frontend.Variable {
+	return k.fr.ToBits(k.fr.Reduce(s))
+}
@@ -0,0 +1,123 @@
+package kzg
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+const polySize = 16
+
+// kzgTestCurve gives the fixtures of a curve to the table-driven tests. The
+// polynomials, the points and the trapdoors are integers, which are reduced
+// modulo the order r of G1.
+type kzgTestCurve struct {
+	name string
+	r    *big.Int
+	// opening returns the circuit and the witness verifying the opening of
+	// poly at z, with the SRS of trapdoor tau. The opening is first verified
+	// natively and, if y is not nil, the claimed value is then replaced by y.
+	opening func(tau *big.Int, poly []*big.Int, z, y *big.Int) (circuit, witness frontend.Circuit, err error)
+	// batch returns the circuit and the witness verifying the openings of
+	// polys at zs, with the SRS of trapdoor tau. The points given in the
+	// witness are witnessZs.
+	batch func(tau *big.Int, polys [2][]*big.Int, zs, witnessZs [2]*big.Int) (circuit, witness frontend.Circuit, err error)
+}
+
+var testCurves = []kzgTestCurve{
+	{"BN254", ecc.BN254.ScalarField(), opening_bn, batch_bn},
+	{"BLS12-381", ecc.BLS12_381.ScalarField(), opening_bls12, batch_bls12},
+}
+
+func randomScalar(assert *test.Assert, r *big.Int) *big.Int {
+	s, err := rand.Int(rand.Reader, r)
+	assert.NoError(err)
+	return s
+}
+
+func randomPolynomial(assert *test.Assert, r *big.Int) []*big.Int {
+	p := make([]*big.Int, polySize)
+	for i := range p {
+		p[i] = randomScalar(assert, r)
+	}
+	return p
+}
+
+func zeroPolynomial() []*big.Int {
+	p := make([]*big.Int, polySize)
+	for i := range p {
+		p[i] = new(big.Int)
+	}
+	return p
+}
+
+// evaluate returns p(z) mod r.
+func evaluate(p []*big.Int, z, r *big.Int) *big.Int {
+	res := new(big.Int)
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(res, z).Add(res, p[i]).Mod(res, r)
+	}
+	return res
+}
+
+func TestKZG_VerifyOpening(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, c := range testCurves {
+		tau := randomScalar(assert, c.r)
+		z := randomScalar(assert, c.r)
+
+		// a polynomial vanishing at z, i.e. with a zero claimed value
+		zero := randomPolynomial(assert, c.r)
+		zero[0].Sub(zero[0], evaluate(zero, z, c.r)).Mod(zero[0], c.r)
+
+		// a constant polynomial, whose quotient commitment is the point at
+		// infinity
+		constant := zeroPolynomial()
+		constant[0] = randomScalar(assert, c.r)
+
+		for _, tc := range []struct {
+			name string
+			poly []*big.Int
+		}{
+			{"random", randomPolynomial(assert, c.r)},
+			{"zero claimed value", zero},
+			{"constant", constant},
+			{"zero", zeroPolynomial()},
+		} {
+			circuit, witness, err := c.opening(tau, tc.poly, z, nil)
+			assert.NoError(err, c.name, tc.name)
+			err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+			assert.NoError(err, c.name, tc.name)
+
+			// wrong claimed value
+			circuit, witness, err = c.opening(tau, tc.poly, z, big.NewInt(1))
+			assert.NoError(err, c.name, tc.name)
+			err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+			assert.Error(err, c.name, tc.name)
+		}
+	}
+}
+
+func TestKZG_BatchVerifyMultiPoints(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, c := range testCurves {
+		tau := randomScalar(assert, c.r)
+		polys := [2][]*big.Int{randomPolynomial(assert, c.r), randomPolynomial(assert, c.r)}
+		zs := [2]*big.Int{randomScalar(assert, c.r), randomScalar(assert, c.r)}
+
+		circuit, witness, err := c.batch(tau, polys, zs, zs)
+		assert.NoError(err, c.name)
+		err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err, c.name)
+
+		// swapped points
+		circuit, witness, err = c.batch(tau, polys, zs, [2]*big.Int{zs[1], zs[0]})
+		assert.NoError(err, c.name)
+		err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err, c.name)
+	}
+}
@@ -0,0 +1,67 @@
+package sha256
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+)
+
+// ExpandMsgXmd implements expand_message_xmd from [RFC9380] (Section 5.3.1)
+// with SHA-256. It returns lenInBytes pseudo-random bytes derived from the
+// message msg and the domain separation tag dst.
+//
+// [RFC9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func ExpandMsgXmd(api frontend.API, msg []frontend.Variable, dst []byte, lenInBytes int) ([]frontend.Variable, error) {
+	ell := (lenInBytes + Size - 1) / Size
+	if ell > 255 {
+		return nil, errors.New("invalid lenInBytes")
+	}
+	if len(dst) > 255 {
+		return nil, errors.New("invalid domain size (>255 bytes)")
+	}
+
+	// DST_prime = DST ∥ I2OSP(len(DST), 1)
+	dstPrime := make([]frontend.Variable, len(dst)+1)
+	for i := range dst {
+		dstPrime[i] = dst[i]
+	}
+	dstPrime[len(dst)] = len(dst)
+
+	// b₀ = H(Z_pad ∥ msg ∥ l_i_b_str ∥ I2OSP(0, 1) ∥ DST_prime)
+	in := make([]frontend.Variable, BlockSize, BlockSize+len(msg)+3+len(dstPrime))
+	for i := range in {
+		in[i] = 0
+	}
+	in = append(in, msg...)
+	in = append(in, lenInBytes>>8, lenInBytes&0xff, 0)
+	in = append(in, dstPrime...)
+	b0 := Sum(api, in)
+
+	// b₁ = H(b₀ ∥ I2OSP(1, 1) ∥ DST_prime)
+	in = append(append(append([]frontend.Variable{}, b0...), 1), dstPrime...)
+	bi := Sum(api, in)
+	res := append([]frontend.Variable{}, bi...)
+
+	// bᵢ = H(strxor(b₀, bᵢ₋₁) ∥ I2OSP(i, 1) ∥ DST_prime)
+	for i := 2; i <= ell; i++ {
+		in = make([]frontend.Variable, 0, Size+1+len(dstPrime))
+		for j := range b0 {
+			in = append(in, xorBytes(api, b0[j], bi[j]))
+		}
+		in = append(append(in, i), dstPrime...)
+		bi = Sum(api, in)
+		res = append(res, bi...)
+	}
+
+	return res[:lenInBytes], nil
+}
+
+func xorBytes(api frontend.API, a, b frontend.Variable) frontend.Variable {
+	aBits := bits.ToBinary(api, a, bits.WithNbDigits(8))
+	bBits := bits.ToBinary(api, b, bits.WithNbDigits(8))
+	for i := range aBits {
+		aBits[i] = api.Xor(aBits[i], bBits[i])
+	}
+	return bits.FromBinary(api, aBits)
+}
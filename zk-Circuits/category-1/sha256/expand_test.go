@@ -0,0 +1,56 @@
+package sha256
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/field/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type expandMsgXmdCircuit struct {
+	Msg      []frontend.Variable
+	Expected []frontend.Variable
+
+	dst []byte
+}
+
+func (c *expandMsgXmdCircuit) Define(api frontend.API) error {
+	res, err := ExpandMsgXmd(api, c.Msg, c.dst, len(c.Expected))
+	if err != nil {
+		return err
+	}
+	for i := range res {
+		api.AssertIsEqual(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+func TestExpandMsgXmd(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("abc")
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	for _, lenInBytes := range []int{32, 96, 128} {
+		expected, err := hash.ExpandMsgXmd(msg, dst, lenInBytes)
+		assert.NoError(err)
+
+		circuit := expandMsgXmdCircuit{
+			Msg:      make([]frontend.Variable, len(msg)),
+			Expected: make([]frontend.Variable, lenInBytes),
+			dst:      dst,
+		}
+		witness := expandMsgXmdCircuit{
+			Msg:      make([]frontend.Variable, len(msg)),
+			Expected: make([]frontend.Variable, lenInBytes),
+		}
+		for i := range msg {
+			witness.Msg[i] = msg[i]
+		}
+		for i := range expected {
+			witness.Expected[i] = expected[i]
+		}
+		err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
@@ -0,0 +1,234 @@
+// Package sha256 implements the SHA-256 hash function (FIPS 180-4) in-circuit.
+//
+// Bytes are represented as frontend.Variable holding values in [0, 256). The
+// input length is fixed at circuit compile time.
+package sha256
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+)
+
+// Size is the size of a SHA-256 digest in bytes.
+const Size = 32
+
+// BlockSize is the block size of SHA-256 in bytes.
+const BlockSize = 64
+
+var _K = [64]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5, 0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3, 0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc, 0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7, 0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13, 0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3, 0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5, 0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208, 0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+var _IV = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+// word is a 32-bit word given by its bits in little-endian order.
+type word [32]frontend.Variable
+
+// Sum returns the SHA-256 digest of data as Size bytes.
+//
+// Every non-constant byte of data is range checked. Blocks made only of
+// constants (e.g. a zero-padding prefix) are hashed at compile time and cost no
+// constraint.
+func Sum(api frontend.API, data []frontend.Variable) []frontend.Variable {
+	// padding: data ∥ 0x80 ∥ 0x00... ∥ I2OSP(8⋅len(data), 8)
+	padded := make([]frontend.Variable, len(data), len(data)+2*BlockSize)
+	copy(padded, data)
+	padded = append(padded, 0x80)
+	for (len(padded)+8)%BlockSize != 0 {
+		padded = append(padded, 0)
+	}
+	l := uint64(len(data)) * 8
+	for i := 7; i >= 0; i-- {
+		padded = append(padded, (l>>(8*i))&0xff)
+	}
+
+	var h [8]word
+	for i := range h {
+		h[i] = constWord(_IV[i])
+	}
+
+	for i := 0; i < len(padded); i += BlockSize {
+		var block [16]word
+		for j := range block {
+			for k := 0; k < 4; k++ {
+				// big-endian: the first byte holds the most significant bits
+				b := toBits(api, padded[i+4*j+k], 8)
+				copy(block[j][24-8*k:32-8*k], b)
+			}
+		}
+		h = compress(api, h, block)
+	}
+
+	digest := make([]frontend.Variable, 0, Size)
+	for i := range h {
+		for k := 3; k >= 0; k-- {
+			digest = append(digest, fromBits(api, h[i][8*k:8*k+8]))
+		}
+	}
+	return digest
+}
+
+// compress applies the SHA-256 compression function to the state h and the
+// message block.
+func compress(api frontend.API, h [8]word, block [16]word) [8]word {
+	// message schedule
+	var w [64]word
+	copy(w[:16], block[:])
+	for t := 16; t < 64; t++ {
+		// σ₀(x) = ROTR⁷(x) ⊕ ROTR¹⁸(x) ⊕ SHR³(x)
+		s0 := xor3(api, rotr(w[t-15], 7), rotr(w[t-15], 18), shr(w[t-15], 3))
+		// σ₁(x) = ROTR¹⁷(x) ⊕ ROTR¹⁹(x) ⊕ SHR¹⁰(x)
+		s1 := xor3(api, rotr(w[t-2], 17), rotr(w[t-2], 19), shr(w[t-2], 10))
+		w[t] = addMod32(api, 2,
+			fromBits(api, s1[:]),
+			fromBits(api, w[t-7][:]),
+			fromBits(api, s0[:]),
+			fromBits(api, w[t-16][:]),
+		)
+	}
+
+	a, b, c, d, e, f, g, hh := h[0], h[1], h[2], h[3], h[4], h[5], h[6], h[7]
+	for t := 0; t < 64; t++ {
+		// Σ₁(e) = ROTR⁶(e) ⊕ ROTR¹¹(e) ⊕ ROTR²⁵(e)
+		S1 := xor3(api, rotr(e, 6), rotr(e, 11), rotr(e, 25))
+		// T₁ = h + Σ₁(e) + Ch(e, f, g) + Kₜ + Wₜ
+		t1 := api.Add(
+			fromBits(api, hh[:]),
+			fromBits(api, S1[:]),
+			ch(api, e, f, g),
+			_K[t],
+			fromBits(api, w[t][:]),
+		)
+		// Σ₀(a) = ROTR²(a) ⊕ ROTR¹³(a) ⊕ ROTR²²(a)
+		S0 := xor3(api, rotr(a, 2), rotr(a, 13), rotr(a, 22))
+		// T₂ = Σ₀(a) + Maj(a, b, c)
+		t2 := api.Add(fromBits(api, S0[:]), maj(api, a, b, c))
+
+		hh = g
+		g = f
+		f = e
+		// T₁ has at most 5 summands and T₁+T₂ at most 7, so that 3 carry
+		// bits are enough in both cases.
+		e = addMod32(api, 3, api.Add(fromBits(api, d[:]), t1))
+		d = c
+		c = b
+		b = a
+		a = addMod32(api, 3, api.Add(t1, t2))
+	}
+
+	return [8]word{
+		addMod32(api, 1, fromBits(api, h[0][:]), fromBits(api, a[:])),
+		addMod32(api, 1, fromBits(api, h[1][:]), fromBits(api, b[:])),
+		addMod32(api, 1, fromBits(api, h[2][:]), fromBits(api, c[:])),
+		addMod32(api, 1, fromBits(api, h[3][:]), fromBits(api, d[:])),
+		addMod32(api, 1, fromBits(api, h[4][:]), fromBits(api, e[:])),
+		addMod32(api, 1, fromBits(api, h[5][:]), fromBits(api, f[:])),
+		addMod32(api, 1, fromBits(api, h[6][:]), fromBits(api, g[:])),
+		addMod32(api, 1, fromBits(api, h[7][:]), fromBits(api, hh[:])),
+	}
+}
+
+// ch returns Ch(e, f, g) = (e ∧ f) ⊕ (¬e ∧ g) as an integer. Bitwise, it is
+// computed as g + e⋅(f - g), at the cost of one constraint per bit.
+func ch(api frontend.API, e, f, g word) frontend.Variable {
+	var res word
+	for i := range res {
+		res[i] = api.Add(g[i], api.Mul(e[i], api.Sub(f[i], g[i])))
+	}
+	return fromBits(api, res[:])
+}
+
+// maj returns Maj(a, b, c) = (a ∧ b) ⊕ (a ∧ c) ⊕ (b ∧ c) as an integer.
+// Bitwise, it is computed as bc + a⋅(b + c - 2bc), at the cost of two
+// constraints per bit.
+func maj(api frontend.API, a, b, c word) frontend.Variable {
+	var res word
+	for i := range res {
+		bc := api.Mul(b[i], c[i])
+		t := api.Sub(api.Add(b[i], c[i]), api.Mul(bc, 2))
+		res[i] = api.Add(bc, api.Mul(a[i], t))
+	}
+	return fromBits(api, res[:])
+}
+
+func xor3(api frontend.API, x, y, z word) word {
+	var res word
+	for i := range res {
+		res[i] = api.Xor(api.Xor(x[i], y[i]), z[i])
+	}
+	return res
+}
+
+// rotr returns ROTRⁿ(x). It is free.
+func rotr(x word, n int) word {
+	var res word
+	for i := range res {
+		res[i] = x[(i+n)%32]
+	}
+	return res
+}
+
+// shr returns SHRⁿ(x). It is free.
+func shr(x word, n int) word {
+	var res word
+	for i := range res {
+		if i+n < 32 {
+			res[i] = x[i+n]
+		} else {
+			res[i] = 0
+		}
+	}
+	return res
+}
+
+// addMod32 returns the sum of the given integers modulo 2³². The integers are
+// assumed to be 32-bit and nbCarries must be large enough to hold the carry of
+// the sum.
+func addMod32(api frontend.API, nbCarries int, xs ...frontend.Variable) word {
+	var res word
+	s := api.Add(xs[0], 0, xs[1:]...)
+	copy(res[:], toBits(api, s, 32+nbCarries)[:32])
+	return res
+}
+
+func constWord(c uint32) word {
+	var res word
+	for i := range res {
+		res[i] = (c >> i) & 1
+	}
+	return res
+}
+
+// toBits decomposes v into n little-endian bits, asserting v < 2ⁿ. Constants
+// are decomposed at compile time (modulo 2ⁿ).
+func toBits(api frontend.API, v frontend.Variable, n int) []frontend.Variable {
+	if c, ok := api.Compiler().ConstantValue(v); ok {
+		res := make([]frontend.Variable, n)
+		for i := range res {
+			res[i] = c.Bit(i)
+		}
+		return res
+	}
+	return bits.ToBinary(api, v, bits.WithNbDigits(n))
+}
+
+// fromBits recomposes the little-endian bits bs. It is free and does not
+// assert the inputs are bits.
+func fromBits(api frontend.API, bs []frontend.Variable) frontend.Variable {
+	var res frontend.Variable = 0
+	for i := range bs {
+		res = api.Add(res, api.Mul(bs[i], new(big.Int).Lsh(big.NewInt(1), uint(i))))
+	}
+	return res
+}
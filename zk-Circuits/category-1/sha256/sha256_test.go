@@ -0,0 +1,78 @@
+package sha256
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type sumCircuit struct {
+	In       []frontend.Variable
+	Expected [Size]frontend.Variable
+}
+
+func (c *sumCircuit) Define(api frontend.API) error {
+	res := Sum(api, c.In)
+	for i := range res {
+		api.AssertIsEqual(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+func TestSum(t *testing.T) {
+	// lengths around the padding boundaries
+	for _, n := range []int{0, 3, 55, 56, 64, 119, 130} {
+		t.Run(fmt.Sprintf("len=%d", n), func(t *testing.T) {
+			assert := test.NewAssert(t)
+			msg := make([]byte, n)
+			_, err := rand.Read(msg)
+			assert.NoError(err)
+			digest := sha256.Sum256(msg)
+
+			witness := sumCircuit{In: make([]frontend.Variable, n)}
+			for i := range msg {
+				witness.In[i] = msg[i]
+			}
+			for i := range digest {
+				witness.Expected[i] = digest[i]
+			}
+			err = test.IsSolved(&sumCircuit{In: make([]frontend.Variable, n)}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestSumWrongDigest(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("abc")
+	digest := sha256.Sum256(msg)
+	digest[0] ^= 1
+
+	witness := sumCircuit{In: make([]frontend.Variable, len(msg))}
+	for i := range msg {
+		witness.In[i] = msg[i]
+	}
+	for i := range digest {
+		witness.Expected[i] = digest[i]
+	}
+	err := test.IsSolved(&sumCircuit{In: make([]frontend.Variable, len(msg))}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestSumInvalidByte(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("abc")
+	digest := sha256.Sum256(msg)
+
+	witness := sumCircuit{In: []frontend.Variable{msg[0], msg[1], int(msg[2]) + 256}}
+	for i := range digest {
+		witness.Expected[i] = digest[i]
+	}
+	err := test.IsSolved(&sumCircuit{In: make([]frontend.Variable, len(msg))}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -69,6 +69,37 @@ func (p *G1Affine) AddAssign(api frontend.API, p1 G1Affine) *G1Affine {
 	return p
 }
 
+// AddUnified adds p1 to p and returns p. Contrary to [G1Affine.AddAssign], the
+// addition is complete: p and p1 may be equal or opposite, and either can be
+// (0,0), which is conventionally taken as the point at infinity as (0,0) is
+// not on the curve. The result is (0,0) if p = -p1.
+func (p *G1Affine) AddUnified(api frontend.API, p1 G1Affine) *G1Affine {
+	pInf := api.And(api.IsZero(p.X), api.IsZero(p.Y))
+	p1Inf := api.And(api.IsZero(p1.X), api.IsZero(p1.Y))
+
+	// lambda = (p1.y-p.y)/(p1.x-p.x) if p.x ≠ p1.x and 3p.x²/2p.y if p = p1
+	xEq := api.IsZero(api.Sub(p1.X, p.X))
+	num := api.Select(xEq, api.Mul(p.X, p.X, 3), api.Sub(p1.Y, p.Y))
+	den := api.Select(xEq, api.Mul(p.Y, 2), api.Sub(p1.X, p.X))
+	// p = -p1 if and only if p.x = p1.x and p.y+p1.y = 0. In this case, or
+	// when p or p1 is (0,0), den may be zero and is replaced by a dummy 1.
+	isInf := api.And(xEq, api.IsZero(api.Add(p.Y, p1.Y)))
+	dummy := api.Or(isInf, api.Or(pInf, p1Inf))
+	lambda := api.Div(num, api.Select(dummy, 1, den))
+
+	// xr = lambda**2-p.x-p1.x
+	xr := api.Sub(api.Mul(lambda, lambda), api.Add(p.X, p1.X))
+
+	// yr = lambda(p.x-xr) - p.y
+	yr := api.Sub(api.Mul(lambda, api.Sub(p.X, xr)), p.Y)
+
+	res := G1Affine{X: api.Select(isInf, 0, xr), Y: api.Select(isInf, 0, yr)}
+	res.Select(api, pInf, p1, res)
+	res.Select(api, p1Inf, *p, res)
+	p.X, p.Y = res.X, res.Y
+	return p
+}
+
 // AddAssign adds 2 point in Jacobian coordinates
 // p=p, a=p1
 func (p *G1Jac) AddAssign(api frontend.API, p1 G1Jac) *G1Jac {
@@ -420,6 +451,12 @@ func (p *G1Affine) AssertIsEqual(api frontend.API, other G1Affine) {
 	api.AssertIsEqual(p.Y, other.Y)
 }
 
+// AssertIsOnCurve constrains p to be on the curve y²=x³+1. In particular, p
+// can't be (0,0).
+func (p *G1Affine) AssertIsOnCurve(api frontend.API) {
+	api.AssertIsEqual(api.Mul(p.Y, p.Y), api.Add(api.Mul(p.X, p.X, p.X), 1))
+}
+
 // DoubleAndAdd computes 2*p1+p in affine coords
 func (p *G1Affine) DoubleAndAdd(api frontend.API, p1, p2 *G1Affine) *G1Affine {
 
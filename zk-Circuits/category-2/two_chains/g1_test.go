@@ -106,6 +106,56 @@ func TestAddAssignAffineG1(t *testing.T) {
 
 }
 
+// -------------------------------------------------------------------------------------------------
+// Add affine (complete)
+
+type g1AddUnified struct {
+	A, B G1Affine
+	C    G1Affine `gnark:",public"`
+}
+
+func (circuit *g1AddUnified) Define(api frontend.API) error {
+	expected := circuit.A
+	expected.AddUnified(api, circuit.B)
+	expected.AssertIsEqual(api, circuit.C)
+	return nil
+}
+
+func TestAddUnifiedG1(t *testing.T) {
+
+	_a := randomPointG1()
+	_b := randomPointG1()
+	var a, b, aNeg, aDouble, aPlusB, infinity bls12377.G1Affine
+	a.FromJacobian(&_a)
+	b.FromJacobian(&_b)
+	aNeg.Neg(&a)
+	aDouble.Add(&a, &a)
+	aPlusB.Add(&a, &b)
+	// the point of order 2
+	var twoTorsion bls12377.G1Affine
+	twoTorsion.X.SetOne()
+	twoTorsion.X.Neg(&twoTorsion.X)
+
+	assert := test.NewAssert(t)
+	for _, tc := range []struct {
+		a, b, c *bls12377.G1Affine
+	}{
+		{&a, &b, &aPlusB},
+		{&a, &a, &aDouble},
+		{&a, &aNeg, &infinity},
+		{&a, &infinity, &a},
+		{&infinity, &b, &b},
+		{&infinity, &infinity, &infinity},
+		{&twoTorsion, &twoTorsion, &infinity},
+	} {
+		var circuit, witness g1AddUnified
+		witness.A.Assign(tc.a)
+		witness.B.Assign(tc.b)
+		witness.C.Assign(tc.c)
+		assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+	}
+}
+
 // -------------------------------------------------------------------------------------------------
 // Double Jacobian
 
@@ -0,0 +1,137 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package two_chains
+
+import (
+	"errors"
+	"fmt"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	groth16_bls12377 "github.com/consensys/gnark/backend/groth16/bls12-377"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Groth16Proof represents a BLS12-377 Groth16 proof
+// Notation follows Figure 4. in DIZK paper https://eprint.iacr.org/2018/691.pdf
+type Groth16Proof struct {
+	Ar, Krs G1Affine
+	Bs      G2Affine
+}
+
+// Groth16VerifyingKey represents a BLS12-377 Groth16 verifying key
+// Notation follows Figure 4. in DIZK paper https://eprint.iacr.org/2018/691.pdf
+type Groth16VerifyingKey struct {
+	// e(α, β)
+	E GT
+
+	// -[γ]2, -[δ]2
+	G2 struct {
+		GammaNeg, DeltaNeg G2Affine
+	}
+
+	// [Kvk]1
+	G1 struct {
+		K []G1Affine // The indexes correspond to the public wires
+	}
+}
+
+// Verifier verifies BLS12-377 proofs in a BW6-761 circuit.
+type Verifier struct {
+	api frontend.API
+}
+
+// NewVerifier returns a new Verifier.
+func NewVerifier(api frontend.API) *Verifier {
+	return &Verifier{api: api}
+}
+
+// VerifyGroth16 verifies the Groth16 proof for the public inputs (without the
+// ONE_WIRE), i.e. checks that
+//
+//	e(Ar, Bs) * e(Krs, -[δ]2) * e(Σx.[Kvk(t)]1, -[γ]2) == e(α, β)
+//
+// The public inputs linear combination uses complete additions. Zero public
+// inputs are skipped. The final exponentiation is the complete
+// FinalExponentiationSafe, so a degenerate Miller loop output can't satisfy
+// the check.
+//
+// The verifying key vk is not constrained by this function: it MUST be a
+// public input of the outer circuit or embedded in it as constants. Otherwise
+// the prover could choose a verifying key for which any proof verifies.
+//
+// This function doesn't check that the points of the proof are in the correct
+// subgroups.
+func (v *Verifier) VerifyGroth16(vk Groth16VerifyingKey, proof Groth16Proof, publicInputs []frontend.Variable) error {
+	api := v.api
+	if len(vk.G1.K) == 0 {
+		return errors.New("invalid verifying key: VerifyingKey.G1 must be initialized before compiling circuit")
+	}
+	if len(publicInputs) != len(vk.G1.K)-1 {
+		return fmt.Errorf("invalid number of public inputs: got %d, expected %d", len(publicInputs), len(vk.G1.K)-1)
+	}
+
+	// compute kSum = Σx.[Kvk(t)]1
+	// kSum = Kvk[0] (assumes ONE_WIRE is at position 0)
+	kSum := vk.G1.K[0]
+	for i, x := range publicInputs {
+		kSum = addScalarMul(api, kSum, vk.G1.K[i+1], x)
+	}
+
+	// compute e(Σx.[Kvk(t)]1, -[γ]2) * e(Krs,-[δ]2) * e(Ar,Bs)
+	ml, err := MillerLoop(api, []G1Affine{kSum, proof.Krs, proof.Ar}, []G2Affine{vk.G2.GammaNeg, vk.G2.DeltaNeg, proof.Bs})
+	if err != nil {
+		return err
+	}
+	pairing := FinalExponentiationSafe(api, ml)
+
+	// vk.E must be equal to pairing
+	vk.E.AssertIsEqual(api, pairing)
+	return nil
+}
+
+// Assign values to the "in-circuit" Groth16Proof from a "out-of-circuit" proof
+func (proof *Groth16Proof) Assign(p *groth16_bls12377.Proof) {
+	proof.Ar.Assign(&p.Ar)
+	proof.Krs.Assign(&p.Krs)
+	proof.Bs.Assign(&p.Bs)
+}
+
+// Assign values to the "in-circuit" Groth16VerifyingKey from a "out-of-circuit"
+// verifying key. The verifying keys of circuits with commitments are not
+// supported.
+func (vk *Groth16VerifyingKey) Assign(ovk *groth16_bls12377.VerifyingKey) error {
+	if ovk.CommitmentInfo.Is() {
+		return errors.New("commitments are not supported")
+	}
+
+	e, err := bls12377.Pair([]bls12377.G1Affine{ovk.G1.Alpha}, []bls12377.G2Affine{ovk.G2.Beta})
+	if err != nil {
+		return err
+	}
+	vk.E.Assign(&e)
+
+	vk.G1.K = make([]G1Affine, len(ovk.G1.K))
+	for i := 0; i < len(ovk.G1.K); i++ {
+		vk.G1.K[i].Assign(&ovk.G1.K[i])
+	}
+	var deltaNeg, gammaNeg bls12377.G2Affine
+	deltaNeg.Neg(&ovk.G2.Delta)
+	gammaNeg.Neg(&ovk.G2.Gamma)
+	vk.G2.DeltaNeg.Assign(&deltaNeg)
+	vk.G2.GammaNeg.Assign(&gammaNeg)
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package two_chains
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bls12377 "github.com/consensys/gnark/backend/groth16/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/profile"
+	"github.com/consensys/gnark/test"
+)
+
+// innerCircuit proves the knowledge of a cube root X of Y.
+type innerCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *innerCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(circuit.Y, api.Mul(circuit.X, circuit.X, circuit.X))
+	return nil
+}
+
+// innerGroth16Proof returns a verifying key, a proof and its public witness
+// for the inner circuit with the secret input x.
+func innerGroth16Proof(x int) (*groth16_bls12377.VerifyingKey, *groth16_bls12377.Proof, fr.Vector, error) {
+	ccs, err := frontend.Compile(ecc.BLS12_377.ScalarField(), r1cs.NewBuilder, &innerCircuit{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pk, vk, err := groth16.Setup(ccs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	w, err := frontend.NewWitness(&innerCircuit{X: x, Y: x * x * x}, ecc.BLS12_377.ScalarField())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	proof, err := groth16.Prove(ccs, pk, w)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pw, err := w.Public()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := groth16.Verify(proof, vk, pw); err != nil {
+		return nil, nil, nil, err
+	}
+	return vk.(*groth16_bls12377.VerifyingKey), proof.(*groth16_bls12377.Proof), pw.Vector().(fr.Vector), nil
+}
+
+type verifierGroth16 struct {
+	InnerProof Groth16Proof
+	InnerVk    Groth16VerifyingKey `gnark:",public"`
+	Y          frontend.Variable   `gnark:",public"`
+}
+
+func (circuit *verifierGroth16) Define(api frontend.API) error {
+	verifier := NewVerifier(api)
+	return verifier.VerifyGroth16(circuit.InnerVk, circuit.InnerProof, []frontend.Variable{circuit.Y})
+}
+
+func TestVerifyGroth16(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, x := range []int{3, 0} {
+		vk, proof, pw, err := innerGroth16Proof(x)
+		assert.NoError(err)
+
+		var circuit, witness verifierGroth16
+		assert.NoError(circuit.InnerVk.Assign(vk))
+		assert.NoError(witness.InnerVk.Assign(vk))
+		witness.InnerProof.Assign(proof)
+		witness.Y = pw[0].BigInt(new(big.Int))
+		assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+
+		// wrong public input
+		witness.Y = x*x*x + 1
+		assert.SolvingFailed(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+
+		// wrong proof
+		witness.Y = pw[0].BigInt(new(big.Int))
+		witness.InnerProof.Ar, witness.InnerProof.Krs = witness.InnerProof.Krs, witness.InnerProof.Ar
+		assert.SolvingFailed(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+	}
+}
+
+// bench
+func BenchmarkVerifyGroth16(b *testing.B) {
+	vk, _, _, err := innerGroth16Proof(3)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var c verifierGroth16
+	if err := c.InnerVk.Assign(vk); err != nil {
+		b.Fatal(err)
+	}
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  Groth16 verifier on BLS12-377 in a BW6-761 R1CS circuit: ", p.NbConstraints())
+}
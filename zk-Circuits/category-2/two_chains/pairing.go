@@ -18,7 +18,10 @@ package two_chains
 
 import (
 	"errors"
+	"math/big"
 
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark/frontend"
 )
 
@@ -180,8 +183,35 @@ func MillerLoop(api frontend.API, P []G1Affine, Q []G2Affine) (GT, error) {
 // where d = (p¹²-1)/r = (p¹²-1)/Φ₁₂(p) ⋅ Φ₁₂(p)/r = (p⁶-1)(p²+1)(p⁴ - p² +1)/r
 // we use instead d=s ⋅ (p⁶-1)(p²+1)(p⁴ - p² +1)/r
 // where s is the cofactor 3 (Hayashida et al.)
+//
+// This is the unsafe version of the method: Karabina's decompression assumes
+// non-zero coordinates, which does not hold when e1 is in a proper subfield
+// (e.g. e1 ∈ E6 or e1 = 1). The circuit is then either unsatisfiable or the
+// result is not uniquely determined. It is applicable to the result of
+// MillerLoop for a single pair of points in the correct subgroups. See
+// FinalExponentiationSafe otherwise.
 func FinalExponentiation(api frontend.API, e1 GT) GT {
-	const genT = 9586122913090633729
+	return finalExponentiation(api, e1, false)
+}
+
+// FinalExponentiationSafe computes the same exponentiation as
+// FinalExponentiation and is complete: the edge cases of the easy part and of
+// Karabina's decompression are handled with selects, so that any e1
+// (including 0, 1 and the elements of E6) has a unique valid result.
+func FinalExponentiationSafe(api frontend.API, e1 GT) GT {
+	return finalExponentiation(api, e1, true)
+}
+
+func finalExponentiation(api frontend.API, e1 GT, safe bool) GT {
+	var e1IsZero frontend.Variable
+	if safe {
+		// 0ᵈ = 0. We assign a dummy value (1) to e1 so that the easy part
+		// division is determined and select 0 as the result.
+		var one GT
+		one.SetOne()
+		e1IsZero = e1.IsZero(api)
+		e1.Select(api, e1IsZero, one, e1)
+	}
 
 	result := e1
 
@@ -200,33 +230,361 @@ func FinalExponentiation(api frontend.API, e1 GT) GT {
 	// and Tadanori Teruya
 	// https://eprint.iacr.org/2020/875.pdf
 	t[0].CyclotomicSquare(api, result)
-	t[1].Expt(api, result, genT)
+	t[1].expt(api, result, safe)
 	t[2].Conjugate(api, result)
 	t[1].Mul(api, t[1], t[2])
-	t[2].Expt(api, t[1], genT)
+	t[2].expt(api, t[1], safe)
 	t[1].Conjugate(api, t[1])
 	t[1].Mul(api, t[1], t[2])
-	t[2].Expt(api, t[1], genT)
+	t[2].expt(api, t[1], safe)
 	t[1].Frobenius(api, t[1])
 	t[1].Mul(api, t[1], t[2])
 	result.Mul(api, result, t[0])
-	t[0].Expt(api, t[1], genT)
-	t[2].Expt(api, t[0], genT)
+	t[0].expt(api, t[1], safe)
+	t[2].expt(api, t[0], safe)
 	t[0].FrobeniusSquare(api, t[1])
 	t[1].Conjugate(api, t[1])
 	t[1].Mul(api, t[1], t[2])
 	t[1].Mul(api, t[1], t[0])
 	result.Mul(api, result, t[1])
 
+	if safe {
+		var zero GT
+		zero.SetZero()
+		result.Select(api, e1IsZero, zero, result)
+	}
+
 	return result
 }
 
+// FinalExponentiationTorus computes the same exponentiation as
+// FinalExponentiation but in torus-compressed form: the hard part is computed
+// in E6 with SquareTorus instead of Karabina's compressed squarings. It is
+// an alternative for the circuits where it costs less constraints, see
+// BenchmarkFinalExponentiation, and can be selected in Pair with WithTorus.
+//
+// The elements 1 and -1 have no compressed form or are edge cases of
+// MulTorus and SquareTorus. They are handled with selects where they can
+// happen for any e1: when e1 ∈ E6 (e.g. e(P, Q)⋅e(-P, Q)), when e1.C0 = 0
+// (e1^(p⁶-1) = -1) and when e1^((p⁶-1)(p²+1)) = 1 (e.g. e1 ∈ 𝔽p⁴), so that it
+// can be used after a product of Miller loops. In the hard part, the elements
+// are in the cyclotomic subgroup of odd order Φ₁₂(p) and are never -1, but a
+// product y1⋅y2 = 1 can still happen for the elements of small order. The
+// circuit is then unsatisfiable, so that the result is never wrong. As in
+// FinalExponentiationSafe, the result is 0 when e1 = 0.
+func FinalExponentiationTorus(api frontend.API, e1 GT) GT {
+	var dummy E6
+	dummy.SetOne()
+	e1IsZero := e1.IsZero(api)
+
+	// 1. Easy part
+	// (p⁶-1)(p²+1)
+	//
+	// If e1.C1 = 0, then e1^(p⁶-1) = 1. We assign a dummy value (1) to e1.C1
+	// and proceed further.
+	selector1 := e1.C1.IsZero(api)
+	e1.C1.Select(api, selector1, dummy, e1.C1)
+
+	// Torus compression absorbed:
+	// Raising e1 to (p⁶-1) is
+	// e1^(p⁶) / e1 = (e1.C0 - w*e1.C1) / (e1.C0 + w*e1.C1)
+	//              = (-e1.C0/e1.C1 + w) / (-e1.C0/e1.C1 - w)
+	// So the fraction -e1.C0/e1.C1 is already in the torus.
+	var c, t0, t1, t2 E6
+	c.DivUnchecked(api, e1.C0, e1.C1)
+	c.Neg(api, c)
+	// If c = 0, i.e. e1^(p⁶-1) = -1, then e1^((p⁶-1)(p²+1)) = 1. We assign a
+	// dummy value (1) to c.
+	selector2 := c.IsZero(api)
+	c.Select(api, selector2, dummy, c)
+	// MulTorus(t0, c) requires t0 ≠ -c. When t0 = -c, the product is 1. We
+	// assign a dummy value (1) to t0.
+	t0.FrobeniusSquareTorus(api, c)
+	var sum E6
+	sum.Add(api, t0, c)
+	selector3 := sum.IsZero(api)
+	t0.Select(api, selector3, dummy, t0)
+	c.MulTorus(api, t0, c)
+
+	// 2. Hard part (up to permutation)
+	// Daiki Hayashida and Kenichiro Hayasaka
+	// and Tadanori Teruya
+	// https://eprint.iacr.org/2020/875.pdf
+	// performed in torus compressed form
+	t0.SquareTorus(api, c)
+	t1.ExptTorus(api, c)
+	t2.InverseTorus(api, c)
+	t1.MulTorus(api, t1, t2)
+	t2.ExptTorus(api, t1)
+	t1.InverseTorus(api, t1)
+	t1.MulTorus(api, t1, t2)
+	t2.ExptTorus(api, t1)
+	t1.FrobeniusTorus(api, t1)
+	t1.MulTorus(api, t1, t2)
+	c.MulTorus(api, c, t0)
+	t0.ExptTorus(api, t1)
+	t2.ExptTorus(api, t0)
+	t0.FrobeniusSquareTorus(api, t1)
+	t1.InverseTorus(api, t1)
+	t1.MulTorus(api, t1, t2)
+	t1.MulTorus(api, t1, t0)
+
+	// MulTorus(c, t1) requires c ≠ -t1. When c = -t1, the product is 1. We
+	// assign a dummy value (1) to t1 and select 1 as the result if any edge
+	// case happened.
+	sum.Add(api, c, t1)
+	selector4 := sum.IsZero(api)
+	t1.Select(api, selector4, dummy, t1)
+	c.MulTorus(api, c, t1)
+
+	var result, res1 GT
+	result.DecompressTorus(api, c)
+	res1.SetOne()
+	isOne := api.Or(api.Or(selector1, selector2), api.Or(selector3, selector4))
+	result.Select(api, isOne, res1, result)
+	// 0ᵈ = 0, e1 = 0 being handled as e1.C1 = 0 above
+	var zero GT
+	zero.SetZero()
+	result.Select(api, e1IsZero, zero, result)
+
+	return result
+}
+
+// PairingOption configures the pairing computation of Pair.
+type PairingOption func(*pairingConfig)
+
+type pairingConfig struct {
+	torus bool
+}
+
+// WithTorus computes the final exponentiation with FinalExponentiationTorus
+// instead of FinalExponentiation (Karabina's compressed squarings).
+func WithTorus() PairingOption {
+	return func(cfg *pairingConfig) {
+		cfg.torus = true
+	}
+}
+
 // Pair calculates the reduced pairing for a set of points
 // ∏ᵢ e(Pᵢ, Qᵢ).
 //
+// The final exponentiation is FinalExponentiation by default and
+// FinalExponentiationTorus with the option WithTorus.
+//
 // This function doesn't check that the inputs are in the correct subgroup. See IsInSubGroup.
-func Pair(api frontend.API, P []G1Affine, Q []G2Affine) (GT, error) {
+func Pair(api frontend.API, P []G1Affine, Q []G2Affine, opts ...PairingOption) (GT, error) {
+	var cfg pairingConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	f, err := MillerLoop(api, P, Q)
+	if err != nil {
+		return GT{}, err
+	}
+	if cfg.torus {
+		return FinalExponentiationTorus(api, f), nil
+	}
+	return FinalExponentiation(api, f), nil
+}
+
+// PairingCheckWithTarget calculates the reduced pairing for a set of points
+// and asserts if the result is the constant T
+// ∏ᵢ e(Pᵢ, Qᵢ) =? T
+//
+// Instead of comparing to T after the final exponentiation, the Miller loop
+// output is multiplied by the inverse of a precomputed representative f of T,
+// i.e. such that the final exponentiation of f is T, so that a single final
+// exponentiation is needed. The product is not a single Miller loop output
+// (e.g. it is in E6 when pairs cancel out) so that FinalExponentiationSafe is
+// used.
+//
+// This function doesn't check that the inputs are in the correct subgroup.
+func PairingCheckWithTarget(api frontend.API, P []G1Affine, Q []G2Affine, T bls12377.GT) error {
 	f, err := MillerLoop(api, P, Q)
+	if err != nil {
+		return err
+	}
+
+	// f⁻¹ = T^(-1/d mod r)
+	var fInv bls12377.GT
+	var t GT
+	fInv.Exp(T, targetExponent)
+	t.Assign(&fInv)
+
+	f.Mul(api, f, t)
+	res := FinalExponentiationSafe(api, f)
+	var one GT
+	one.SetOne()
+	res.AssertIsEqual(api, one)
+
+	return nil
+}
+
+// targetExponent = -1/d mod r, where d = 3 ⋅ (p¹²-1)/r is the exponent of
+// FinalExponentiation. For T ∈ GT, (T^targetExponent)^d = T⁻¹.
+var targetExponent = func() *big.Int {
+	p, r := ecc.BLS12_377.BaseField(), ecc.BLS12_377.ScalarField()
+	d := new(big.Int).Exp(p, big.NewInt(12), nil)
+	d.Sub(d, big.NewInt(1)).
+		Div(d, r).
+		Mul(d, big.NewInt(3)).
+		ModInverse(d, r)
+	return d.Sub(r, d)
+}()
+
+// MillerLoopFixedQ computes the Miller loop f_{x₀,Q}(P) for a fixed G2
+// argument Q given by its precomputed lines (see PrecomputeLines).
+func MillerLoopFixedQ(api frontend.API, P G1Affine, lines *FixedLines) (GT, error) {
+	var res GT
+	var prodLines [5]E2
+	var l1, l2 lineEvaluation
+
+	yInv := api.DivUnchecked(1, P.Y)
+	xOverY := api.Mul(P.X, yInv)
+
+	// i = 62, separately to avoid an E12 Square and a MulBy034
+	// (assign line to res)
+	res.SetOne()
+	res.C1.B0.MulByFp(api, lines[0][62], xOverY)
+	res.C1.B1.MulByFp(api, lines[1][62], yInv)
+
+	for i := 61; i >= 0; i-- {
+		// (∏ᵢfᵢ)²
+		res.Square(api, res)
+
+		// line evaluation at P
+		l1.R0.MulByFp(api, lines[0][i], xOverY)
+		l1.R1.MulByFp(api, lines[1][i], yInv)
+
+		if loopCounter[i] == 0 {
+			// ℓ × res
+			res.MulBy034(api, l1.R0, l1.R1)
+			continue
+		}
+
+		// line evaluation at P
+		l2.R0.MulByFp(api, lines[2][i], xOverY)
+		l2.R1.MulByFp(api, lines[3][i], yInv)
+
+		// ℓ × ℓ
+		prodLines = *Mul034By034(api, l1.R0, l1.R1, l2.R0, l2.R1)
+		// (ℓ × ℓ) × res
+		res.MulBy01234(api, prodLines)
+	}
+
+	return res, nil
+}
+
+// DoubleMillerLoopFixedQ computes the double Miller loop
+// f_{x₀,Q}(P)⋅f_{x₀,R}(T) for a variable Q and a fixed R given by its
+// precomputed lines (see PrecomputeLines).
+func DoubleMillerLoopFixedQ(api frontend.API, P, T G1Affine, Q G2Affine, lines *FixedLines) (GT, error) {
+	var res GT
+	var prodLines [5]E2
+	var l1, l2 lineEvaluation
+
+	Qacc := Q
+	yInv := api.DivUnchecked(1, P.Y)
+	xOverY := api.Mul(P.X, yInv)
+	y2Inv := api.DivUnchecked(1, T.Y)
+	x2OverY2 := api.Mul(T.X, y2Inv)
+
+	// i = 62, separately to avoid an E12 Square and a MulBy034
+	// (assign line to res)
+	Qacc, l1 = doubleStep(api, &Qacc)
+	res.SetOne()
+	res.C1.B0.MulByFp(api, l1.R0, xOverY)
+	res.C1.B1.MulByFp(api, l1.R1, yInv)
+
+	// fixed line evaluation at T
+	l1.R0.MulByFp(api, lines[0][62], x2OverY2)
+	l1.R1.MulByFp(api, lines[1][62], y2Inv)
+
+	// ℓ × res
+	// (res is also a line at this point, so we use Mul034By034 ℓ × ℓ)
+	prodLines = *Mul034By034(api, l1.R0, l1.R1, res.C1.B0, res.C1.B1)
+	res.C0.B0 = prodLines[0]
+	res.C0.B1 = prodLines[1]
+	res.C0.B2 = prodLines[2]
+	res.C1.B0 = prodLines[3]
+	res.C1.B1 = prodLines[4]
+
+	for i := 61; i >= 0; i-- {
+		// mutualize the square among the two Miller loops
+		// (∏ᵢfᵢ)²
+		res.Square(api, res)
+
+		// fixed lines evaluation at T
+		l1.R0.MulByFp(api, lines[0][i], x2OverY2)
+		l1.R1.MulByFp(api, lines[1][i], y2Inv)
+
+		if loopCounter[i] == 0 {
+			// ℓ × res
+			res.MulBy034(api, l1.R0, l1.R1)
+
+			// Qacc ← 2Qacc and l1 the tangent ℓ passing 2Qacc
+			Qacc, l1 = doubleStep(api, &Qacc)
+
+			// line evaluation at P
+			l1.R0.MulByFp(api, l1.R0, xOverY)
+			l1.R1.MulByFp(api, l1.R1, yInv)
+
+			// ℓ × res
+			res.MulBy034(api, l1.R0, l1.R1)
+			continue
+		}
+
+		l2.R0.MulByFp(api, lines[2][i], x2OverY2)
+		l2.R1.MulByFp(api, lines[3][i], y2Inv)
+
+		// (ℓ × ℓ) × res
+		prodLines = *Mul034By034(api, l1.R0, l1.R1, l2.R0, l2.R1)
+		res.MulBy01234(api, prodLines)
+
+		if i > 0 {
+			// Qacc ← 2Qacc+Q,
+			// l1 the line ℓ passing Qacc and Q
+			// l2 the line ℓ passing (Qacc+Q) and Qacc
+			Qacc, l1, l2 = doubleAndAddStep(api, &Qacc, &Q)
+		} else {
+			// l1 line through Qacc and Q
+			// l2 line through Qacc+Q and Qacc
+			l1, l2 = linesCompute(api, &Qacc, &Q)
+		}
+
+		// lines evaluation at P
+		l1.R0.MulByFp(api, l1.R0, xOverY)
+		l1.R1.MulByFp(api, l1.R1, yInv)
+		l2.R0.MulByFp(api, l2.R0, xOverY)
+		l2.R1.MulByFp(api, l2.R1, yInv)
+
+		// (ℓ × ℓ) × res
+		prodLines = *Mul034By034(api, l1.R0, l1.R1, l2.R0, l2.R1)
+		res.MulBy01234(api, prodLines)
+	}
+
+	return res, nil
+}
+
+// PairFixedQ calculates the reduced pairing e(P, Q) for a fixed Q given by its
+// precomputed lines (see PrecomputeLines).
+//
+// This function doesn't check that the inputs are in the correct subgroup.
+func PairFixedQ(api frontend.API, P G1Affine, lines *FixedLines) (GT, error) {
+	f, err := MillerLoopFixedQ(api, P, lines)
+	if err != nil {
+		return GT{}, err
+	}
+	return FinalExponentiation(api, f), nil
+}
+
+// DoublePairFixedQ calculates the reduced pairing product e(P, Q)⋅e(T, R) for
+// a variable Q and a fixed R given by its precomputed lines (see
+// PrecomputeLines).
+//
+// This function doesn't check that the inputs are in the correct subgroup.
+func DoublePairFixedQ(api frontend.API, P, T G1Affine, Q G2Affine, lines *FixedLines) (GT, error) {
+	f, err := DoubleMillerLoopFixedQ(api, P, T, Q, lines)
 	if err != nil {
 		return GT{}, err
 	}
@@ -26,6 +26,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
 	"github.com/consensys/gnark/profile"
 	"github.com/consensys/gnark/test"
 )
@@ -57,15 +58,149 @@ func TestFinalExp(t *testing.T) {
 	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
 }
 
+type finalExpSafe struct {
+	ML E12
+	R  E12 `gnark:",public"`
+}
+
+func (circuit *finalExpSafe) Define(api frontend.API) error {
+
+	finalExpRes := FinalExponentiationSafe(api, circuit.ML)
+	finalExpRes.AssertIsEqual(api, circuit.R)
+
+	return nil
+}
+
+func TestFinalExpSafe(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// pairing test data
+	P, Q, milRes, pairingRes := pairingData()
+
+	var negP bls12377.G1Affine
+	negP.Neg(&P)
+	degenerate, _ := bls12377.MillerLoop([]bls12377.G1Affine{P, negP}, []bls12377.G2Affine{Q, Q})
+
+	var one, zero, e6 bls12377.GT
+	one.SetOne()
+	_, _ = e6.C0.SetRandom()
+
+	for _, tc := range []struct {
+		ml, res bls12377.GT
+	}{
+		{milRes, pairingRes},
+		// e(P, Q)⋅e(-P, Q) = 1
+		{degenerate, one},
+		{one, one},
+		// Miller loop result in E6
+		{e6, one},
+		{zero, zero},
+	} {
+		var witness finalExpSafe
+		witness.ML.Assign(&tc.ml)
+		witness.R.Assign(&tc.res)
+		assert.SolvingSucceeded(&finalExpSafe{}, &witness, test.WithCurves(ecc.BW6_761))
+	}
+
+	// wrong result
+	var witness finalExpSafe
+	witness.ML.Assign(&one)
+	witness.R.Assign(&pairingRes)
+	assert.SolvingFailed(&finalExpSafe{}, &witness, test.WithCurves(ecc.BW6_761))
+}
+
+type finalExpTorus struct {
+	ML E12
+	R  bls12377.GT
+}
+
+func (circuit *finalExpTorus) Define(api frontend.API) error {
+
+	finalExpRes := FinalExponentiationTorus(api, circuit.ML)
+	mustbeEq(api, finalExpRes, &circuit.R)
+
+	return nil
+}
+
+func TestFinalExpTorus(t *testing.T) {
+
+	// pairing test data
+	P, Q, milRes, pairingRes := pairingData()
+
+	// create cs
+	var circuit, witness finalExpTorus
+	witness.ML.Assign(&milRes)
+	circuit.R = pairingRes
+
+	assert := test.NewAssert(t)
+	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+
+	// edge cases, for which the result is 1 (a new assert is needed as the
+	// expected result is a circuit constant)
+	circuit.R.SetOne()
+
+	// e(P, Q)⋅e(-P, Q)
+	var negP bls12377.G1Affine
+	negP.Neg(&P)
+	milRes, _ = bls12377.MillerLoop([]bls12377.G1Affine{P, negP}, []bls12377.G2Affine{Q, Q})
+	witness.ML.Assign(&milRes)
+	assert = test.NewAssert(t)
+	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+
+	// Miller loop result in E6
+	_, _ = milRes.C0.SetRandom()
+	milRes.C1 = bls12377.E6{}
+	witness.ML.Assign(&milRes)
+	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+
+	// Miller loop result in w⋅E6, raised to -1 by p⁶-1
+	_, _ = milRes.C1.SetRandom()
+	milRes.C0 = bls12377.E6{}
+	witness.ML.Assign(&milRes)
+	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+
+	// Miller loop result in 𝔽p⁴ = 𝔽p²(vw), raised to 1 by (p⁶-1)(p²+1)
+	milRes = bls12377.GT{}
+	_, _ = milRes.C0.B0.SetRandom()
+	_, _ = milRes.C1.B1.SetRandom()
+	witness.ML.Assign(&milRes)
+	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+}
+
+type finalExpTorusSafe struct {
+	ML E12
+}
+
+func (circuit *finalExpTorusSafe) Define(api frontend.API) error {
+	res := FinalExponentiationSafe(api, circuit.ML)
+	resTorus := FinalExponentiationTorus(api, circuit.ML)
+	res.AssertIsEqual(api, resTorus)
+	return nil
+}
+
+func TestFinalExpTorusSafe(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, milRes, _ := pairingData()
+
+	var one, zero bls12377.GT
+	one.SetOne()
+	for _, ml := range []bls12377.GT{milRes, one, zero} {
+		var witness finalExpTorusSafe
+		witness.ML.Assign(&ml)
+		assert.SolvingSucceeded(&finalExpTorusSafe{}, &witness, test.WithCurves(ecc.BW6_761))
+	}
+}
+
 type pairingBLS377 struct {
 	P          G1Affine `gnark:",public"`
 	Q          G2Affine
 	pairingRes bls12377.GT
+	opts       []PairingOption
 }
 
 func (circuit *pairingBLS377) Define(api frontend.API) error {
 
-	pairingRes, _ := Pair(api, []G1Affine{circuit.P}, []G2Affine{circuit.Q})
+	pairingRes, _ := Pair(api, []G1Affine{circuit.P}, []G2Affine{circuit.Q}, circuit.opts...)
 
 	mustbeEq(api, pairingRes, &circuit.pairingRes)
 
@@ -77,50 +212,188 @@ func TestPairingBLS377(t *testing.T) {
 	// pairing test data
 	P, Q, _, pairingRes := pairingData()
 
+	for _, opts := range [][]PairingOption{nil, {WithTorus()}} {
+		// create cs
+		var circuit, witness pairingBLS377
+		circuit.pairingRes = pairingRes
+		circuit.opts = opts
+
+		// assign values to witness
+		witness.P.Assign(&P)
+		witness.Q.Assign(&Q)
+
+		assert := test.NewAssert(t)
+		assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+	}
+}
+
+type triplePairingBLS377 struct {
+	P1, P2, P3 G1Affine `gnark:",public"`
+	Q1, Q2, Q3 G2Affine
+	pairingRes bls12377.GT
+	opts       []PairingOption
+}
+
+func (circuit *triplePairingBLS377) Define(api frontend.API) error {
+
+	pairingRes, _ := Pair(api, []G1Affine{circuit.P1, circuit.P2, circuit.P3}, []G2Affine{circuit.Q1, circuit.Q2, circuit.Q3}, circuit.opts...)
+
+	mustbeEq(api, pairingRes, &circuit.pairingRes)
+
+	return nil
+}
+
+func TestTriplePairingBLS377(t *testing.T) {
+
+	// pairing test data
+	P, Q, pairingRes := triplePairingData()
+
+	for _, opts := range [][]PairingOption{nil, {WithTorus()}} {
+		// create cs
+		var circuit, witness triplePairingBLS377
+		circuit.pairingRes = pairingRes
+		circuit.opts = opts
+
+		// assign values to witness
+		witness.P1.Assign(&P[0])
+		witness.P2.Assign(&P[1])
+		witness.P3.Assign(&P[2])
+		witness.Q1.Assign(&Q[0])
+		witness.Q2.Assign(&Q[1])
+		witness.Q3.Assign(&Q[2])
+
+		assert := test.NewAssert(t)
+		assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+	}
+}
+
+type pairingCheckWithTargetBLS377 struct {
+	P1, P2 G1Affine `gnark:",public"`
+	Q1, Q2 G2Affine
+	target bls12377.GT
+}
+
+func (circuit *pairingCheckWithTargetBLS377) Define(api frontend.API) error {
+	return PairingCheckWithTarget(api, []G1Affine{circuit.P1, circuit.P2}, []G2Affine{circuit.Q1, circuit.Q2}, circuit.target)
+}
+
+func TestPairingCheckWithTargetBLS377(t *testing.T) {
+
+	// pairing test data
+	P, Q, _ := triplePairingData()
+	target, _ := bls12377.Pair([]bls12377.G1Affine{P[1], P[2]}, []bls12377.G2Affine{Q[1], Q[2]})
+
+	// assign values to witness
+	var witness pairingCheckWithTargetBLS377
+	witness.P1.Assign(&P[1])
+	witness.P2.Assign(&P[2])
+	witness.Q1.Assign(&Q[1])
+	witness.Q2.Assign(&Q[2])
+
+	assert := test.NewAssert(t)
+	assert.SolvingSucceeded(&pairingCheckWithTargetBLS377{target: target}, &witness, test.WithCurves(ecc.BW6_761))
+
+	// wrong target (a new assert is needed as the target is a circuit constant)
+	target.Square(&target)
+	assert = test.NewAssert(t)
+	assert.SolvingFailed(&pairingCheckWithTargetBLS377{target: target}, &witness, test.WithCurves(ecc.BW6_761))
+
+	// cancelling pairs: e(P, Q) * e(P, -Q) = 1, where the product of the
+	// Miller loops is in E6
+	var negQ bls12377.G2Affine
+	negQ.Neg(&Q[1])
+	witness.P2.Assign(&P[1])
+	witness.Q2.Assign(&negQ)
+	target.SetOne()
+	assert = test.NewAssert(t)
+	assert.SolvingSucceeded(&pairingCheckWithTargetBLS377{target: target}, &witness, test.WithCurves(ecc.BW6_761))
+}
+
+type pairingFixedBLS377 struct {
+	P          G1Affine `gnark:",public"`
+	pairingRes bls12377.GT
+	lines      *FixedLines `gnark:"-"`
+}
+
+func (circuit *pairingFixedBLS377) Define(api frontend.API) error {
+
+	pairingRes, _ := PairFixedQ(api, circuit.P, circuit.lines)
+
+	mustbeEq(api, pairingRes, &circuit.pairingRes)
+
+	return nil
+}
+
+func TestPairingFixedBLS377(t *testing.T) {
+
+	// pairing test data
+	P, _, _, pairingRes := pairingData()
+
 	// create cs
-	var circuit, witness pairingBLS377
+	var circuit, witness pairingFixedBLS377
 	circuit.pairingRes = pairingRes
+	circuit.lines = &PrecomputedLines
 
 	// assign values to witness
 	witness.P.Assign(&P)
-	witness.Q.Assign(&Q)
 
 	assert := test.NewAssert(t)
 	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
 
 }
 
-type triplePairingBLS377 struct {
-	P1, P2, P3 G1Affine `gnark:",public"`
-	Q1, Q2, Q3 G2Affine
+func TestPairingFixedLinesBLS377(t *testing.T) {
+
+	// pairing test data
+	P, Q, _ := triplePairingData()
+	pairingRes, _ := bls12377.Pair([]bls12377.G1Affine{P[1]}, []bls12377.G2Affine{Q[1]})
+	lines := PrecomputeLines(Q[1])
+
+	// create cs
+	var circuit, witness pairingFixedBLS377
+	circuit.pairingRes = pairingRes
+	circuit.lines = &lines
+
+	// assign values to witness
+	witness.P.Assign(&P[1])
+
+	assert := test.NewAssert(t)
+	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+
+}
+
+type doublePairingFixedBLS377 struct {
+	P1, P2     G1Affine `gnark:",public"`
+	Q1         G2Affine
 	pairingRes bls12377.GT
+	lines      *FixedLines `gnark:"-"`
 }
 
-func (circuit *triplePairingBLS377) Define(api frontend.API) error {
+func (circuit *doublePairingFixedBLS377) Define(api frontend.API) error {
 
-	pairingRes, _ := Pair(api, []G1Affine{circuit.P1, circuit.P2, circuit.P3}, []G2Affine{circuit.Q1, circuit.Q2, circuit.Q3})
+	pairingRes, _ := DoublePairFixedQ(api, circuit.P1, circuit.P2, circuit.Q1, circuit.lines)
 
 	mustbeEq(api, pairingRes, &circuit.pairingRes)
 
 	return nil
 }
 
-func TestTriplePairingBLS377(t *testing.T) {
+func TestDoublePairingFixedBLS377(t *testing.T) {
 
 	// pairing test data
-	P, Q, pairingRes := triplePairingData()
+	P, Q, _ := triplePairingData()
+	pairingRes, _ := bls12377.Pair([]bls12377.G1Affine{P[1], P[2]}, []bls12377.G2Affine{Q[1], Q[2]})
+	lines := PrecomputeLines(Q[2])
 
 	// create cs
-	var circuit, witness triplePairingBLS377
+	var circuit, witness doublePairingFixedBLS377
 	circuit.pairingRes = pairingRes
+	circuit.lines = &lines
 
 	// assign values to witness
-	witness.P1.Assign(&P[0])
-	witness.P2.Assign(&P[1])
-	witness.P3.Assign(&P[2])
-	witness.Q1.Assign(&Q[0])
-	witness.Q2.Assign(&Q[1])
-	witness.Q3.Assign(&Q[2])
+	witness.P1.Assign(&P[1])
+	witness.P2.Assign(&P[2])
+	witness.Q1.Assign(&Q[1])
 
 	assert := test.NewAssert(t)
 	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
@@ -176,3 +449,49 @@ func BenchmarkPairing(b *testing.B) {
 	p.Stop()
 	fmt.Println("⏱️  Single pairing on BLS12-377 in a BW6-761 R1CS circuit: ", p.NbConstraints())
 }
+
+func BenchmarkFinalExponentiation(b *testing.B) {
+	var c finalExp
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  Final exponentiation (Karabina) on BLS12-377 in a BW6-761 R1CS circuit: ", p.NbConstraints())
+
+	var cs finalExpSafe
+	p = profile.Start()
+	_, _ = frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, &cs)
+	p.Stop()
+	fmt.Println("⏱️  Final exponentiation (Karabina, safe) on BLS12-377 in a BW6-761 R1CS circuit: ", p.NbConstraints())
+
+	var ct finalExpTorus
+	p = profile.Start()
+	_, _ = frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, &ct)
+	p.Stop()
+	fmt.Println("⏱️  Final exponentiation (torus) on BLS12-377 in a BW6-761 R1CS circuit: ", p.NbConstraints())
+
+	p = profile.Start()
+	_, _ = frontend.Compile(ecc.BW6_761.ScalarField(), scs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  Final exponentiation (Karabina) on BLS12-377 in a BW6-761 PLONK circuit: ", p.NbConstraints())
+
+	p = profile.Start()
+	_, _ = frontend.Compile(ecc.BW6_761.ScalarField(), scs.NewBuilder, &ct)
+	p.Stop()
+	fmt.Println("⏱️  Final exponentiation (torus) on BLS12-377 in a BW6-761 PLONK circuit: ", p.NbConstraints())
+}
+
+func BenchmarkPairingFixedQ(b *testing.B) {
+	c := pairingFixedBLS377{lines: &PrecomputedLines}
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  Single pairing on BLS12-377 with a fixed G2 argument in a BW6-761 R1CS circuit: ", p.NbConstraints())
+}
+
+func BenchmarkDoublePairingFixedQ(b *testing.B) {
+	c := doublePairingFixedBLS377{lines: &PrecomputedLines}
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  Double pairing on BLS12-377 with a fixed G2 argument in a BW6-761 R1CS circuit: ", p.NbConstraints())
+}
@@ -0,0 +1,487 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package two_chains
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	plonk_bls12377 "github.com/consensys/gnark/backend/plonk/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	fiatshamir "github.com/consensys/gnark/std/fiat-shamir"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/yelhousni/ZKHackathon/zk-Circuits/category-1/sha256"
+)
+
+// BLS12377Fr provides type parametrization for emulated field on 4 limb of width
+// 64bits for modulus 0x12ab655e9a2ca55660b44d1e5c37b00159aa76fed00000010a11800000000001.
+// This is the scalar field of the BLS12-377 curve.
+type BLS12377Fr struct{}
+
+func (fp BLS12377Fr) NbLimbs() uint     { return 4 }
+func (fp BLS12377Fr) BitsPerLimb() uint { return 64 }
+func (fp BLS12377Fr) IsPrime() bool     { return true }
+func (fp BLS12377Fr) Modulus() *big.Int { return ecc.BLS12_377.ScalarField() }
+
+// PlonkProof represents a BLS12-377 PLONK proof.
+//
+// The claimed values are elements of 𝔽_r given as native variables.
+type PlonkProof struct {
+	// Commitments to the solution vectors
+	LRO [3]G1Affine
+
+	// Commitment to Z, the permutation polynomial
+	Z G1Affine
+
+	// Commitments to h1, h2, h3 such that h = h1 + Xh2 + X**2h3 is the quotient polynomial
+	H [3]G1Affine
+
+	// Batch opening proof of h1 + zeta*h2 + zeta**2h3, linearizedPolynomial, l, r, o, s1, s2, qCPrime
+	BatchedProof struct {
+		H             G1Affine
+		ClaimedValues [8]frontend.Variable
+	}
+
+	// Opening proof of Z at zeta*mu
+	ZShiftedOpening struct {
+		H            G1Affine
+		ClaimedValue frontend.Variable
+	}
+}
+
+// PlonkVerifyingKey represents a BLS12-377 PLONK verifying key. The size of
+// the circuit and the parameters of its evaluation domain are circuit
+// constants.
+type PlonkVerifyingKey struct {
+	// S commitments to S1, S2, S3
+	S [3]G1Affine
+
+	// Commitments to ql, qr, qm, qo, qk
+	Ql, Qr, Qm, Qo, Qk G1Affine
+
+	// KZG setup: [1]1, [1]2 and [τ]2
+	KZG struct {
+		G1 G1Affine
+		G2 [2]G2Affine
+	}
+
+	size                           uint64     `gnark:"-"`
+	sizeInv, generator, cosetShift fr.Element `gnark:"-"`
+	nbPublicVariables              uint64     `gnark:"-"`
+}
+
+// VerifyPlonk verifies the PLONK proof for the public inputs, as gnark's
+// BLS12-377 PLONK verifier does.
+//
+// The challenges γ, β, α, ζ and the challenge folding the openings at ζ are
+// derived with a SHA-256 transcript, as in the prover, so that proofs of the
+// gnark prover are accepted as they are. The random numbers batching the two
+// KZG openings, which are sampled by the native verifier, are derived with a
+// MiMC transcript over the BW6-761 scalar field, binded to the proof and the
+// opened values.
+//
+// The verifying key vk, including the KZG setup points, is not constrained by
+// this function: it MUST be a public input of the outer circuit or embedded in
+// it as constants. Otherwise the prover could choose a verifying key, or a
+// trapdoor τ, for which any proof verifies.
+//
+// The points of the proof are asserted to be on the curve and different from
+// (0,±1), the points of order 3, for which the scalar multiplications are
+// incomplete. This function doesn't check that they are in the correct
+// subgroups.
+func (v *Verifier) VerifyPlonk(vk PlonkVerifyingKey, proof PlonkProof, publicInputs []frontend.Variable) error {
+	api := v.api
+	if vk.size == 0 {
+		return errors.New("invalid verifying key: VerifyingKey must be assigned before compiling circuit")
+	}
+	if uint64(len(publicInputs)) != vk.nbPublicVariables {
+		return fmt.Errorf("invalid number of public inputs: got %d, expected %d", len(publicInputs), vk.nbPublicVariables)
+	}
+	f, err := emulated.NewField[BLS12377Fr](api)
+	if err != nil {
+		return err
+	}
+
+	// the points of the proof are chosen by the prover
+	for _, p := range []G1Affine{
+		proof.LRO[0], proof.LRO[1], proof.LRO[2], proof.Z, proof.H[0], proof.H[1], proof.H[2],
+		proof.BatchedProof.H, proof.ZShiftedOpening.H,
+	} {
+		p.AssertIsOnCurve(api)
+		api.AssertIsDifferent(p.X, 0)
+	}
+
+	// canonical encodings of the elements of 𝔽_r and of the points
+	toFr := func(x frontend.Variable) (*emulated.Element[BLS12377Fr], []frontend.Variable) {
+		b := canonicalBits(api, x, ecc.BLS12_377.ScalarField())
+		return f.FromBits(b...), toBytes(api, b, fr.Bytes)
+	}
+	toScalar := func(x *emulated.Element[BLS12377Fr]) ([]frontend.Variable, frontend.Variable) {
+		b := f.Reduce(f.Mul(x, f.One()))
+		f.AssertIsInRange(b)
+		bb := f.ToBits(b)
+		return toBytes(api, bb, fr.Bytes), api.FromBinary(bb...)
+	}
+	var infinity [bls12377.SizeOfG1AffineUncompressed]frontend.Variable
+	for i := range infinity {
+		infinity[i] = 0
+	}
+	infinity[0] = 0x40
+
+	publicInputsFr := make([]*emulated.Element[BLS12377Fr], len(publicInputs))
+	publicInputsBytes := make([][]frontend.Variable, len(publicInputs))
+	for i := range publicInputs {
+		publicInputsFr[i], publicInputsBytes[i] = toFr(publicInputs[i])
+	}
+	var claimedValues [8]*emulated.Element[BLS12377Fr]
+	var claimedValuesBytes [8][]frontend.Variable
+	for i := range claimedValues {
+		claimedValues[i], claimedValuesBytes[i] = toFr(proof.BatchedProof.ClaimedValues[i])
+	}
+	zu, _ := toFr(proof.ZShiftedOpening.ClaimedValue)
+	s0Bytes, s1Bytes := g1Bytes(api, vk.S[0]), g1Bytes(api, vk.S[1])
+	lroBytes := [3][]frontend.Variable{g1Bytes(api, proof.LRO[0]), g1Bytes(api, proof.LRO[1]), g1Bytes(api, proof.LRO[2])}
+
+	// derive γ, β, α, ζ
+	fs := transcript{api: api}
+	fs.bind(s0Bytes, s1Bytes, g1Bytes(api, vk.S[2]))
+	fs.bind(g1Bytes(api, vk.Ql), g1Bytes(api, vk.Qr), g1Bytes(api, vk.Qm), g1Bytes(api, vk.Qo), g1Bytes(api, vk.Qk))
+	fs.bind(publicInputsBytes...)
+	fs.bind(infinity[:]) // PI2, there is no commitment
+	fs.bind(lroBytes[:]...)
+	gamma := fromDigest(api, f, fs.computeChallenge("gamma"))
+	beta := fromDigest(api, f, fs.computeChallenge("beta"))
+	fs.bind(g1Bytes(api, proof.Z))
+	alpha := fromDigest(api, f, fs.computeChallenge("alpha"))
+	fs.bind(g1Bytes(api, proof.H[0]), g1Bytes(api, proof.H[1]), g1Bytes(api, proof.H[2]))
+	zeta := fromDigest(api, f, fs.computeChallenge("zeta"))
+
+	// evaluation of Xⁿ-1 at ζ
+	zetaPowerM := zeta
+	for i := 0; i < bits.TrailingZeros64(vk.size); i++ {
+		zetaPowerM = f.Mul(zetaPowerM, zetaPowerM)
+	}
+	zzeta := f.Sub(zetaPowerM, f.One())
+
+	// compute PI = ∑_{i<n} Lᵢ*wᵢ, where Lᵢ(ζ) = wⁱ/n (ζⁿ-1)/(ζ-wⁱ)
+	lagrange := func(wPowI fr.Element) *emulated.Element[BLS12377Fr] {
+		var c fr.Element
+		c.Mul(&wPowI, &vk.sizeInv)
+		cst, wi := emulated.ValueOf[BLS12377Fr](c), emulated.ValueOf[BLS12377Fr](wPowI)
+		return f.Div(f.Mul(&cst, zzeta), f.Sub(zeta, &wi))
+	}
+	var wPowI fr.Element
+	wPowI.SetOne()
+	lagrangeOne := lagrange(wPowI)
+	pi := f.Zero()
+	for i := range publicInputs {
+		li := lagrangeOne
+		if i > 0 {
+			li = lagrange(wPowI)
+		}
+		pi = f.Add(pi, f.Mul(li, publicInputsFr[i]))
+		wPowI.Mul(&wPowI, &vk.generator)
+	}
+
+	claimedQuotient := claimedValues[0]
+	linearizedPolynomialZeta := claimedValues[1]
+	l := claimedValues[2]
+	r := claimedValues[3]
+	o := claimedValues[4]
+	s1 := claimedValues[5]
+	s2 := claimedValues[6]
+
+	// α*(Z(μζ))*(l(ζ)+β*s1(ζ)+γ)*(r(ζ)+β*s2(ζ)+γ)*(o(ζ)+γ)
+	_s1 := f.Add(f.Add(f.Mul(s1, beta), l), gamma)
+	_s2 := f.Add(f.Add(f.Mul(s2, beta), r), gamma)
+	_o := f.Add(o, gamma)
+	_s1 = f.Mul(f.Mul(f.Mul(f.Mul(_s1, _s2), _o), alpha), zu)
+
+	// α²*L₁(ζ)
+	alphaSquareLagrange := f.Mul(f.Mul(lagrangeOne, alpha), alpha)
+
+	// check that H(ζ)(ζⁿ-1) = linearizedpolynomial + pi(ζ) + α*(Z(μζ))*(l(ζ)+β*s1(ζ)+γ)*(r(ζ)+β*s2(ζ)+γ)*(o(ζ)+γ) - α²*L₁(ζ)
+	f.AssertIsEqual(
+		f.Mul(claimedQuotient, zzeta),
+		f.Sub(f.Add(f.Add(linearizedPolynomialZeta, pi), _s1), alphaSquareLagrange),
+	)
+
+	// compute the folded commitment to H: Comm(h₁) + ζᵐ⁺²*Comm(h₂) + ζ²⁽ᵐ⁺²⁾*Comm(h₃)
+	zetaMPlusTwo := f.Mul(f.Mul(zetaPowerM, zeta), zeta)
+	_, zetaMPlusTwoScalar := toScalar(zetaMPlusTwo)
+	_, zetaMPlusTwoSquare := toScalar(f.Mul(zetaMPlusTwo, zetaMPlusTwo))
+	foldedH := addScalarMul(api, proof.H[0], proof.H[1], zetaMPlusTwoScalar)
+	foldedH = addScalarMul(api, foldedH, proof.H[2], zetaMPlusTwoSquare)
+
+	// Compute the commitment to the linearized polynomial
+	// linearizedPolynomialDigest =
+	// 		l(ζ)*ql+r(ζ)*qr+r(ζ)l(ζ)*qm+o(ζ)*qo+qk +
+	// 		α*( Z(μζ)(l(ζ)+β*s₁(ζ)+γ)*(r(ζ)+β*s₂(ζ)+γ)*s₃(X)-Z(X)(l(ζ)+β*id_1(ζ)+γ)*(r(ζ)+β*id_2(ζ)+γ)*(o(ζ)+β*id_3(ζ)+γ) ) +
+	// 		α²*L₁(ζ)*Z
+	_, rl := toScalar(f.Mul(l, r))
+
+	// α*Z(μζ)(l(ζ)+β*s₁(ζ)+γ)*(r(ζ)+β*s₂(ζ)+γ)*β
+	u := f.Mul(zu, beta)
+	_v := f.Add(f.Add(f.Mul(beta, s1), l), gamma)
+	w := f.Add(f.Add(f.Mul(beta, s2), r), gamma)
+	_, coeffS3 := toScalar(f.Mul(f.Mul(f.Mul(u, _v), w), alpha))
+
+	// -α*(l(ζ)+β*ζ+γ)*(r(ζ)+β*u*ζ+γ)*(o(ζ)+β*u²*ζ+γ) + α²*L₁(ζ)
+	var cosetSquare fr.Element
+	cosetSquare.Square(&vk.cosetShift)
+	cosetShift := emulated.ValueOf[BLS12377Fr](vk.cosetShift)
+	cosetShiftSquare := emulated.ValueOf[BLS12377Fr](cosetSquare)
+	betaZeta := f.Mul(beta, zeta)
+	u = f.Add(f.Add(betaZeta, l), gamma)
+	_v = f.Add(f.Add(f.Mul(betaZeta, &cosetShift), r), gamma)
+	w = f.Add(f.Add(f.Mul(betaZeta, &cosetShiftSquare), o), gamma)
+	_, coeffZ := toScalar(f.Sub(alphaSquareLagrange, f.Mul(f.Mul(f.Mul(u, _v), w), alpha)))
+
+	linearizedPolynomialDigest := vk.Qk
+	linearizedPolynomialDigest = addScalarMul(api, linearizedPolynomialDigest, vk.Ql, proof.BatchedProof.ClaimedValues[2])
+	linearizedPolynomialDigest = addScalarMul(api, linearizedPolynomialDigest, vk.Qr, proof.BatchedProof.ClaimedValues[3])
+	linearizedPolynomialDigest = addScalarMul(api, linearizedPolynomialDigest, vk.Qm, rl)
+	linearizedPolynomialDigest = addScalarMul(api, linearizedPolynomialDigest, vk.Qo, proof.BatchedProof.ClaimedValues[4])
+	linearizedPolynomialDigest = addScalarMul(api, linearizedPolynomialDigest, vk.S[2], coeffS3)
+	linearizedPolynomialDigest = addScalarMul(api, linearizedPolynomialDigest, proof.Z, coeffZ)
+
+	// fold the openings at ζ: the digests are foldedH, the linearized
+	// polynomial, l, r, o, s1, s2 and qcp, which is the point at infinity
+	zetaBytes, zetaScalar := toScalar(zeta)
+	fsFold := transcript{api: api}
+	fsFold.bind(zetaBytes)
+	fsFold.bind(g1Bytes(api, foldedH), g1Bytes(api, linearizedPolynomialDigest))
+	fsFold.bind(lroBytes[:]...)
+	fsFold.bind(s0Bytes, s1Bytes, infinity[:])
+	fsFold.bind(claimedValuesBytes[:]...)
+	gammaFold := fromDigest(api, f, fsFold.computeChallenge("gamma"))
+
+	foldedDigest := foldedH
+	foldedEvaluation := claimedValues[0]
+	gammaI := f.One()
+	for i, d := range []G1Affine{linearizedPolynomialDigest, proof.LRO[0], proof.LRO[1], proof.LRO[2], vk.S[0], vk.S[1]} {
+		gammaI = f.Mul(gammaI, gammaFold)
+		_, s := toScalar(gammaI)
+		foldedDigest = addScalarMul(api, foldedDigest, d, s)
+		foldedEvaluation = f.Add(foldedEvaluation, f.Mul(gammaI, claimedValues[i+1]))
+	}
+	gammaI = f.Mul(gammaI, gammaFold)
+	foldedEvaluation = f.Add(foldedEvaluation, f.Mul(gammaI, claimedValues[7]))
+
+	// batch the openings of the folded digest at ζ and of Z at μζ with a
+	// random λ
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	fsBatch := fiatshamir.NewTranscript(api, &h, "lambda")
+	_, foldedEvaluationScalar := toScalar(foldedEvaluation)
+	if err := fsBatch.Bind("lambda", []frontend.Variable{
+		foldedDigest.X, foldedDigest.Y, proof.Z.X, proof.Z.Y,
+		proof.BatchedProof.H.X, proof.BatchedProof.H.Y, proof.ZShiftedOpening.H.X, proof.ZShiftedOpening.H.Y,
+		foldedEvaluationScalar, proof.ZShiftedOpening.ClaimedValue, zetaScalar,
+	}); err != nil {
+		return err
+	}
+	lambda, err := fsBatch.ComputeChallenge("lambda")
+	if err != nil {
+		return err
+	}
+	// a 128-bit λ is enough for the soundness of the batching
+	lambdaBits := api.ToBinary(lambda)[:128]
+	lambdaFr := f.FromBits(lambdaBits...)
+	lambdaScalar := api.FromBinary(lambdaBits...)
+
+	// [∑ᵢλᵢ(fᵢ(α) - fᵢ(pᵢ) + pᵢHᵢ(α))]1 with λ₀ = 1, p₀ = ζ and p₁ = μζ
+	generator := emulated.ValueOf[BLS12377Fr](vk.generator)
+	_, foldedEvaluationsScalar := toScalar(f.Add(foldedEvaluation, f.Mul(lambdaFr, zu)))
+	_, lambdaShiftedZeta := toScalar(f.Mul(f.Mul(lambdaFr, zeta), &generator))
+	var g1Neg G1Affine
+	g1Neg.Neg(api, vk.KZG.G1)
+	foldedDigests := addScalarMul(api, foldedDigest, proof.Z, lambdaScalar)
+	foldedDigests = addScalarMul(api, foldedDigests, g1Neg, foldedEvaluationsScalar)
+	foldedDigests = addScalarMul(api, foldedDigests, proof.BatchedProof.H, zetaScalar)
+	foldedDigests = addScalarMul(api, foldedDigests, proof.ZShiftedOpening.H, lambdaShiftedZeta)
+
+	// -[∑ᵢλᵢHᵢ(α)]1
+	foldedQuotients := addScalarMul(api, proof.BatchedProof.H, proof.ZShiftedOpening.H, lambdaScalar)
+	foldedQuotients.Neg(api, foldedQuotients)
+
+	// e([∑ᵢλᵢ(fᵢ(α) - fᵢ(pᵢ) + pᵢHᵢ(α))]1, [1]2) * e(-[∑ᵢλᵢHᵢ(α)]1, [τ]2) must be 1
+	pairing, err := Pair(api, []G1Affine{foldedDigests, foldedQuotients}, []G2Affine{vk.KZG.G2[0], vk.KZG.G2[1]})
+	if err != nil {
+		return err
+	}
+	var one GT
+	one.SetOne()
+	pairing.AssertIsEqual(api, one)
+	return nil
+}
+
+// Assign values to the "in-circuit" PlonkProof from a "out-of-circuit" proof
+func (proof *PlonkProof) Assign(p *plonk_bls12377.Proof) {
+	for i := range proof.LRO {
+		proof.LRO[i].Assign(&p.LRO[i])
+	}
+	proof.Z.Assign(&p.Z)
+	for i := range proof.H {
+		proof.H[i].Assign(&p.H[i])
+	}
+	proof.BatchedProof.H.Assign(&p.BatchedProof.H)
+	for i := range proof.BatchedProof.ClaimedValues {
+		proof.BatchedProof.ClaimedValues[i] = p.BatchedProof.ClaimedValues[i].BigInt(new(big.Int))
+	}
+	proof.ZShiftedOpening.H.Assign(&p.ZShiftedOpening.H)
+	proof.ZShiftedOpening.ClaimedValue = p.ZShiftedOpening.ClaimedValue.BigInt(new(big.Int))
+}
+
+// Assign values to the "in-circuit" PlonkVerifyingKey from a "out-of-circuit"
+// verifying key. The verifying keys of circuits with commitments are not
+// supported, nor are the ones with a selector commitment at infinity.
+func (vk *PlonkVerifyingKey) Assign(ovk *plonk_bls12377.VerifyingKey) error {
+	if ovk.CommitmentInfo.Is() {
+		return errors.New("commitments are not supported")
+	}
+	for _, p := range []*bls12377.G1Affine{&ovk.S[0], &ovk.S[1], &ovk.S[2], &ovk.Ql, &ovk.Qr, &ovk.Qm, &ovk.Qo, &ovk.Qk} {
+		if p.IsInfinity() {
+			return errors.New("selector commitment at infinity")
+		}
+	}
+	for i := range vk.S {
+		vk.S[i].Assign(&ovk.S[i])
+	}
+	vk.Ql.Assign(&ovk.Ql)
+	vk.Qr.Assign(&ovk.Qr)
+	vk.Qm.Assign(&ovk.Qm)
+	vk.Qo.Assign(&ovk.Qo)
+	vk.Qk.Assign(&ovk.Qk)
+	vk.KZG.G1.Assign(&ovk.KZGSRS.G1[0])
+	vk.KZG.G2[0].Assign(&ovk.KZGSRS.G2[0])
+	vk.KZG.G2[1].Assign(&ovk.KZGSRS.G2[1])
+
+	vk.size = ovk.Size
+	vk.sizeInv = ovk.SizeInv
+	vk.generator = ovk.Generator
+	vk.cosetShift = ovk.CosetShift
+	vk.nbPublicVariables = ovk.NbPublicVariables
+	return nil
+}
+
+// addScalarMul returns acc + [s]p. The scalar s may be zero and the addition
+// is complete (see [G1Affine.AddUnified]), so that acc may be ±[s]p or (0,0),
+// as the proof points are chosen by the prover. p must be a point of G1
+// different from (0,0).
+func addScalarMul(api frontend.API, acc, p G1Affine, s frontend.Variable) G1Affine {
+	// [0]p is not representable in affine coordinates, so we compute [1]p
+	// instead and drop it
+	isZero := api.IsZero(s)
+	var sp, res G1Affine
+	sp.ScalarMul(api, p, api.Select(isZero, 1, s))
+	res = acc
+	res.AddUnified(api, sp)
+	res.Select(api, isZero, acc, res)
+	return res
+}
+
+// transcript is the in-circuit counterpart of gnark-crypto's
+// fiatshamir.Transcript with SHA-256: a challenge is
+// H(name || previous challenge || binded values...).
+type transcript struct {
+	api      frontend.API
+	previous []frontend.Variable
+	bindings []frontend.Variable
+}
+
+func (t *transcript) bind(values ...[]frontend.Variable) {
+	for _, v := range values {
+		t.bindings = append(t.bindings, v...)
+	}
+}
+
+// computeChallenge returns the bytes of the challenge name and resets the
+// bindings.
+func (t *transcript) computeChallenge(name string) []frontend.Variable {
+	data := make([]frontend.Variable, 0, len(name)+len(t.previous)+len(t.bindings))
+	for i := 0; i < len(name); i++ {
+		data = append(data, int(name[i]))
+	}
+	data = append(data, t.previous...)
+	data = append(data, t.bindings...)
+	t.previous = sha256.Sum(t.api, data)
+	t.bindings = nil
+	return t.previous
+}
+
+// fromDigest returns the element of 𝔽_r of the big-endian bytes of a digest,
+// as fr.Element.SetBytes does.
+func fromDigest(api frontend.API, f *emulated.Field[BLS12377Fr], digest []frontend.Variable) *emulated.Element[BLS12377Fr] {
+	b := make([]frontend.Variable, 0, 8*len(digest))
+	for i := len(digest) - 1; i >= 0; i-- {
+		b = append(b, api.ToBinary(digest[i], 8)...)
+	}
+	return f.FromBits(b...)
+}
+
+// g1Bytes returns the uncompressed encoding of p, as bls12377.G1Affine.RawBytes
+// does. p must not be the point at infinity.
+func g1Bytes(api frontend.API, p G1Affine) []frontend.Variable {
+	fp := ecc.BLS12_377.BaseField()
+	x := toBytes(api, canonicalBits(api, p.X, fp), bls12377.SizeOfG1AffineCompressed)
+	y := toBytes(api, canonicalBits(api, p.Y, fp), bls12377.SizeOfG1AffineCompressed)
+	return append(x, y...)
+}
+
+// canonicalBits returns the little-endian bits of x, which are asserted to
+// be the ones of an integer less than modulus.
+func canonicalBits(api frontend.API, x frontend.Variable, modulus *big.Int) []frontend.Variable {
+	b := api.ToBinary(x, modulus.BitLen())
+	bound := new(big.Int).Sub(modulus, big.NewInt(1))
+	// eq is 1 as long as the high bits are equal to the ones of bound
+	var eq frontend.Variable = 1
+	for i := len(b) - 1; i >= 0; i-- {
+		if bound.Bit(i) == 0 {
+			api.AssertIsEqual(api.Mul(eq, b[i]), 0)
+		} else {
+			eq = api.Mul(eq, b[i])
+		}
+	}
+	return b
+}
+
+// toBytes returns the n big-endian bytes of the little-endian bits b.
+func toBytes(api frontend.API, b []frontend.Variable, n int) []frontend.Variable {
+	padded := make([]frontend.Variable, 8*n)
+	for i := range padded {
+		if i < len(b) {
+			padded[i] = b[i]
+		} else {
+			padded[i] = 0
+		}
+	}
+	res := make([]frontend.Variable, n)
+	for i := range res {
+		j := 8 * (n - 1 - i)
+		res[i] = api.FromBinary(padded[j : j+8]...)
+	}
+	return res
+}
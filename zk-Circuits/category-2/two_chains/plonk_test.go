@@ -0,0 +1,149 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package two_chains
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark/backend/plonk"
+	plonk_bls12377 "github.com/consensys/gnark/backend/plonk/bls12-377"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/profile"
+	"github.com/consensys/gnark/test"
+)
+
+// innerPlonkCircuit proves the knowledge of a cube root X of Y - X - 5. The
+// constant makes the commitment to qk different from the point at infinity.
+type innerPlonkCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *innerPlonkCircuit) Define(api frontend.API) error {
+	x3 := api.Mul(circuit.X, circuit.X, circuit.X)
+	api.AssertIsEqual(circuit.Y, api.Add(x3, circuit.X, 5))
+	return nil
+}
+
+// innerPlonkProof returns a verifying key, a proof and its public witness for
+// the inner circuit.
+func innerPlonkProof() (*plonk_bls12377.VerifyingKey, *plonk_bls12377.Proof, fr.Vector, error) {
+	ccs, err := frontend.Compile(ecc.BLS12_377.ScalarField(), scs.NewBuilder, &innerPlonkCircuit{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	srs, err := test.NewKZGSRS(ccs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pk, vk, err := plonk.Setup(ccs, srs)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	w, err := frontend.NewWitness(&innerPlonkCircuit{X: 3, Y: 35}, ecc.BLS12_377.ScalarField())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	proof, err := plonk.Prove(ccs, pk, w)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pw, err := w.Public()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := plonk.Verify(proof, vk, pw); err != nil {
+		return nil, nil, nil, err
+	}
+	return vk.(*plonk_bls12377.VerifyingKey), proof.(*plonk_bls12377.Proof), pw.Vector().(fr.Vector), nil
+}
+
+type verifierPlonk struct {
+	InnerProof PlonkProof
+	InnerVk    PlonkVerifyingKey `gnark:",public"`
+	Y          frontend.Variable `gnark:",public"`
+}
+
+func (circuit *verifierPlonk) Define(api frontend.API) error {
+	verifier := NewVerifier(api)
+	return verifier.VerifyPlonk(circuit.InnerVk, circuit.InnerProof, []frontend.Variable{circuit.Y})
+}
+
+func TestVerifyPlonk(t *testing.T) {
+	assert := test.NewAssert(t)
+	vk, proof, pw, err := innerPlonkProof()
+	assert.NoError(err)
+
+	var circuit, witness verifierPlonk
+	assert.NoError(circuit.InnerVk.Assign(vk))
+	assert.NoError(witness.InnerVk.Assign(vk))
+	witness.InnerProof.Assign(proof)
+	witness.Y = pw[0].BigInt(new(big.Int))
+	err = test.IsSolved(&circuit, &witness, ecc.BW6_761.ScalarField())
+	assert.NoError(err)
+
+	// wrong public input
+	witness.Y = 36
+	err = test.IsSolved(&circuit, &witness, ecc.BW6_761.ScalarField())
+	assert.Error(err)
+
+	// wrong claimed value
+	witness.Y = pw[0].BigInt(new(big.Int))
+	witness.InnerProof.BatchedProof.ClaimedValues[2] = 1
+	err = test.IsSolved(&circuit, &witness, ecc.BW6_761.ScalarField())
+	assert.Error(err)
+
+	// wrong opening proof
+	witness.InnerProof.Assign(proof)
+	witness.InnerProof.BatchedProof.H, witness.InnerProof.ZShiftedOpening.H = witness.InnerProof.ZShiftedOpening.H, witness.InnerProof.BatchedProof.H
+	err = test.IsSolved(&circuit, &witness, ecc.BW6_761.ScalarField())
+	assert.Error(err)
+
+	// commitment off the curve
+	witness.InnerProof.Assign(proof)
+	witness.InnerProof.H[2] = G1Affine{X: 0, Y: 0}
+	err = test.IsSolved(&circuit, &witness, ecc.BW6_761.ScalarField())
+	assert.Error(err)
+
+	// commitment of order 3
+	witness.InnerProof.Assign(proof)
+	witness.InnerProof.Z = G1Affine{X: 0, Y: 1}
+	err = test.IsSolved(&circuit, &witness, ecc.BW6_761.ScalarField())
+	assert.Error(err)
+}
+
+// bench
+func BenchmarkVerifyPlonk(b *testing.B) {
+	vk, _, _, err := innerPlonkProof()
+	if err != nil {
+		b.Fatal(err)
+	}
+	var c verifierPlonk
+	if err := c.InnerVk.Assign(vk); err != nil {
+		b.Fatal(err)
+	}
+	p := profile.Start()
+	_, _ = frontend.Compile(ecc.BW6_761.ScalarField(), r1cs.NewBuilder, &c)
+	p.Stop()
+	fmt.Println("⏱️  PLONK verifier on BLS12-377 in a BW6-761 R1CS circuit: ", p.NbConstraints())
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package two_chains
+
+import (
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+)
+
+// FixedLines are the lines of the Miller loop for a fixed G2 argument Q, as
+// used by MillerLoopFixedQ. For each iteration i, lines[0][i], lines[1][i] are
+// the coefficients R0 and R1 of a first line and, when loopCounter[i] = 1,
+// lines[2][i] and lines[3][i] those of a second line:
+//
+//   - for loopCounter[i] = 0, the tangent at the accumulated point T,
+//   - for loopCounter[i] = 1, the line through T and Q and the line through
+//     T+Q and T.
+//
+// The lines are circuit constants, so that their evaluations at a variable P
+// cost no constraint.
+type FixedLines [4][63]E2
+
+// PrecomputedLines are the lines of the Miller loop for the canonical
+// generator of G2.
+var PrecomputedLines FixedLines
+
+func init() {
+	_, _, _, g2 := bls12377.Generators()
+	PrecomputedLines = PrecomputeLines(g2)
+}
+
+// PrecomputeLines computes out of circuit the lines of the Miller loop for the
+// fixed G2 argument Q. Q must be in G2 and not the point at infinity.
+func PrecomputeLines(Q bls12377.G2Affine) FixedLines {
+	var lines FixedLines
+	var l1, l2 nativeLine
+
+	Qacc := Q
+	for i := 62; i >= 0; i-- {
+		if loopCounter[i] == 0 {
+			l1 = nativeDoubleStep(&Qacc)
+		} else {
+			l1, l2 = nativeDoubleAndAddStep(&Qacc, &Q)
+			lines[2][i].Assign(&l2.R0)
+			lines[3][i].Assign(&l2.R1)
+		}
+		lines[0][i].Assign(&l1.R0)
+		lines[1][i].Assign(&l1.R1)
+	}
+
+	return lines
+}
+
+// nativeLine is the out-of-circuit counterpart of lineEvaluation.
+type nativeLine struct {
+	R0, R1 bls12377.E2
+}
+
+// newNativeLine returns the line of slope λ passing through p:
+// R0 = -λ and R1 = λ⋅p.x - p.y.
+func newNativeLine(λ *bls12377.E2, p *bls12377.G2Affine) nativeLine {
+	var l nativeLine
+	l.R0.Neg(λ)
+	l.R1.Mul(λ, &p.X).Sub(&l.R1, &p.Y)
+	return l
+}
+
+// nativeDoubleStep is the out-of-circuit counterpart of doubleStep. It sets p
+// to 2p and returns the tangent line.
+func nativeDoubleStep(p *bls12377.G2Affine) nativeLine {
+	// λ = 3x²/2y
+	var n, d, λ bls12377.E2
+	n.Square(&p.X)
+	d.Double(&n)
+	n.Add(&n, &d)
+	d.Double(&p.Y)
+	λ.Inverse(&d).Mul(&λ, &n)
+	l := newNativeLine(&λ, p)
+
+	// xr = λ²-2x, yr = λ(x-xr)-y
+	var xr, yr bls12377.E2
+	xr.Square(&λ).Sub(&xr, &p.X).Sub(&xr, &p.X)
+	yr.Sub(&p.X, &xr).Mul(&yr, &λ).Sub(&yr, &p.Y)
+	p.X, p.Y = xr, yr
+
+	return l
+}
+
+// nativeDoubleAndAddStep is the out-of-circuit counterpart of
+// doubleAndAddStep. It sets p1 to 2p1+p2 and returns the line through p1 and
+// p2 and the line through p1+p2 and p1.
+func nativeDoubleAndAddStep(p1, p2 *bls12377.G2Affine) (nativeLine, nativeLine) {
+	// λ1 = (y1-y2)/(x1-x2)
+	var n, d, λ1, λ2, x3, x4, y4 bls12377.E2
+	n.Sub(&p1.Y, &p2.Y)
+	d.Sub(&p1.X, &p2.X)
+	λ1.Inverse(&d).Mul(&λ1, &n)
+	l1 := newNativeLine(&λ1, p1)
+
+	// x3 = λ1²-x1-x2
+	x3.Square(&λ1).Sub(&x3, &p1.X).Sub(&x3, &p2.X)
+
+	// λ2 = -λ1-2y1/(x3-x1)
+	n.Double(&p1.Y)
+	d.Sub(&x3, &p1.X)
+	λ2.Inverse(&d).Mul(&λ2, &n).Add(&λ2, &λ1).Neg(&λ2)
+	l2 := newNativeLine(&λ2, p1)
+
+	// x4 = λ2²-x1-x3, y4 = λ2(x1-x4)-y1
+	x4.Square(&λ2).Sub(&x4, &p1.X).Sub(&x4, &x3)
+	y4.Sub(&p1.X, &x4).Mul(&y4, &λ2).Sub(&y4, &p1.Y)
+	p1.X, p1.Y = x4, y4
+
+	return l1, l2
+}
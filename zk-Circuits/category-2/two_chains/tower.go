@@ -231,6 +231,11 @@ func (e *E2) AssertIsEqual(api frontend.API, other E2) {
 	api.AssertIsEqual(e.A1, other.A1)
 }
 
+// IsZero returns 1 if e is zero, 0 otherwise
+func (e *E2) IsZero(api frontend.API) frontend.Variable {
+	return api.And(api.IsZero(e.A0), api.IsZero(e.A1))
+}
+
 // Select sets e to r1 if b=1, r2 otherwise
 func (e *E2) Select(api frontend.API, b frontend.Variable, r1, r2 E2) *E2 {
 
@@ -518,6 +523,20 @@ func (e *E6) AssertIsEqual(api frontend.API, other E6) {
 	e.B2.AssertIsEqual(api, other.B2)
 }
 
+// IsZero returns 1 if e is zero, 0 otherwise
+func (e *E6) IsZero(api frontend.API) frontend.Variable {
+	res := api.And(e.B0.IsZero(api), e.B1.IsZero(api))
+	return api.And(res, e.B2.IsZero(api))
+}
+
+// Select sets e to r1 if b=1, r2 otherwise
+func (e *E6) Select(api frontend.API, b frontend.Variable, r1, r2 E6) *E6 {
+	e.B0.Select(api, b, r1.B0, r2.B0)
+	e.B1.Select(api, b, r1.B1, r2.B1)
+	e.B2.Select(api, b, r1.B2, r2.B2)
+	return e
+}
+
 // MulByE2 multiplies an element in E6 by an element in E2
 func (e *E6) MulByE2(api frontend.API, e1 E6, e2 E2) *E6 {
 	e.B0.Mul(api, e1.B0, e2)
@@ -825,9 +844,20 @@ func (e *E12) CyclotomicSquareCompressed(api frontend.API, x E12) *E12 {
 }
 
 // Decompress Karabina's cyclotomic square result
+//
+// It assumes g3 ≠ 0, see DecompressSafe otherwise.
 func (e *E12) Decompress(api frontend.API, x E12) *E12 {
+	return e.decompress(api, x, false)
+}
 
-	// TODO: hadle the g3==0 case with MUX
+// DecompressSafe decompresses Karabina's cyclotomic square result, including
+// the edge cases g3 = 0, where g4 = 2⋅g1⋅g5/g2, and g2 = g3 = 0, where the
+// result is 1.
+func (e *E12) DecompressSafe(api frontend.API, x E12) *E12 {
+	return e.decompress(api, x, true)
+}
+
+func (e *E12) decompress(api frontend.API, x E12, safe bool) *E12 {
 
 	var t [3]E2
 	var one E2
@@ -846,6 +876,20 @@ func (e *E12) Decompress(api frontend.API, x E12) *E12 {
 	// t1 = 4 * g3
 	t[1].Double(api, x.C1.B0).
 		Double(api, t[1])
+
+	var g3IsZero, g2g3AreZero frontend.Variable
+	if safe {
+		// if g3 = 0: t0 = 2 * g1 * g5, t1 = g2
+		g3IsZero = x.C1.B0.IsZero(api)
+		t[2].Mul(api, x.C0.B1, x.C1.B2).
+			Double(api, t[2])
+		t[0].Select(api, g3IsZero, t[2], t[0])
+		t[1].Select(api, g3IsZero, x.C0.B2, t[1])
+		// if g2 = g3 = 0, the result is 1: t1 = 1 so that g4 is determined
+		g2g3AreZero = api.And(g3IsZero, x.C0.B2.IsZero(api))
+		t[1].Select(api, g2g3AreZero, one, t[1])
+	}
+
 	// z4 = g4 / t1
 	e.C1.B1.DivUnchecked(api, t[0], t[1])
 
@@ -868,6 +912,12 @@ func (e *E12) Decompress(api frontend.API, x E12) *E12 {
 	e.C1.B0 = x.C1.B0
 	e.C1.B2 = x.C1.B2
 
+	if safe {
+		var res1 E12
+		res1.SetOne()
+		e.Select(api, g2g3AreZero, res1, *e)
+	}
+
 	return e
 }
 
@@ -1083,6 +1133,11 @@ func (e *E12) DivUnchecked(api frontend.API, e1, e2 E12) *E12 {
 	return e
 }
 
+// IsZero returns 1 if e is zero, 0 otherwise
+func (e *E12) IsZero(api frontend.API) frontend.Variable {
+	return api.And(e.C0.IsZero(api), e.C1.IsZero(api))
+}
+
 // Select sets e to r1 if b=1, r2 otherwise
 func (e *E12) Select(api frontend.API, b frontend.Variable, r1, r2 E12) *E12 {
 
@@ -1204,27 +1259,31 @@ func (e *E12) MulBy01234(api frontend.API, x [5]E2) *E12 {
 	return e
 }
 
-// Expt compute e1**exponent, where the exponent is hardcoded
-// This function is only used for the final expo of the pairing for bls12377, so the exponent is supposed to be hardcoded
-// and on 64 bits.
-func (e *E12) Expt(api frontend.API, e1 E12, exponent uint64) *E12 {
+// Expt computes e1**x₀, where x₀ = 9586122913090633729 is the seed of
+// bls12377, hardcoded as an addition chain. e1 must be in the cyclotomic
+// subgroup and the compressed squares must have g3 ≠ 0 (see DecompressSafe).
+func (e *E12) Expt(api frontend.API, e1 E12) *E12 {
+	return e.expt(api, e1, false)
+}
+
+func (e *E12) expt(api frontend.API, e1 E12, safe bool) *E12 {
 
 	res := e1
 
 	res.nSquareCompressed(api, 5)
-	res.Decompress(api, res)
+	res.decompress(api, res, safe)
 	res.Mul(api, res, e1)
 	x33 := res
 	res.nSquareCompressed(api, 7)
-	res.Decompress(api, res)
+	res.decompress(api, res, safe)
 	res.Mul(api, res, x33)
 	res.nSquareCompressed(api, 4)
-	res.Decompress(api, res)
+	res.decompress(api, res, safe)
 	res.Mul(api, res, e1)
 	res.CyclotomicSquare(api, res)
 	res.Mul(api, res, e1)
 	res.nSquareCompressed(api, 46)
-	res.Decompress(api, res)
+	res.decompress(api, res, safe)
 	res.Mul(api, res, e1)
 
 	*e = res
@@ -1232,3 +1291,173 @@ func (e *E12) Expt(api frontend.API, e1 E12, exponent uint64) *E12 {
 	return e
 
 }
+
+/*
+	Torus-based arithmetic
+
+	After the easy part of the final exponentiation the elements are in a
+	proper subgroup of 𝔽p¹² that coincides with some algebraic tori. We use
+	T₂(𝔽p⁶): an element x = x.C0 + x.C1⋅w ≠ ±1 of G_{q,2} = {m ∈ 𝔽q² | m^(q+1) = 1},
+	q = p⁶, is compressed to y = (x.C0+1)/x.C1 ∈ 𝔽p⁶ and decompressed as
+	x = (y+w)/(y-w).
+
+	Reference: https://www.math.uci.edu/~asilverb/bibliography/ceilidh.pdf
+*/
+
+// inverse of w^(p-1) and w^(p²-1), see FrobeniusTorus and FrobeniusSquareTorus
+var frobwInv, frob2wInv *big.Int
+
+func init() {
+	frobwInv = new(big.Int).ModInverse(ext.frobw, fr.Modulus())
+	frob2wInv = new(big.Int).ModInverse(ext.frob2w, fr.Modulus())
+}
+
+// CompressTorus compresses x ∈ E12 to (x.C0 + 1)/x.C1 ∈ E6
+func (e *E6) CompressTorus(api frontend.API, x E12) *E6 {
+	// x ∈ G_{q,2} \ {-1,1}
+	var one, y E6
+	one.SetOne()
+	y.Add(api, x.C0, one)
+	e.DivUnchecked(api, y, x.C1)
+	return e
+}
+
+// DecompressTorus decompresses y ∈ E6 to (y+w)/(y-w) ∈ E12
+func (e *E12) DecompressTorus(api frontend.API, y E6) *E12 {
+	var n, d E12
+	n.C0 = y
+	n.C1.SetOne()
+	d.C0 = y
+	d.C1.SetOne()
+	d.C1.Neg(api, d.C1)
+	e.DivUnchecked(api, n, d)
+	return e
+}
+
+// MulTorus multiplies two compressed elements y1, y2 ∈ E6
+// and returns (y1 * y2 + v)/(y1 + y2)
+//
+// N.B.: y1 must be different from -y2, otherwise the product is 1, which has
+// no compressed form. As v is not a square in E6, y1 * y2 + v is then nonzero
+// and the circuit is unsatisfiable.
+func (e *E6) MulTorus(api frontend.API, y1, y2 E6) *E6 {
+	var n, d E6
+	n.Mul(api, y1, y2)
+	n.B1.A0 = api.Add(n.B1.A0, 1)
+	d.Add(api, y1, y2)
+	e.DivUnchecked(api, n, d)
+	return e
+}
+
+// InverseTorus inverses a compressed elements y ∈ E6
+// and returns -y
+func (e *E6) InverseTorus(api frontend.API, y E6) *E6 {
+	return e.Neg(api, y)
+}
+
+var SquareTorusHint = func(_ *big.Int, inputs []*big.Int, res []*big.Int) error {
+	var a, c bls12377.E6
+
+	a.B0.A0.SetBigInt(inputs[0])
+	a.B0.A1.SetBigInt(inputs[1])
+	a.B1.A0.SetBigInt(inputs[2])
+	a.B1.A1.SetBigInt(inputs[3])
+	a.B2.A0.SetBigInt(inputs[4])
+	a.B2.A1.SetBigInt(inputs[5])
+
+	// c = (a + v/a)/2
+	c.Inverse(&a).MulByNonResidue(&c).Add(&c, &a)
+	c.B0.Halve()
+	c.B1.Halve()
+	c.B2.Halve()
+
+	c.B0.A0.BigInt(res[0])
+	c.B0.A1.BigInt(res[1])
+	c.B1.A0.BigInt(res[2])
+	c.B1.A1.BigInt(res[3])
+	c.B2.A0.BigInt(res[4])
+	c.B2.A1.BigInt(res[5])
+
+	return nil
+}
+
+func init() {
+	solver.RegisterHint(SquareTorusHint)
+}
+
+// SquareTorus squares a compressed elements y ∈ E6
+// and returns (y + v/y)/2
+//
+// It uses a hint to verify that (2x-y)y = v saving one E6 AssertIsEqual.
+//
+// N.B.: y must be nonzero. y = 0 is the compressed form of -1, whose square 1
+// has no compressed form, and the circuit is then unsatisfiable.
+func (e *E6) SquareTorus(api frontend.API, y E6) *E6 {
+
+	res, err := api.NewHint(SquareTorusHint, 6, y.B0.A0, y.B0.A1, y.B1.A0, y.B1.A1, y.B2.A0, y.B2.A1)
+	if err != nil {
+		// err is non-nil only for invalid number of inputs
+		panic(err)
+	}
+
+	var sq, v, _v E6
+	sq.assign(res[:6])
+
+	// v = (2x-y)y
+	v.Add(api, sq, sq).Sub(api, v, y).Mul(api, v, y)
+	_v.SetZero()
+	_v.B1.SetOne()
+	v.AssertIsEqual(api, _v)
+
+	e.assign(res[:6])
+
+	return e
+}
+
+// FrobeniusTorus raises a compressed elements y ∈ E6 to the modulus p
+// and returns y^p / w^(p-1)
+func (e *E6) FrobeniusTorus(api frontend.API, y E6) *E6 {
+	e.B0.Conjugate(api, y.B0).MulByFp(api, e.B0, frobwInv)
+	e.B1.Conjugate(api, y.B1).MulByFp(api, e.B1, new(big.Int).Mul(ext.frobv, frobwInv))
+	e.B2.Conjugate(api, y.B2).MulByFp(api, e.B2, new(big.Int).Mul(ext.frobv2, frobwInv))
+	return e
+}
+
+// FrobeniusSquareTorus raises a compressed elements y ∈ E6 to the square
+// modulus p² and returns y^(p²) / w^(p²-1)
+func (e *E6) FrobeniusSquareTorus(api frontend.API, y E6) *E6 {
+	e.B0.MulByFp(api, y.B0, frob2wInv)
+	e.B1.MulByFp(api, y.B1, new(big.Int).Mul(ext.frob2v, frob2wInv))
+	e.B2.MulByFp(api, y.B2, new(big.Int).Mul(ext.frob2v2, frob2wInv))
+	return e
+}
+
+// nSquareTorus repeated torus square
+func (e *E6) nSquareTorus(api frontend.API, n int) {
+	for i := 0; i < n; i++ {
+		e.SquareTorus(api, *e)
+	}
+}
+
+// ExptTorus computes y**x₀ for a compressed element y ∈ E6, with the same
+// addition chain as Expt.
+func (e *E6) ExptTorus(api frontend.API, y E6) *E6 {
+
+	res := y
+
+	res.nSquareTorus(api, 5)
+	res.MulTorus(api, res, y)
+	x33 := res
+	res.nSquareTorus(api, 7)
+	res.MulTorus(api, res, x33)
+	res.nSquareTorus(api, 4)
+	res.MulTorus(api, res, y)
+	res.SquareTorus(api, res)
+	res.MulTorus(api, res, y)
+	res.nSquareTorus(api, 46)
+	res.MulTorus(api, res, y)
+
+	*e = res
+
+	return e
+}
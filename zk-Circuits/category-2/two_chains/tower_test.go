@@ -620,6 +620,66 @@ func TestFp12CyclotomicSquareCompressed(t *testing.T) {
 
 }
 
+type fp12DecompressSafe struct {
+	A E12
+	B E12 `gnark:",public"`
+}
+
+func (circuit *fp12DecompressSafe) Define(api frontend.API) error {
+
+	var u, v E12
+	u.CyclotomicSquare(api, circuit.A)
+	v.CyclotomicSquareCompressed(api, circuit.A)
+	v.DecompressSafe(api, v)
+	u.AssertIsEqual(api, v)
+	u.AssertIsEqual(api, circuit.B)
+	return nil
+}
+
+func TestFp12DecompressSafe(t *testing.T) {
+
+	assert := test.NewAssert(t)
+
+	// witness values
+	var b bls12377.E12
+	a := cyclotomicRandom()
+	b.CyclotomicSquare(&a)
+
+	var witness fp12DecompressSafe
+	witness.A.Assign(&a)
+	witness.B.Assign(&b)
+	assert.SolvingSucceeded(&fp12DecompressSafe{}, &witness, test.WithCurves(ecc.BW6_761))
+
+	// g3 = 0, g2 ≠ 0: a = √x where x is the image by the easy part of
+	// y = 1 + s⋅v² + w, s being a root of the g3 coordinate of x.
+	var y, x bls12377.E12
+	y.C0.B0.SetOne()
+	y.C0.B2.A0.SetString("57790210042332335617173999225659812348373374772748021242678811074878304201669079666043364385244312496781319793956")
+	y.C0.B2.A1.SetString("48249640391195063061725869272732195155073639993126469023738900894765724554915630958620025023937639746558516001282")
+	y.C1.B0.SetOne()
+	x.Conjugate(&y)
+	y.Inverse(&y)
+	x.Mul(&x, &y)
+	y.FrobeniusSquare(&x)
+	x.Mul(&x, &y)
+	assert.True(x.C1.B0.IsZero() && !x.C0.B2.IsZero())
+	// x has order dividing Φ₁₂(p) = p⁴ - p² + 1, which is odd
+	p := fp.Modulus()
+	p2 := new(big.Int).Mul(p, p)
+	e := new(big.Int).Mul(p2, p2)
+	e.Sub(e, p2).Add(e, big.NewInt(2)).Rsh(e, 1)
+	a.Exp(x, e)
+	witness.A.Assign(&a)
+	witness.B.Assign(&x)
+	assert.SolvingSucceeded(&fp12DecompressSafe{}, &witness, test.WithCurves(ecc.BW6_761))
+
+	// g2 = g3 = 0
+	a.SetOne()
+	witness.A.Assign(&a)
+	witness.B.Assign(&a)
+	assert.SolvingSucceeded(&fp12DecompressSafe{}, &witness, test.WithCurves(ecc.BW6_761))
+}
+
 type fp12Conjugate struct {
 	A E12
 	C E12 `gnark:",public"`
@@ -752,9 +812,7 @@ type fp12FixedExpo struct {
 
 func (circuit *fp12FixedExpo) Define(api frontend.API) error {
 	expected := E12{}
-
-	expo := uint64(9586122913090633729)
-	expected.Expt(api, circuit.A, expo)
+	expected.Expt(api, circuit.A)
 	expected.AssertIsEqual(api, circuit.C)
 	return nil
 }
@@ -820,3 +878,138 @@ func TestFp12MulBy034(t *testing.T) {
 	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
 
 }
+
+// cyclotomicRandom returns a random element of the cyclotomic subgroup
+func cyclotomicRandom() bls12377.E12 {
+	var a, b bls12377.E12
+	_, _ = a.SetRandom()
+	b.Conjugate(&a)
+	a.Inverse(&a)
+	b.Mul(&b, &a)
+	a.FrobeniusSquare(&b).Mul(&a, &b)
+	return a
+}
+
+type fp12Torus struct {
+	A           E12
+	Ac, Bc      E6
+	Square, Mul E6 `gnark:",public"`
+}
+
+func (circuit *fp12Torus) Define(api frontend.API) error {
+
+	var ac, sq, mul E6
+	ac.CompressTorus(api, circuit.A)
+	ac.AssertIsEqual(api, circuit.Ac)
+
+	sq.SquareTorus(api, circuit.Ac)
+	sq.AssertIsEqual(api, circuit.Square)
+
+	mul.MulTorus(api, circuit.Ac, circuit.Bc)
+	mul.AssertIsEqual(api, circuit.Mul)
+
+	var a E12
+	a.DecompressTorus(api, circuit.Ac)
+	a.AssertIsEqual(api, circuit.A)
+
+	return nil
+}
+
+func TestTorusFp12(t *testing.T) {
+
+	var circuit, witness fp12Torus
+
+	// witness values
+	a := cyclotomicRandom()
+	b := cyclotomicRandom()
+	ac, _ := a.CompressTorus()
+	bc, _ := b.CompressTorus()
+
+	var sq, mul bls12377.E12
+	sq.CyclotomicSquare(&a)
+	mul.Mul(&a, &b)
+	sqc, _ := sq.CompressTorus()
+	mulc, _ := mul.CompressTorus()
+
+	witness.A.Assign(&a)
+	witness.Ac.Assign(&ac)
+	witness.Bc.Assign(&bc)
+	witness.Square.Assign(&sqc)
+	witness.Mul.Assign(&mulc)
+
+	// cs values
+	assert := test.NewAssert(t)
+	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+}
+
+type fp12FrobeniusTorus struct {
+	A    E6
+	C, D E6 `gnark:",public"`
+}
+
+func (circuit *fp12FrobeniusTorus) Define(api frontend.API) error {
+
+	fb := E6{}
+	fb.FrobeniusTorus(api, circuit.A)
+	fb.AssertIsEqual(api, circuit.C)
+
+	fbSquare := E6{}
+	fbSquare.FrobeniusSquareTorus(api, circuit.A)
+	fbSquare.AssertIsEqual(api, circuit.D)
+
+	return nil
+}
+
+func TestFrobeniusTorusFp12(t *testing.T) {
+
+	var circuit, witness fp12FrobeniusTorus
+
+	// witness values
+	var c, d bls12377.E12
+	a := cyclotomicRandom()
+	c.Frobenius(&a)
+	d.FrobeniusSquare(&a)
+	ac, _ := a.CompressTorus()
+	cc, _ := c.CompressTorus()
+	dc, _ := d.CompressTorus()
+
+	witness.A.Assign(&ac)
+	witness.C.Assign(&cc)
+	witness.D.Assign(&dc)
+
+	// cs values
+	assert := test.NewAssert(t)
+	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+}
+
+type fp12FixedExpoTorus struct {
+	A E6
+	C E6 `gnark:",public"`
+}
+
+func (circuit *fp12FixedExpoTorus) Define(api frontend.API) error {
+	expected := E6{}
+
+	expected.ExptTorus(api, circuit.A)
+	expected.AssertIsEqual(api, circuit.C)
+	return nil
+}
+
+func TestExpFixedExpoTorusFp12(t *testing.T) {
+	var circuit, witness fp12FixedExpoTorus
+
+	// witness values
+	var c bls12377.E12
+	expo := uint64(9586122913090633729)
+	a := cyclotomicRandom()
+	c.Exp(a, new(big.Int).SetUint64(expo))
+	ac, _ := a.CompressTorus()
+	cc, _ := c.CompressTorus()
+
+	witness.A.Assign(&ac)
+	witness.C.Assign(&cc)
+
+	// cs values
+	assert := test.NewAssert(t)
+	assert.SolvingSucceeded(&circuit, &witness, test.WithCurves(ecc.BW6_761))
+}